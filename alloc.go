@@ -0,0 +1,59 @@
+package orderedmap
+
+import "github.com/lorenzosaino/go-orderedmap/list"
+
+// Allocator allocates the internal elements holding the items of a map.
+//
+// Alloc returns an element which is not part of any list. Free is called
+// with the elements of items deleted, evicted or cleared from the map, after
+// they have been zeroed, and may recycle them or do nothing, for example if
+// they are released all at once by the allocator.
+type Allocator[K comparable, V any] interface {
+	Alloc() *list.Element[Item[K, V]]
+	Free(el *list.Element[Item[K, V]])
+}
+
+// WithAllocator configures the map to allocate the internal elements holding
+// its items with a, for example an arena owned by the caller whose memory is
+// released all at once when the map is no longer used, as for maps scoped to
+// a single request. It takes precedence over WithElementPool.
+//
+// The same restrictions on Entry and Cursor as for WithElementPool apply if a
+// recycles elements. Copies of the map, such as those returned by Clone,
+// allocate from a too, and elements moved to another map by Splice methods
+// are freed by the allocator of that map. The allocator must therefore
+// outlive all of them.
+//
+// The key and value types of a must match those of the map, or New panics.
+func WithAllocator[K comparable, V any](a Allocator[K, V]) Option {
+	return func(o *options) {
+		o.allocator = a
+	}
+}
+
+// alloc returns a new element holding item.
+func (m *OrderedMap[K, V]) alloc(item Item[K, V]) *list.Element[Item[K, V]] {
+	var el *list.Element[Item[K, V]]
+	switch {
+	case m.allocator != nil:
+		el = m.allocator.Alloc()
+	case m.pool != nil:
+		el = m.pool.Get().(*list.Element[Item[K, V]])
+	default:
+		return &list.Element[Item[K, V]]{Value: item}
+	}
+	el.Value = item
+	return el
+}
+
+// free releases an element removed from the map, if it is recycled.
+func (m *OrderedMap[K, V]) free(el *list.Element[Item[K, V]]) {
+	switch {
+	case m.allocator != nil:
+		*el = list.Element[Item[K, V]]{}
+		m.allocator.Free(el)
+	case m.pool != nil:
+		*el = list.Element[Item[K, V]]{}
+		m.pool.Put(el)
+	}
+}
@@ -0,0 +1,93 @@
+package orderedmap
+
+import (
+	"testing"
+
+	"github.com/lorenzosaino/go-orderedmap/list"
+)
+
+// arena allocates elements from chunks which are released all at once by
+// Reset, and counts the elements freed.
+type arena[K comparable, V any] struct {
+	chunk []list.Element[Item[K, V]]
+	size  int
+	alloc int
+	freed int
+}
+
+func (a *arena[K, V]) Alloc() *list.Element[Item[K, V]] {
+	if len(a.chunk) == 0 {
+		a.chunk = make([]list.Element[Item[K, V]], a.size)
+	}
+	el := &a.chunk[0]
+	a.chunk = a.chunk[1:]
+	a.alloc++
+	return el
+}
+
+func (a *arena[K, V]) Free(el *list.Element[Item[K, V]]) {
+	if el.Value.Key != *new(K) || el.Next() != nil {
+		panic("element not zeroed")
+	}
+	a.freed++
+}
+
+func (a *arena[K, V]) Reset() {
+	a.chunk = nil
+}
+
+func TestWithAllocator(t *testing.T) {
+	a := &arena[int, string]{size: 4}
+	m := New[int, string](WithAllocator[int, string](a), WithElementPool(), WithPositionIndex())
+	for i := 0; i < 10; i++ {
+		m.Set(i, "v")
+	}
+	m.PushFront(10, "front")
+	m.Delete(0)
+	m.Delete(5)
+	m.Set(1, "updated")
+	checkAll(t, m, []Item[int, string]{
+		{10, "front"}, {1, "updated"}, {2, "v"}, {3, "v"}, {4, "v"},
+		{6, "v"}, {7, "v"}, {8, "v"}, {9, "v"},
+	})
+	if a.alloc != 11 || a.freed != 2 {
+		t.Fatalf("unexpected allocations: alloc=%d, freed=%d", a.alloc, a.freed)
+	}
+
+	clone := m.Clone()
+	if a.alloc != 20 {
+		t.Fatalf("unexpected allocations after Clone: %d", a.alloc)
+	}
+	clone.Clear()
+	if a.freed != 11 {
+		t.Fatalf("unexpected frees after Clear: %d", a.freed)
+	}
+	m.ClearRetain()
+	if a.freed != 20 {
+		t.Fatalf("unexpected frees after ClearRetain: %d", a.freed)
+	}
+	a.Reset()
+	m.Set(1, "v")
+	checkAll(t, m, []Item[int, string]{{1, "v"}})
+}
+
+func TestWithAllocatorEvict(t *testing.T) {
+	a := &arena[int, int]{size: 8}
+	m := New[int, int](WithAllocator[int, int](a), WithMaxEntries[int, int](2, EvictOldest, nil))
+	for i := 0; i < 5; i++ {
+		m.Set(i, i)
+	}
+	checkAll(t, m, []Item[int, int]{{3, 3}, {4, 4}})
+	if a.alloc != 5 || a.freed != 3 {
+		t.Fatalf("unexpected allocations: alloc=%d, freed=%d", a.alloc, a.freed)
+	}
+}
+
+func TestWithAllocatorInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	New[int, int](WithAllocator[int, string](&arena[int, string]{size: 1}))
+}
@@ -0,0 +1,152 @@
+package orderedmap
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// binaryVersion is the version of the binary encoding produced by MarshalBinary.
+const binaryVersion = 1
+
+// ErrInvalidBinary indicates that the data passed to UnmarshalBinary is not a
+// valid binary encoding of an ordered map.
+var ErrInvalidBinary = errors.New("invalid binary encoding")
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+//
+// The encoding starts with a version byte followed by the number of items
+// and then by each item, in order. Each item is encoded as its length-prefixed
+// key followed by its length-prefixed value. All lengths are encoded as
+// unsigned varints.
+//
+// Unless codecs are configured with WithKeyCodec or WithValueCodec, keys and
+// values must either implement encoding.BinaryMarshaler or be of a string,
+// byte slice, boolean, integer or floating point kind.
+func (m *OrderedMap[K, V]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+//
+// It replaces the content of the map with the items encoded in data,
+// which must have been produced by MarshalBinary. If data is malformed,
+// it returns an error wrapping ErrInvalidBinary.
+func (m *OrderedMap[K, V]) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	if _, err := m.ReadFrom(r); err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidBinary, r.Len())
+	}
+	return nil
+}
+
+// marshalBinaryValue returns the binary encoding of v, without length prefix.
+func marshalBinaryValue(v any) ([]byte, error) {
+	if bm, ok := v.(encoding.BinaryMarshaler); ok {
+		return bm.MarshalBinary()
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return []byte(rv.String()), nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return rv.Bytes(), nil
+		}
+	case reflect.Bool:
+		if rv.Bool() {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return appendVarint(nil, rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return appendUvarint(nil, rv.Uint()), nil
+	case reflect.Float32:
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, math.Float32bits(float32(rv.Float())))
+		return b, nil
+	case reflect.Float64:
+		b := make([]byte, 8)
+		binary.BigEndian.PutUint64(b, math.Float64bits(rv.Float()))
+		return b, nil
+	}
+	return nil, fmt.Errorf("unsupported type %T", v)
+}
+
+// unmarshalBinaryValue decodes data, as encoded by marshalBinaryValue,
+// into the value pointed to by dst.
+func unmarshalBinaryValue(data []byte, dst any) error {
+	if bu, ok := dst.(encoding.BinaryUnmarshaler); ok {
+		return bu.UnmarshalBinary(data)
+	}
+	rv := reflect.ValueOf(dst).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(string(data))
+		return nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			rv.SetBytes(append([]byte{}, data...))
+			return nil
+		}
+	case reflect.Bool:
+		if len(data) != 1 || data[0] > 1 {
+			return fmt.Errorf("%w: malformed boolean", ErrInvalidBinary)
+		}
+		rv.SetBool(data[0] == 1)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, size := binary.Varint(data)
+		if size != len(data) || size == 0 || rv.OverflowInt(n) {
+			return fmt.Errorf("%w: malformed %s", ErrInvalidBinary, rv.Type())
+		}
+		rv.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, size := binary.Uvarint(data)
+		if size != len(data) || size == 0 || rv.OverflowUint(n) {
+			return fmt.Errorf("%w: malformed %s", ErrInvalidBinary, rv.Type())
+		}
+		rv.SetUint(n)
+		return nil
+	case reflect.Float32:
+		if len(data) != 4 {
+			return fmt.Errorf("%w: malformed %s", ErrInvalidBinary, rv.Type())
+		}
+		rv.SetFloat(float64(math.Float32frombits(binary.BigEndian.Uint32(data))))
+		return nil
+	case reflect.Float64:
+		if len(data) != 8 {
+			return fmt.Errorf("%w: malformed %s", ErrInvalidBinary, rv.Type())
+		}
+		rv.SetFloat(math.Float64frombits(binary.BigEndian.Uint64(data)))
+		return nil
+	}
+	return fmt.Errorf("unsupported type %s", rv.Type())
+}
+
+// appendUvarint appends the unsigned varint encoding of x to b.
+func appendUvarint(b []byte, x uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], x)
+	return append(b, buf[:n]...)
+}
+
+// appendVarint appends the signed varint encoding of x to b.
+func appendVarint(b []byte, x int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(buf[:], x)
+	return append(b, buf[:n]...)
+}
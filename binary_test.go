@@ -0,0 +1,168 @@
+package orderedmap
+
+import (
+	"bytes"
+	"errors"
+	"net/netip"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMarshalBinary(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[string, int]
+		want  []byte
+	}{
+		{
+			name:  "empty",
+			items: []Item[string, int]{},
+			want:  []byte{binaryVersion, 0},
+		},
+		{
+			name:  "multiple items",
+			items: []Item[string, int]{{"b", 2}, {"a", -1}},
+			want:  []byte{binaryVersion, 2, 1, 'b', 1, 4, 1, 'a', 1, 1},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			got, err := m.MarshalBinary()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, c.want) {
+				t.Fatalf("unexpected encoding: want: %v, got %v", c.want, got)
+			}
+		})
+	}
+}
+
+func TestMarshalBinaryUnsupportedValue(t *testing.T) {
+	m := newFromItems(t, []Item[string, []int]{{"a", []int{1}}})
+	if _, err := m.MarshalBinary(); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestUnmarshalBinary(t *testing.T) {
+	cases := []struct {
+		name  string
+		data  []byte
+		items []Item[string, int]
+		want  []Item[string, int]
+		err   error
+	}{
+		{
+			name: "empty",
+			data: []byte{binaryVersion, 0},
+			want: []Item[string, int]{},
+		},
+		{
+			name: "multiple items",
+			data: []byte{binaryVersion, 2, 1, 'b', 1, 4, 1, 'a', 1, 1},
+			want: []Item[string, int]{{"b", 2}, {"a", -1}},
+		},
+		{
+			name:  "replace existing content",
+			data:  []byte{binaryVersion, 1, 1, 'a', 1, 2},
+			items: []Item[string, int]{{"z", 26}},
+			want:  []Item[string, int]{{"a", 1}},
+		},
+		{
+			name: "duplicate key",
+			data: []byte{binaryVersion, 2, 1, 'a', 1, 2, 1, 'a', 1, 4},
+			want: []Item[string, int]{{"a", 1}},
+			err:  ErrKeyAlreadyPresent,
+		},
+		{
+			name: "no data",
+			want: []Item[string, int]{},
+			err:  ErrInvalidBinary,
+		},
+		{
+			name: "unsupported version",
+			data: []byte{binaryVersion + 1, 0},
+			want: []Item[string, int]{},
+			err:  ErrInvalidBinary,
+		},
+		{
+			name: "truncated",
+			data: []byte{binaryVersion, 2, 1, 'a', 1, 2},
+			want: []Item[string, int]{{"a", 1}},
+			err:  ErrInvalidBinary,
+		},
+		{
+			name: "length out of bounds",
+			data: []byte{binaryVersion, 1, 5, 'a'},
+			want: []Item[string, int]{},
+			err:  ErrInvalidBinary,
+		},
+		{
+			name: "trailing bytes",
+			data: []byte{binaryVersion, 0, 1},
+			want: []Item[string, int]{},
+			err:  ErrInvalidBinary,
+		},
+		{
+			name: "malformed value",
+			data: []byte{binaryVersion, 1, 1, 'a', 0},
+			want: []Item[string, int]{},
+			err:  ErrInvalidBinary,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := m.UnmarshalBinary(c.data); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	t.Run("scalars", func(t *testing.T) {
+		testBinaryRoundTrip(t, []Item[uint16, float64]{{3, 1.5}, {1, -2}, {65535, 0}})
+		testBinaryRoundTrip(t, []Item[int8, float32]{{-128, 1.5}, {127, -2}})
+		testBinaryRoundTrip(t, []Item[bool, []byte]{{true, []byte("yes")}, {false, []byte{}}})
+	})
+	t.Run("binary marshaler", func(t *testing.T) {
+		testBinaryRoundTrip(t, []Item[netip.Addr, string]{
+			{netip.MustParseAddr("10.0.0.1"), "a"},
+			{netip.MustParseAddr("::1"), "b"},
+		})
+	})
+}
+
+func TestUnmarshalBinaryOverflow(t *testing.T) {
+	m := newFromItems(t, []Item[string, int]{{"a", 300}})
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var out OrderedMap[string, int8]
+	if err := out.UnmarshalBinary(data); !errors.Is(err, ErrInvalidBinary) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrInvalidBinary, err)
+	}
+}
+
+func testBinaryRoundTrip[K comparable, V any](t *testing.T, items []Item[K, V]) {
+	t.Helper()
+
+	m := newFromItems(t, items)
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var out OrderedMap[K, V]
+	if err := out.UnmarshalBinary(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(items, out.Items(), cmp.Comparer(func(a, b netip.Addr) bool { return a == b })); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,79 @@
+package orderedmap
+
+import (
+	"fmt"
+
+	"github.com/lorenzosaino/go-orderedmap/internal/bson"
+)
+
+// MarshalBSON implements the Marshaler interface of
+// go.mongodb.org/mongo-driver/bson.
+//
+// The map is encoded as a BSON document whose elements are written in order.
+// Keys must either implement encoding.TextMarshaler or be strings, booleans,
+// integers or floating point numbers. Values can be nil, booleans, numbers,
+// strings, byte slices, time.Time, slices, arrays, maps with string keys,
+// structs, pointers to any of these or types implementing MarshalBSON
+// themselves, including other ordered maps.
+func (m *OrderedMap[K, V]) MarshalBSON() ([]byte, error) {
+	b, start := bson.StartDocument(nil)
+	if m.m != nil {
+		for el := m.l.Front(); el != nil; el = el.Next() {
+			name, err := formatText(el.Value.Key)
+			if err != nil {
+				return nil, fmt.Errorf("cannot encode key %v: %w", el.Value.Key, err)
+			}
+			if b, err = bson.AppendElement(b, name, el.Value.Value); err != nil {
+				return nil, fmt.Errorf("cannot encode value of key %v: %w", el.Value.Key, err)
+			}
+		}
+	}
+	return bson.EndDocument(b, start), nil
+}
+
+// UnmarshalBSON implements the Unmarshaler interface of
+// go.mongodb.org/mongo-driver/bson.
+//
+// It replaces the content of the map with the elements of the BSON document
+// data, in the order in which they appear. Embedded documents decoded into
+// values of interface type are decoded as *OrderedMap[string, any] so that
+// their order is preserved too. If an element name appears more than once,
+// it returns an error wrapping ErrKeyAlreadyPresent.
+func (m *OrderedMap[K, V]) UnmarshalBSON(data []byte) error {
+	m.lazyInit()
+	m.Clear()
+	r, err := bson.NewReader(data)
+	if err != nil {
+		return err
+	}
+	d := bson.Decoder{DecodeDocument: decodeBSONDocument}
+	for {
+		name, t, raw, ok, err := r.Next()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		var key K
+		if err := parseText(name, &key); err != nil {
+			return fmt.Errorf("cannot decode element name %q: %w", name, err)
+		}
+		var value V
+		if err := d.Decode(t, raw, &value); err != nil {
+			return fmt.Errorf("cannot decode element %q: %w", name, err)
+		}
+		if err := m.PushBack(key, value); err != nil {
+			return fmt.Errorf("cannot decode element %q: %w", name, err)
+		}
+	}
+}
+
+// decodeBSONDocument decodes an embedded BSON document into an ordered map.
+func decodeBSONDocument(doc []byte) (any, error) {
+	m := New[string, any]()
+	if err := m.UnmarshalBSON(doc); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
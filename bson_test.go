@@ -0,0 +1,152 @@
+package orderedmap
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMarshalBSON(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[string, any]
+		want  []byte
+	}{
+		{
+			name:  "empty",
+			items: []Item[string, any]{},
+			want:  []byte{5, 0, 0, 0, 0},
+		},
+		{
+			name:  "multiple items",
+			items: []Item[string, any]{{"b", true}, {"a", nil}},
+			want:  []byte{12, 0, 0, 0, 0x08, 'b', 0, 1, 0x0a, 'a', 0, 0},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			got, err := m.MarshalBSON()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, c.want) {
+				t.Fatalf("unexpected encoding: want: %x, got %x", c.want, got)
+			}
+		})
+	}
+}
+
+func TestMarshalBSONInvalidKey(t *testing.T) {
+	m := newFromItems(t, []Item[string, int]{{"a\x00", 1}})
+	if _, err := m.MarshalBSON(); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestUnmarshalBSON(t *testing.T) {
+	cases := []struct {
+		name  string
+		data  []byte
+		items []Item[string, bool]
+		want  []Item[string, bool]
+		err   error
+	}{
+		{
+			name: "empty",
+			data: []byte{5, 0, 0, 0, 0},
+			want: []Item[string, bool]{},
+		},
+		{
+			name: "multiple items",
+			data: []byte{13, 0, 0, 0, 0x08, 'b', 0, 1, 0x08, 'a', 0, 0, 0},
+			want: []Item[string, bool]{{"b", true}, {"a", false}},
+		},
+		{
+			name:  "replace existing content",
+			data:  []byte{9, 0, 0, 0, 0x08, 'a', 0, 1, 0},
+			items: []Item[string, bool]{{"z", true}},
+			want:  []Item[string, bool]{{"a", true}},
+		},
+		{
+			name: "duplicate key",
+			data: []byte{13, 0, 0, 0, 0x08, 'a', 0, 1, 0x08, 'a', 0, 0, 0},
+			want: []Item[string, bool]{{"a", true}},
+			err:  ErrKeyAlreadyPresent,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := m.UnmarshalBSON(c.data); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestUnmarshalBSONInvalid(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{"truncated", []byte{9, 0, 0, 0, 0x08, 'a', 0, 1}},
+		{"wrong value type", []byte{14, 0, 0, 0, 0x02, 'a', 0, 2, 0, 0, 0, 'x', 0, 0}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := New[string, bool]()
+			if err := m.UnmarshalBSON(c.data); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestBSONRoundTrip(t *testing.T) {
+	nested := newFromItems(t, []Item[string, any]{{"$gt", int32(5)}, {"$lt", int32(10)}})
+	m := newFromItems(t, []Item[string, any]{
+		{"name", "pipeline"},
+		{"count", int64(3)},
+		{"filter", nested},
+		{"stages", []any{"b", "a"}},
+	})
+	data, err := m.MarshalBSON()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var out OrderedMap[string, any]
+	if err := out.UnmarshalBSON(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"name", "count", "filter", "stages"}, out.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+	filter, _ := out.Get("filter")
+	got, ok := filter.(*OrderedMap[string, any])
+	if !ok {
+		t.Fatalf("unexpected type %T", filter)
+	}
+	checkAll(t, got, nested.Items())
+	stages, _ := out.Get("stages")
+	if diff := cmp.Diff([]any{"b", "a"}, stages); diff != "" {
+		t.Fatalf("unexpected stages (-want +got):\n%s", diff)
+	}
+}
+
+func TestBSONNonStringKeys(t *testing.T) {
+	items := []Item[int, string]{{3, "three"}, {1, "one"}}
+	m := newFromItems(t, items)
+	data, err := m.MarshalBSON()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var out OrderedMap[int, string]
+	if err := out.UnmarshalBSON(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, &out, items)
+}
@@ -0,0 +1,49 @@
+package orderedmap
+
+import "context"
+
+// ToChan returns a channel with buffer size buf producing the items of the
+// ordered map in order, starting from the front element.
+//
+// The channel is closed after all items have been sent or when ctx is
+// done, whichever happens first. The map must not be modified until the
+// channel is closed.
+func (m *OrderedMap[K, V]) ToChan(ctx context.Context, buf int) <-chan Item[K, V] {
+	ch := make(chan Item[K, V], buf)
+	go func() {
+		defer close(ch)
+		for e := m.l.Front(); e != nil; e = e.Next() {
+			select {
+			case ch <- e.Value:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// FromChan returns an ordered map with the items received from ch in order
+// of arrival, until ch is closed or ctx is done. Items whose key has already
+// been received are handled according to policy.
+//
+// If ctx is done before ch is closed, it returns the items received so far
+// together with the error returned by ctx.Err(). Similarly, if an item with
+// a duplicate key is received and policy is DuplicateError, it returns the
+// items received so far together with an error wrapping ErrKeyAlreadyPresent.
+func FromChan[K comparable, V any](ctx context.Context, ch <-chan Item[K, V], policy DuplicatePolicy) (*OrderedMap[K, V], error) {
+	m := New[K, V]()
+	for {
+		select {
+		case item, ok := <-ch:
+			if !ok {
+				return m, nil
+			}
+			if err := m.add(item.Key, item.Value, policy); err != nil {
+				return m, err
+			}
+		case <-ctx.Done():
+			return m, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package orderedmap
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestToChan(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		buf   int
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+		},
+		{
+			name:  "unbuffered",
+			items: []Item[int, string]{{3, "three"}, {1, "one"}, {2, "two"}},
+		},
+		{
+			name:  "buffered",
+			items: []Item[int, string]{{3, "three"}, {1, "one"}, {2, "two"}},
+			buf:   2,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			got := []Item[int, string]{}
+			for item := range m.ToChan(context.Background(), c.buf) {
+				got = append(got, item)
+			}
+			if diff := cmp.Diff(c.items, got); diff != "" {
+				t.Fatalf("unexpected items (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestToChanCancel(t *testing.T) {
+	m := newFromItems(t, []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}})
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := m.ToChan(ctx, 0)
+	if item := <-ch; item.Key != 1 {
+		t.Fatalf("unexpected item: %v", item)
+	}
+	cancel()
+	// at most one more item can be received if the send was already
+	// selected, then the channel must be closed
+	n := 0
+	for range ch {
+		n++
+	}
+	if n > 1 {
+		t.Fatalf("unexpected number of items received after cancellation: %d", n)
+	}
+}
+
+func TestFromChan(t *testing.T) {
+	items := []Item[int, string]{{2, "two"}, {1, "one"}, {2, "deux"}, {3, "three"}}
+	cases := []struct {
+		name   string
+		items  []Item[int, string]
+		policy DuplicatePolicy
+		want   []Item[int, string]
+		err    error
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			want:  []Item[int, string]{},
+		},
+		{
+			name:  "no duplicates",
+			items: []Item[int, string]{{2, "two"}, {1, "one"}, {3, "three"}},
+			want:  []Item[int, string]{{2, "two"}, {1, "one"}, {3, "three"}},
+		},
+		{
+			name:   "duplicate error",
+			items:  items,
+			policy: DuplicateError,
+			want:   []Item[int, string]{{2, "two"}, {1, "one"}},
+			err:    ErrKeyAlreadyPresent,
+		},
+		{
+			name:   "duplicate keep first",
+			items:  items,
+			policy: DuplicateKeepFirst,
+			want:   []Item[int, string]{{2, "two"}, {1, "one"}, {3, "three"}},
+		},
+		{
+			name:   "duplicate keep last",
+			items:  items,
+			policy: DuplicateKeepLast,
+			want:   []Item[int, string]{{2, "deux"}, {1, "one"}, {3, "three"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ch := make(chan Item[int, string], len(c.items))
+			for _, item := range c.items {
+				ch <- item
+			}
+			close(ch)
+			m, err := FromChan(context.Background(), ch, c.policy)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("unexpected err: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestFromChanCancel(t *testing.T) {
+	ch := make(chan Item[int, string], 1)
+	ch <- Item[int, string]{1, "one"}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	var m *OrderedMap[int, string]
+	var err error
+	go func() {
+		defer close(done)
+		m, err = FromChan(ctx, ch, DuplicateError)
+	}()
+	// wait for the first item to be consumed before cancelling
+	for len(ch) > 0 {
+		runtime.Gosched()
+	}
+	cancel()
+	<-done
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected err: want: %v, got %v", context.Canceled, err)
+	}
+	checkAll(t, m, []Item[int, string]{{1, "one"}})
+}
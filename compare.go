@@ -0,0 +1,87 @@
+package orderedmap
+
+// EqualFunc reports whether m and other contain the same keys in the same
+// order, using eq to compare values.
+func (m *OrderedMap[K, V]) EqualFunc(other *OrderedMap[K, V], eq func(a, b V) bool) bool {
+	if m.Len() != other.Len() {
+		return false
+	}
+	for e, o := m.l.Front(), other.l.Front(); e != nil; e, o = e.Next(), o.Next() {
+		if e.Value.Key != o.Value.Key || !eq(e.Value.Value, o.Value.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Compare compares m and other lexicographically, comparing their items
+// pairwise in order with cmpItem, which must return a negative number if
+// a sorts before b, a positive number if a sorts after b and zero if they
+// sort equally.
+//
+// The result is the result of the first non-zero comparison. If all items
+// compare equal up to the length of the shortest map, the shortest map sorts
+// first. The result is 0 if both maps have the same length and all items
+// compare equal.
+func (m *OrderedMap[K, V]) Compare(other *OrderedMap[K, V], cmpItem func(a, b Item[K, V]) int) int {
+	e, o := m.l.Front(), other.l.Front()
+	for ; e != nil && o != nil; e, o = e.Next(), o.Next() {
+		if c := cmpItem(e.Value, o.Value); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case e != nil:
+		return 1
+	case o != nil:
+		return -1
+	}
+	return 0
+}
+
+// EqualUnorderedFunc reports whether m and other contain the same keys,
+// regardless of their order, using eq to compare the values of each key.
+func (m *OrderedMap[K, V]) EqualUnorderedFunc(other *OrderedMap[K, V], eq func(a, b V) bool) bool {
+	if m.Len() != other.Len() {
+		return false
+	}
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		o, ok := other.m[e.Value.Key]
+		if !ok || !eq(e.Value.Value, o.Value.Value) {
+			return false
+		}
+	}
+	return true
+}
+
+// EqualUnordered reports whether a and b contain the same keys associated to
+// the same values, regardless of their order.
+func EqualUnordered[K, V comparable](a, b *OrderedMap[K, V]) bool {
+	return a.EqualUnorderedFunc(b, func(x, y V) bool { return x == y })
+}
+
+// HashUnordered returns a hash of the items of the map which does not depend
+// on their order, combining the hashes of all items computed by hash. Maps
+// equal according to EqualUnorderedFunc have the same hash, as long as hash
+// returns the same hash for equal items.
+func (m *OrderedMap[K, V]) HashUnordered(hash func(key K, value V) uint64) uint64 {
+	var h uint64
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		// item hashes are combined by addition, which does not depend on
+		// their order, after mixing their bits so that items with related
+		// hashes do not cancel out
+		h += mix64(hash(e.Value.Key, e.Value.Value))
+	}
+	return mix64(h + uint64(m.Len()))
+}
+
+// mix64 is the finalizer of the SplitMix64 generator, which maps each input
+// to a distinct output with well distributed bits.
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
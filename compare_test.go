@@ -0,0 +1,207 @@
+package orderedmap
+
+import (
+	"fmt"
+	"hash/fnv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEqualFunc(t *testing.T) {
+	cases := []struct {
+		name  string
+		a     []Item[string, []int]
+		b     []Item[string, []int]
+		equal bool
+	}{
+		{
+			name:  "empty",
+			a:     []Item[string, []int]{},
+			b:     []Item[string, []int]{},
+			equal: true,
+		},
+		{
+			name:  "equal",
+			a:     []Item[string, []int]{{"a", []int{1}}, {"b", []int{2, 3}}},
+			b:     []Item[string, []int]{{"a", []int{1}}, {"b", []int{2, 3}}},
+			equal: true,
+		},
+		{
+			name: "different length",
+			a:    []Item[string, []int]{{"a", []int{1}}, {"b", []int{2, 3}}},
+			b:    []Item[string, []int]{{"a", []int{1}}},
+		},
+		{
+			name: "different order",
+			a:    []Item[string, []int]{{"a", []int{1}}, {"b", []int{2, 3}}},
+			b:    []Item[string, []int]{{"b", []int{2, 3}}, {"a", []int{1}}},
+		},
+		{
+			name: "different keys",
+			a:    []Item[string, []int]{{"a", []int{1}}, {"b", []int{2, 3}}},
+			b:    []Item[string, []int]{{"a", []int{1}}, {"c", []int{2, 3}}},
+		},
+		{
+			name: "different values",
+			a:    []Item[string, []int]{{"a", []int{1}}, {"b", []int{2, 3}}},
+			b:    []Item[string, []int]{{"a", []int{1}}, {"b", []int{2, 4}}},
+		},
+	}
+	eq := func(a, b []int) bool { return cmp.Equal(a, b) }
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a, b := newFromItems(t, c.a), newFromItems(t, c.b)
+			if got := a.EqualFunc(b, eq); got != c.equal {
+				t.Fatalf("unexpected result: want: %v, got %v", c.equal, got)
+			}
+			if got := b.EqualFunc(a, eq); got != c.equal {
+				t.Fatalf("unexpected result: want: %v, got %v", c.equal, got)
+			}
+		})
+	}
+}
+
+func TestEqualUnordered(t *testing.T) {
+	cases := []struct {
+		name  string
+		a     []Item[string, int]
+		b     []Item[string, int]
+		equal bool
+	}{
+		{
+			name:  "empty",
+			a:     []Item[string, int]{},
+			b:     []Item[string, int]{},
+			equal: true,
+		},
+		{
+			name:  "equal",
+			a:     []Item[string, int]{{"a", 1}, {"b", 2}},
+			b:     []Item[string, int]{{"a", 1}, {"b", 2}},
+			equal: true,
+		},
+		{
+			name:  "different order",
+			a:     []Item[string, int]{{"a", 1}, {"b", 2}, {"c", 3}},
+			b:     []Item[string, int]{{"c", 3}, {"a", 1}, {"b", 2}},
+			equal: true,
+		},
+		{
+			name: "different length",
+			a:    []Item[string, int]{{"a", 1}, {"b", 2}},
+			b:    []Item[string, int]{{"a", 1}},
+		},
+		{
+			name: "different keys",
+			a:    []Item[string, int]{{"a", 1}, {"b", 2}},
+			b:    []Item[string, int]{{"a", 1}, {"c", 2}},
+		},
+		{
+			name: "swapped values",
+			a:    []Item[string, int]{{"a", 1}, {"b", 2}},
+			b:    []Item[string, int]{{"a", 2}, {"b", 1}},
+		},
+	}
+	hash := func(key string, value int) uint64 {
+		h := fnv.New64a()
+		fmt.Fprintf(h, "%s=%d", key, value)
+		return h.Sum64()
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a, b := newFromItems(t, c.a), newFromItems(t, c.b)
+			if got := EqualUnordered(a, b); got != c.equal {
+				t.Fatalf("unexpected result: want: %v, got %v", c.equal, got)
+			}
+			if got := EqualUnordered(b, a); got != c.equal {
+				t.Fatalf("unexpected result: want: %v, got %v", c.equal, got)
+			}
+			if got := a.HashUnordered(hash) == b.HashUnordered(hash); got != c.equal {
+				t.Fatalf("unexpected hash equality: want: %v, got %v", c.equal, got)
+			}
+		})
+	}
+}
+
+func TestEqualUnorderedFunc(t *testing.T) {
+	a := newFromItems(t, []Item[string, []int]{{"a", []int{1}}, {"b", []int{2, 3}}})
+	b := newFromItems(t, []Item[string, []int]{{"b", []int{2, 3}}, {"a", []int{1}}})
+	eq := func(a, b []int) bool { return cmp.Equal(a, b) }
+	if !a.EqualUnorderedFunc(b, eq) {
+		t.Fatal("expected maps to be equal")
+	}
+	b.Set("a", []int{4})
+	if a.EqualUnorderedFunc(b, eq) {
+		t.Fatal("expected maps to differ")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	cmpItem := func(a, b Item[string, int]) int {
+		switch {
+		case a.Key < b.Key:
+			return -1
+		case a.Key > b.Key:
+			return 1
+		}
+		return a.Value - b.Value
+	}
+	cases := []struct {
+		name string
+		a    []Item[string, int]
+		b    []Item[string, int]
+		want int
+	}{
+		{
+			name: "empty",
+			a:    []Item[string, int]{},
+			b:    []Item[string, int]{},
+			want: 0,
+		},
+		{
+			name: "equal",
+			a:    []Item[string, int]{{"a", 1}, {"b", 2}},
+			b:    []Item[string, int]{{"a", 1}, {"b", 2}},
+			want: 0,
+		},
+		{
+			name: "prefix",
+			a:    []Item[string, int]{{"a", 1}},
+			b:    []Item[string, int]{{"a", 1}, {"b", 2}},
+			want: -1,
+		},
+		{
+			name: "smaller key",
+			a:    []Item[string, int]{{"a", 1}, {"b", 2}},
+			b:    []Item[string, int]{{"a", 1}, {"c", 0}},
+			want: -1,
+		},
+		{
+			name: "larger value",
+			a:    []Item[string, int]{{"a", 2}},
+			b:    []Item[string, int]{{"a", 1}, {"b", 2}},
+			want: 1,
+		},
+	}
+	sign := func(n int) int {
+		switch {
+		case n < 0:
+			return -1
+		case n > 0:
+			return 1
+		}
+		return 0
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a, b := newFromItems(t, c.a), newFromItems(t, c.b)
+			if got := sign(a.Compare(b, cmpItem)); got != c.want {
+				t.Fatalf("unexpected result: want: %v, got %v", c.want, got)
+			}
+			if got := sign(b.Compare(a, cmpItem)); got != -c.want {
+				t.Fatalf("unexpected result: want: %v, got %v", -c.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package orderedmap
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// csvHeader is the header record written by WriteCSV.
+var csvHeader = []string{"key", "value"}
+
+// WriteCSV writes m to w as CSV records of two fields, the key and the value
+// of each item, in order. If header is true, a "key,value" header record is
+// written first.
+func WriteCSV(w io.Writer, m *OrderedMap[string, string], header bool) error {
+	cw := csv.NewWriter(w)
+	if header {
+		if err := cw.Write(csvHeader); err != nil {
+			return err
+		}
+	}
+	for el := m.l.Front(); el != nil; el = el.Next() {
+		if err := cw.Write([]string{el.Value.Key, el.Value.Value}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ReadCSV reads CSV records of two fields, a key and a value, from r and
+// returns them as an ordered map in the order in which they are read.
+// If header is true, the first record is treated as a header and skipped.
+// If a key appears more than once, it returns an error wrapping
+// ErrKeyAlreadyPresent.
+func ReadCSV(r io.Reader, header bool) (*OrderedMap[string, string], error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 2
+	if header {
+		if _, err := cr.Read(); err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+	m := New[string, string]()
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return m, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := m.PushBack(record[0], record[1]); err != nil {
+			line, _ := cr.FieldPos(0)
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package orderedmap
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	cases := []struct {
+		name   string
+		items  []Item[string, string]
+		header bool
+		want   string
+	}{
+		{
+			name:  "empty",
+			items: []Item[string, string]{},
+			want:  "",
+		},
+		{
+			name:   "empty with header",
+			items:  []Item[string, string]{},
+			header: true,
+			want:   "key,value\n",
+		},
+		{
+			name:  "multiple items",
+			items: []Item[string, string]{{"b", "2"}, {"a", "1"}},
+			want:  "b,2\na,1\n",
+		},
+		{
+			name:   "multiple items with header",
+			items:  []Item[string, string]{{"b", "2"}, {"a", "1"}},
+			header: true,
+			want:   "key,value\nb,2\na,1\n",
+		},
+		{
+			name:  "quoted fields",
+			items: []Item[string, string]{{"a,b", `say "hi"`}, {"c", "multi\nline"}},
+			want:  "\"a,b\",\"say \"\"hi\"\"\"\nc,\"multi\nline\"\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			var buf bytes.Buffer
+			if err := WriteCSV(&buf, m, c.header); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := buf.String(); got != c.want {
+				t.Fatalf("unexpected CSV: want: %q, got %q", c.want, got)
+			}
+		})
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	cases := []struct {
+		name   string
+		data   string
+		header bool
+		want   []Item[string, string]
+		err    error
+		fails  bool
+	}{
+		{
+			name: "empty",
+			data: "",
+			want: []Item[string, string]{},
+		},
+		{
+			name:   "empty with header",
+			data:   "",
+			header: true,
+			want:   []Item[string, string]{},
+		},
+		{
+			name: "multiple items",
+			data: "b,2\na,1\n",
+			want: []Item[string, string]{{"b", "2"}, {"a", "1"}},
+		},
+		{
+			name:   "multiple items with header",
+			data:   "name,id\nb,2\na,1\n",
+			header: true,
+			want:   []Item[string, string]{{"b", "2"}, {"a", "1"}},
+		},
+		{
+			name: "quoted fields",
+			data: "\"a,b\",\"say \"\"hi\"\"\"\r\nc,\"multi\nline\"\r\n",
+			want: []Item[string, string]{{"a,b", `say "hi"`}, {"c", "multi\nline"}},
+		},
+		{
+			name:  "wrong number of fields",
+			data:  "a,1\nb,2,3\n",
+			fails: true,
+		},
+		{
+			name:  "duplicate key",
+			data:  "a,1\na,2\n",
+			err:   ErrKeyAlreadyPresent,
+			fails: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m, err := ReadCSV(strings.NewReader(c.data), c.header)
+			if c.fails {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if c.err != nil && !errors.Is(err, c.err) {
+					t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
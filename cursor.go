@@ -0,0 +1,129 @@
+package orderedmap
+
+import "github.com/lorenzosaino/go-orderedmap/list"
+
+// Cursor is a position in an ordered map, used to iterate it in either
+// direction and to modify it while iterating.
+//
+// A cursor is either positioned at an item of the map or unpositioned. It
+// remains positioned at the same item across modifications of the map,
+// including those performed by the cursor itself, until that item is deleted.
+// Moving a cursor to the next or previous item takes O(1) time, without
+// looking up any key.
+type Cursor[K comparable, V any] struct {
+	m       *OrderedMap[K, V]
+	el      *list.Element[Item[K, V]]
+	version uint64
+}
+
+// Cursor returns a new unpositioned cursor over the map.
+func (m *OrderedMap[K, V]) Cursor() *Cursor[K, V] {
+	return &Cursor[K, V]{m: m}
+}
+
+// set positions the cursor at el, or unpositions it if el is nil,
+// and reports whether it is positioned.
+func (c *Cursor[K, V]) set(el *list.Element[Item[K, V]]) bool {
+	c.el = el
+	c.version = c.m.version
+	return el != nil
+}
+
+// valid reports whether the cursor is positioned at an item of the map,
+// updating its element if the map has been modified since last accessed.
+func (c *Cursor[K, V]) valid() bool {
+	if c.el == nil {
+		return false
+	}
+	if c.version != c.m.version {
+		// the element may have been removed or replaced
+		return c.set(c.m.m[c.el.Value.Key])
+	}
+	return true
+}
+
+// Valid reports whether the cursor is positioned at an item of the map.
+func (c *Cursor[K, V]) Valid() bool {
+	return c.valid()
+}
+
+// Seek positions the cursor at the item with the key specified and reports
+// whether the key is present. If not, the cursor is unpositioned.
+func (c *Cursor[K, V]) Seek(key K) bool {
+	return c.set(c.m.m[c.m.normalize(key)])
+}
+
+// First positions the cursor at the front item and reports whether the
+// map is not empty.
+func (c *Cursor[K, V]) First() bool {
+	return c.set(c.m.l.Front())
+}
+
+// Last positions the cursor at the back item and reports whether the
+// map is not empty.
+func (c *Cursor[K, V]) Last() bool {
+	return c.set(c.m.l.Back())
+}
+
+// Next moves the cursor to the next item and reports whether there is one.
+// If the cursor is not positioned or at the back item, it is unpositioned.
+func (c *Cursor[K, V]) Next() bool {
+	if !c.valid() {
+		return false
+	}
+	return c.set(c.el.Next())
+}
+
+// Prev moves the cursor to the previous item and reports whether there is
+// one. If the cursor is not positioned or at the front item, it is
+// unpositioned.
+func (c *Cursor[K, V]) Prev() bool {
+	if !c.valid() {
+		return false
+	}
+	return c.set(c.el.Prev())
+}
+
+// Key returns the key of the item at the cursor, or the zero value of K if
+// the cursor is not positioned.
+func (c *Cursor[K, V]) Key() K {
+	if !c.valid() {
+		var zero K
+		return zero
+	}
+	return c.el.Value.Key
+}
+
+// Value returns the value of the item at the cursor, or the zero value of V
+// if the cursor is not positioned.
+func (c *Cursor[K, V]) Value() V {
+	if !c.valid() {
+		var zero V
+		return zero
+	}
+	return c.el.Value.Value
+}
+
+// Set updates the value of the item at the cursor, without moving it, and
+// reports whether the cursor is positioned. If not, the map is not modified.
+func (c *Cursor[K, V]) Set(value V) bool {
+	c.m.beforeWrite()
+	if !c.valid() {
+		return false
+	}
+	c.m.update(c.el, value)
+	return true
+}
+
+// Delete deletes the item at the cursor and moves the cursor to the next
+// item, reporting whether there is one. If the cursor is not positioned, the
+// map is not modified.
+func (c *Cursor[K, V]) Delete() bool {
+	c.m.beforeWrite()
+	if !c.valid() {
+		return false
+	}
+	next := c.el.Next()
+	c.m.remove(c.el)
+	return c.set(next)
+}
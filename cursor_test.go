@@ -0,0 +1,95 @@
+package orderedmap
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCursorIterate(t *testing.T) {
+	items := []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}}
+	m := newFromItems(t, items)
+	c := m.Cursor()
+	if c.Valid() {
+		t.Fatal("new cursor is positioned")
+	}
+	if c.Next() || c.Prev() {
+		t.Fatal("unpositioned cursor moved")
+	}
+
+	var got []Item[int, string]
+	for ok := c.First(); ok; ok = c.Next() {
+		got = append(got, Item[int, string]{c.Key(), c.Value()})
+	}
+	if diff := cmp.Diff(items, got); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+	if c.Valid() || c.Key() != 0 || c.Value() != "" {
+		t.Fatal("cursor is positioned past the back item")
+	}
+
+	got = nil
+	for ok := c.Last(); ok; ok = c.Prev() {
+		got = append(got, Item[int, string]{c.Key(), c.Value()})
+	}
+	want := []Item[int, string]{{3, "three"}, {2, "two"}, {1, "one"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+}
+
+func TestCursorSeek(t *testing.T) {
+	m := newFromItems(t, []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}})
+	c := m.Cursor()
+	if !c.Seek(2) || c.Key() != 2 || c.Value() != "two" {
+		t.Fatalf("unexpected position: %v", c.Key())
+	}
+	if !c.Next() || c.Key() != 3 {
+		t.Fatalf("unexpected position: %v", c.Key())
+	}
+	if c.Seek(4) || c.Valid() {
+		t.Fatal("cursor is positioned at missing key")
+	}
+}
+
+func TestCursorModify(t *testing.T) {
+	m := newFromItems(t, []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}})
+	c := m.Cursor()
+	for ok := c.First(); ok; {
+		if c.Key()%2 == 0 {
+			ok = c.Delete()
+			continue
+		}
+		c.Set(c.Value() + "!")
+		ok = c.Next()
+	}
+	checkAll(t, m, []Item[int, string]{{1, "one!"}, {3, "three!"}})
+
+	if c.Set("x") || c.Delete() {
+		t.Fatal("unpositioned cursor modified the map")
+	}
+	checkAll(t, m, []Item[int, string]{{1, "one!"}, {3, "three!"}})
+}
+
+func TestCursorExternalModification(t *testing.T) {
+	m := newFromItems(t, []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}})
+	c := m.Cursor()
+	c.Seek(2)
+
+	// moving the item keeps the cursor at it
+	if err := m.MoveToFront(2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !c.Valid() || c.Key() != 2 {
+		t.Fatalf("unexpected position: %v", c.Key())
+	}
+	if !c.Next() || c.Key() != 1 {
+		t.Fatalf("unexpected position: %v", c.Key())
+	}
+
+	// deleting the item unpositions the cursor
+	m.Delete(1)
+	if c.Valid() || c.Next() {
+		t.Fatal("cursor is positioned at deleted item")
+	}
+}
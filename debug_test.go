@@ -0,0 +1,45 @@
+//go:build orderedmap_debug
+
+package orderedmap
+
+import "testing"
+
+func TestDebugConcurrentModification(t *testing.T) {
+	iterate := map[string]func(m *OrderedMap[int, string], f func()){
+		"Range": func(m *OrderedMap[int, string], f func()) {
+			m.Range(func(key int, value string) bool { f(); return true })
+		},
+		"RangeWithIndex": func(m *OrderedMap[int, string], f func()) {
+			m.RangeWithIndex(func(i int, key int, value string) bool { f(); return true })
+		},
+		"RangeReverse": func(m *OrderedMap[int, string], f func()) {
+			m.RangeReverse(func(key int, value string) bool { f(); return true })
+		},
+		"Find": func(m *OrderedMap[int, string], f func()) {
+			m.Find(func(key int, value string) bool { f(); return false })
+		},
+		"FindLast": func(m *OrderedMap[int, string], f func()) {
+			m.FindLast(func(key int, value string) bool { f(); return false })
+		},
+		"Pairs": func(m *OrderedMap[int, string], f func()) {
+			m.Pairs()(func(a, b Item[int, string]) bool { f(); return true })
+		},
+		"Windows": func(m *OrderedMap[int, string], f func()) {
+			m.Windows(1)(func(window []Item[int, string]) bool { f(); return true })
+		},
+	}
+	for name, iter := range iterate {
+		t.Run(name, func(t *testing.T) {
+			m := newFromItems(t, []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}})
+			// updating values is allowed
+			iter(m, func() { m.Set(2, "TWO") })
+
+			defer func() {
+				if r := recover(); r != ErrConcurrentModification {
+					t.Fatalf("unexpected panic: want: %v, got %v", ErrConcurrentModification, r)
+				}
+			}()
+			iter(m, func() { m.Delete(2) })
+		})
+	}
+}
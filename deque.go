@@ -0,0 +1,95 @@
+package orderedmap
+
+// Deque is a double-ended queue of values backed by an ordered map, whose
+// keys are generated automatically.
+//
+// Each value pushed is assigned a new key, which is returned by the push
+// methods and can be used to look up or remove the value while it is in
+// the deque, for example to cancel a task queued by a scheduler.
+type Deque[V any] struct {
+	m *OrderedMap[uint64, V]
+	// next is the key of the next value pushed
+	next uint64
+}
+
+// NewDeque returns a new empty deque configured with the options provided,
+// which apply to the ordered map holding its values, whose keys are of type
+// uint64. For example, WithMaxEntries[uint64, V] bounds the length of the
+// deque.
+func NewDeque[V any](opts ...Option) *Deque[V] {
+	return &Deque[V]{m: New[uint64, V](opts...)}
+}
+
+// Len returns the number of values in the deque.
+func (d *Deque[V]) Len() int {
+	return d.m.Len()
+}
+
+// PushFront inserts a value at the front of the deque and returns its key.
+func (d *Deque[V]) PushFront(value V) (key uint64) {
+	key = d.next
+	d.next++
+	d.m.PushFront(key, value)
+	return key
+}
+
+// PushBack inserts a value at the back of the deque and returns its key.
+func (d *Deque[V]) PushBack(value V) (key uint64) {
+	key = d.next
+	d.next++
+	d.m.PushBack(key, value)
+	return key
+}
+
+// PopFront removes and returns the value at the front of the deque.
+//
+// If the deque is empty, it returns the zero value of V and ok is set to
+// false.
+func (d *Deque[V]) PopFront() (value V, ok bool) {
+	item, ok := d.m.PopFront()
+	return item.Value, ok
+}
+
+// PopBack removes and returns the value at the back of the deque.
+//
+// If the deque is empty, it returns the zero value of V and ok is set to
+// false.
+func (d *Deque[V]) PopBack() (value V, ok bool) {
+	item, ok := d.m.PopBack()
+	return item.Value, ok
+}
+
+// Front returns the value at the front of the deque without removing it.
+//
+// If the deque is empty, it returns the zero value of V and ok is set to
+// false.
+func (d *Deque[V]) Front() (value V, ok bool) {
+	item, ok := d.m.Front()
+	return item.Value, ok
+}
+
+// Back returns the value at the back of the deque without removing it.
+//
+// If the deque is empty, it returns the zero value of V and ok is set to
+// false.
+func (d *Deque[V]) Back() (value V, ok bool) {
+	item, ok := d.m.Back()
+	return item.Value, ok
+}
+
+// Get returns the value of a key returned by PushFront or PushBack.
+//
+// If the value has been removed from the deque, it returns the zero value of
+// V and ok is set to false.
+func (d *Deque[V]) Get(key uint64) (value V, ok bool) {
+	return d.m.Get(key)
+}
+
+// Remove removes the value of a key returned by PushFront or PushBack and
+// returns it.
+//
+// If the value has already been removed from the deque, it returns the zero
+// value of V and ok is set to false.
+func (d *Deque[V]) Remove(key uint64) (value V, ok bool) {
+	return d.m.Delete(key)
+}
@@ -0,0 +1,73 @@
+package orderedmap
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDeque(t *testing.T) {
+	d := NewDeque[string]()
+	if _, ok := d.PopFront(); ok {
+		t.Fatal("unexpected value in empty deque")
+	}
+	if _, ok := d.PopBack(); ok {
+		t.Fatal("unexpected value in empty deque")
+	}
+	b := d.PushBack("b")
+	a := d.PushFront("a")
+	c := d.PushBack("c")
+	if a == b || b == c || a == c {
+		t.Fatalf("keys are not unique: %d, %d, %d", a, b, c)
+	}
+	if d.Len() != 3 {
+		t.Fatalf("unexpected length: %d", d.Len())
+	}
+	if v, ok := d.Front(); !ok || v != "a" {
+		t.Fatalf("unexpected front: %v, %v", v, ok)
+	}
+	if v, ok := d.Back(); !ok || v != "c" {
+		t.Fatalf("unexpected back: %v, %v", v, ok)
+	}
+	if v, ok := d.Get(b); !ok || v != "b" {
+		t.Fatalf("unexpected value: %v, %v", v, ok)
+	}
+	if v, ok := d.PopBack(); !ok || v != "c" {
+		t.Fatalf("unexpected value: %v, %v", v, ok)
+	}
+	if v, ok := d.PopFront(); !ok || v != "a" {
+		t.Fatalf("unexpected value: %v, %v", v, ok)
+	}
+	if _, ok := d.Get(a); ok {
+		t.Fatal("unexpected value of popped key")
+	}
+	if v, ok := d.Remove(b); !ok || v != "b" {
+		t.Fatalf("unexpected removed value: %v, %v", v, ok)
+	}
+	if _, ok := d.Remove(b); ok {
+		t.Fatal("unexpected removal of removed key")
+	}
+	if d.Len() != 0 {
+		t.Fatalf("unexpected length: %d", d.Len())
+	}
+}
+
+func TestDequeMaxEntries(t *testing.T) {
+	var evicted []int
+	d := NewDeque[int](WithMaxEntries(2, EvictOldest, func(key uint64, value int) {
+		evicted = append(evicted, value)
+	}))
+	for i := 0; i < 4; i++ {
+		d.PushBack(i)
+	}
+	if diff := cmp.Diff([]int{0, 1}, evicted); diff != "" {
+		t.Fatalf("unexpected evicted values (-want +got):\n%s", diff)
+	}
+	var got []int
+	for v, ok := d.PopFront(); ok; v, ok = d.PopFront() {
+		got = append(got, v)
+	}
+	if diff := cmp.Diff([]int{2, 3}, got); diff != "" {
+		t.Fatalf("unexpected values (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,61 @@
+package orderedmap
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/lorenzosaino/go-orderedmap/list"
+)
+
+// WriteDOT writes the doubly-linked list of the items of the map to w as a
+// graph in the DOT language of Graphviz, with one node per item, labeled by
+// label, and edges following the links to the next and previous items, as
+// well as from the front and back of the map. If label is nil, items are
+// labeled as "key: value".
+//
+// Nodes are named n0, n1, ... after the position of their items, and edges
+// reflect the actual links of the list, so that the graph of a corrupted map
+// shows which links are inconsistent.
+func (m *OrderedMap[K, V]) WriteDOT(w io.Writer, label func(key K, value V) string) error {
+	if label == nil {
+		label = func(key K, value V) string {
+			return fmt.Sprintf("%v: %v", key, value)
+		}
+	}
+	var b bytes.Buffer
+	b.WriteString("digraph orderedmap {\n\trankdir=LR;\n\tnode [shape=box];\n")
+	var ids map[*list.Element[Item[K, V]]]string
+	if m != nil && m.m != nil && m.l.Len() > 0 {
+		ids = make(map[*list.Element[Item[K, V]]]string, m.l.Len())
+		for e := m.l.Front(); e != nil; e = e.Next() {
+			id := fmt.Sprintf("n%d", len(ids))
+			ids[e] = id
+			fmt.Fprintf(&b, "\t%s [label=%s];\n", id, dotQuote(label(e.Value.Key, e.Value.Value)))
+		}
+		b.WriteString("\tfront [shape=plaintext];\n\tback [shape=plaintext];\n")
+		fmt.Fprintf(&b, "\tfront -> %s;\n", ids[m.l.Front()])
+		fmt.Fprintf(&b, "\tback -> %s;\n", ids[m.l.Back()])
+		for e := m.l.Front(); e != nil; e = e.Next() {
+			if next := e.Next(); next != nil {
+				fmt.Fprintf(&b, "\t%s -> %s [label=next];\n", ids[e], ids[next])
+			}
+			if prev := e.Prev(); prev != nil {
+				fmt.Fprintf(&b, "\t%s -> %s [label=prev, style=dashed];\n", ids[e], ids[prev])
+			}
+		}
+	}
+	b.WriteString("}\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// dotEscaper escapes the characters of DOT quoted strings that have a special
+// meaning.
+var dotEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", ``)
+
+// dotQuote returns s as a DOT quoted string.
+func dotQuote(s string) string {
+	return `"` + dotEscaper.Replace(s) + `"`
+}
@@ -0,0 +1,58 @@
+package orderedmap
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteDOT(t *testing.T) {
+	m := newFromItems(t, []Item[string, int]{{"a", 1}, {`"b"`, 2}})
+	var b strings.Builder
+	if err := m.WriteDOT(&b, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := `digraph orderedmap {
+	rankdir=LR;
+	node [shape=box];
+	n0 [label="a: 1"];
+	n1 [label="\"b\": 2"];
+	front [shape=plaintext];
+	back [shape=plaintext];
+	front -> n0;
+	back -> n1;
+	n0 -> n1 [label=next];
+	n1 -> n0 [label=prev, style=dashed];
+}
+`
+	if got := b.String(); got != want {
+		t.Fatalf("unexpected output: want:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestWriteDOTLabel(t *testing.T) {
+	m := newFromItems(t, []Item[string, int]{{"a", 1}})
+	var b strings.Builder
+	err := m.WriteDOT(&b, func(key string, value int) string {
+		return key + "\n" + strings.Repeat("*", value)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := b.String(), `n0 [label="a\n*"];`; !strings.Contains(got, want) {
+		t.Fatalf("output does not contain %s:\n%s", want, got)
+	}
+}
+
+func TestWriteDOTEmpty(t *testing.T) {
+	want := "digraph orderedmap {\n\trankdir=LR;\n\tnode [shape=box];\n}\n"
+	var zero OrderedMap[string, int]
+	for _, m := range []*OrderedMap[string, int]{&zero, New[string, int]()} {
+		var b strings.Builder
+		if err := m.WriteDOT(&b, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := b.String(); got != want {
+			t.Fatalf("unexpected output: want: %q, got %q", want, got)
+		}
+	}
+}
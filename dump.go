@@ -0,0 +1,83 @@
+package orderedmap
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/lorenzosaino/go-orderedmap/list"
+)
+
+// Dump writes a description of the internal state of the map to w, for
+// troubleshooting. It lists the items of the map in order, one per line,
+// with their position, the address of the list element holding them and
+// the addresses of the preceding and following elements:
+//
+//	*orderedmap.OrderedMap[string,int] len=2 list=2 version=2 frozen=false
+//	  front=0xc000010000 back=0xc000010040
+//	  0 0xc000010000 prev=0x0 next=0xc000010040 key=a value=1
+//	  1 0xc000010040 prev=0xc000010000 next=0x0 key=b value=2
+//
+// Inconsistencies between the list, the map indexing its elements and the
+// position index, if any, are reported next to the items affected.
+// The format is not stable and must not be parsed.
+func (m *OrderedMap[K, V]) Dump(w io.Writer) error {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%T", m)
+	if m == nil || m.m == nil {
+		b.WriteString(" <empty>\n")
+		_, err := w.Write(b.Bytes())
+		return err
+	}
+	fmt.Fprintf(&b, " len=%d list=%d version=%d frozen=%v\n",
+		len(m.m), m.l.Len(), m.version, m.frozen)
+	fmt.Fprintf(&b, "  front=%p back=%p\n", m.l.Front(), m.l.Back())
+	i := 0
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		fmt.Fprintf(&b, "  %d %p prev=%p next=%p key=%v value=%v", i, e, e.Prev(), e.Next(), e.Value.Key, e.Value.Value)
+		m.dumpInconsistencies(&b, i, e)
+		b.WriteByte('\n')
+		i++
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// DumpCompact writes a description of the internal state of the map to w
+// like Dump, but in a single line listing the position, key, value and
+// element address of each item:
+//
+//	orderedmap[0:a=1@0xc000010000 1:b=2@0xc000010040]
+func (m *OrderedMap[K, V]) DumpCompact(w io.Writer) error {
+	var b bytes.Buffer
+	b.WriteString("orderedmap[")
+	if m != nil && m.m != nil {
+		i := 0
+		for e := m.l.Front(); e != nil; e = e.Next() {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			fmt.Fprintf(&b, "%d:%v=%v@%p", i, e.Value.Key, e.Value.Value, e)
+			m.dumpInconsistencies(&b, i, e)
+			i++
+		}
+	}
+	b.WriteString("]\n")
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// dumpInconsistencies writes the inconsistencies of the element at
+// position i with the map and the position index, if any.
+func (m *OrderedMap[K, V]) dumpInconsistencies(b *bytes.Buffer, i int, e *list.Element[Item[K, V]]) {
+	if el := m.m[e.Value.Key]; el != e {
+		fmt.Fprintf(b, " !map=%p", el)
+	}
+	if m.idx != nil {
+		if _, ok := m.idx.nodes[e]; !ok {
+			b.WriteString(" !index=missing")
+		} else if j := m.idx.indexOf(e); j != i {
+			fmt.Fprintf(b, " !index=%d", j)
+		}
+	}
+}
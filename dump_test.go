@@ -0,0 +1,73 @@
+package orderedmap
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// pointers matches the non-nil addresses written by Dump, which are not
+// deterministic.
+var pointers = regexp.MustCompile(`0x[1-9a-f][0-9a-f]*`)
+
+func TestDump(t *testing.T) {
+	m := newFromItems(t, []Item[string, int]{{"a", 1}, {"b", 2}, {"c", 3}})
+	var b strings.Builder
+	if err := m.Dump(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := pointers.ReplaceAllString(b.String(), "PTR")
+	want := `*orderedmap.OrderedMap[string,int] len=3 list=3 version=3 frozen=false
+  front=PTR back=PTR
+  0 PTR prev=0x0 next=PTR key=a value=1
+  1 PTR prev=PTR next=PTR key=b value=2
+  2 PTR prev=PTR next=0x0 key=c value=3
+`
+	if got != want {
+		t.Fatalf("unexpected dump: want:\n%s\ngot:\n%s", want, got)
+	}
+
+	// addresses are consistent with each other
+	lines := strings.Split(b.String(), "\n")
+	addr := func(line, prefix string) string {
+		return regexp.MustCompile(prefix + `(0x[0-9a-f]+)`).FindStringSubmatch(line)[1]
+	}
+	if front, first := addr(lines[1], "front="), addr(lines[2], `0 `); front != first {
+		t.Fatalf("unexpected front address: want: %s, got %s", first, front)
+	}
+	if next, second := addr(lines[2], "next="), addr(lines[3], `1 `); next != second {
+		t.Fatalf("unexpected next address: want: %s, got %s", second, next)
+	}
+}
+
+func TestDumpInconsistent(t *testing.T) {
+	m := New[string, int](WithPositionIndex())
+	m.Set("a", 1)
+	m.Set("b", 2)
+	// corrupt the map, as a bug would
+	m.m["b"] = m.m["a"]
+	var b strings.Builder
+	if err := m.DumpCompact(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := pointers.ReplaceAllString(b.String(), "PTR")
+	want := "orderedmap[0:a=1@PTR 1:b=2@PTR !map=PTR]\n"
+	if got != want {
+		t.Fatalf("unexpected dump: want: %q, got %q", want, got)
+	}
+}
+
+func TestDumpEmpty(t *testing.T) {
+	var zero OrderedMap[string, int]
+	var b strings.Builder
+	if err := zero.Dump(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := zero.DumpCompact(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "*orderedmap.OrderedMap[string,int] <empty>\norderedmap[]\n"
+	if got := b.String(); got != want {
+		t.Fatalf("unexpected dump: want: %q, got %q", want, got)
+	}
+}
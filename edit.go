@@ -0,0 +1,224 @@
+package orderedmap
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// EditOp is the operation of an Edit.
+type EditOp int
+
+const (
+	// EditPushFront inserts a new key and value at the front of the map.
+	EditPushFront EditOp = iota
+
+	// EditPushBack inserts a new key and value at the back of the map.
+	EditPushBack
+
+	// EditInsertAfter inserts a new key and value immediately after a mark key.
+	EditInsertAfter
+
+	// EditInsertBefore inserts a new key and value immediately before a mark key.
+	EditInsertBefore
+
+	// EditUpdate updates the value associated to an existing key.
+	EditUpdate
+
+	// EditSet updates the value associated to a key if present,
+	// or inserts the key and value at the back of the map otherwise.
+	EditSet
+
+	// EditDelete deletes an existing key.
+	EditDelete
+
+	// EditMoveToFront moves an existing key to the front of the map.
+	EditMoveToFront
+
+	// EditMoveToBack moves an existing key to the back of the map.
+	EditMoveToBack
+
+	// EditMoveAfter moves an existing key immediately after a mark key.
+	EditMoveAfter
+
+	// EditMoveBefore moves an existing key immediately before a mark key.
+	EditMoveBefore
+
+	// EditEvict deletes a key evicted from a map bounded with WithMaxEntries
+	// or WithMaxBytes, if present. Unlike EditDelete, it does not fail if the
+	// key is missing, as a copy of the map with the same bounds has already
+	// evicted it.
+	EditEvict
+)
+
+var editOpNames = [...]string{
+	EditPushFront:    "PushFront",
+	EditPushBack:     "PushBack",
+	EditInsertAfter:  "InsertAfter",
+	EditInsertBefore: "InsertBefore",
+	EditUpdate:       "Update",
+	EditSet:          "Set",
+	EditDelete:       "Delete",
+	EditMoveToFront:  "MoveToFront",
+	EditMoveToBack:   "MoveToBack",
+	EditMoveAfter:    "MoveAfter",
+	EditMoveBefore:   "MoveBefore",
+	EditEvict:        "Evict",
+}
+
+// String returns the name of the operation.
+func (op EditOp) String() string {
+	if op >= 0 && int(op) < len(editOpNames) {
+		return editOpNames[op]
+	}
+	return "EditOp(" + strconv.Itoa(int(op)) + ")"
+}
+
+// Edit is an operation modifying an ordered map.
+type Edit[K comparable, V any] struct {
+	// Op is the operation to perform.
+	Op EditOp
+
+	// Key is the key the operation applies to.
+	Key K
+
+	// Value is the value to insert or update, if any.
+	Value V
+
+	// Mark is the mark key of EditInsertAfter, EditInsertBefore,
+	// EditMoveAfter and EditMoveBefore operations.
+	Mark K
+}
+
+// Apply applies a sequence of edits to the map, in order, atomically.
+//
+// Each edit has the same semantics of the method of the same name, except that
+// EditDelete fails with ErrKeyMissing if the key is missing. If an edit fails,
+// all edits already applied are reverted, so that the map is left unmodified,
+// and an error wrapping the error of the failed edit is returned.
+//
+// While edits are applied, maps bounded with WithMaxEntries or WithMaxBytes do
+// not evict items and maps in access order do not move the items updated, so
+// that the edits can be reverted exactly. Excess items are evicted once all
+// edits have been applied. The write hook and journal, if any, are notified of
+// the edits only if all of them are applied.
+func (m *OrderedMap[K, V]) Apply(edits []Edit[K, V]) error {
+	m.beforeWrite()
+	m.applying = true
+	undo := make([]func(), 0, len(edits))
+	for i, e := range edits {
+		u, err := m.applyEdit(e)
+		if err != nil {
+			for j := len(undo) - 1; j >= 0; j-- {
+				undo[j]()
+			}
+			m.endApply(false)
+			return fmt.Errorf("edit %d (%v %v): %w", i, e.Op, e.Key, err)
+		}
+		undo = append(undo, u)
+	}
+	m.endApply(true)
+	return nil
+}
+
+// endApply ends the application of edits by Apply, emitting the edits
+// deferred and evicting excess items if committed.
+func (m *OrderedMap[K, V]) endApply(commit bool) {
+	applied := m.applied
+	m.applying = false
+	m.applied = nil
+	if !commit {
+		return
+	}
+	for _, e := range applied {
+		m.emit(e)
+	}
+	m.evictExcess()
+}
+
+// applyEdit applies an edit and returns a function reverting it.
+func (m *OrderedMap[K, V]) applyEdit(e Edit[K, V]) (undo func(), err error) {
+	key := m.normalize(e.Key)
+	switch e.Op {
+	case EditPushFront:
+		err = m.PushFront(key, e.Value)
+	case EditPushBack:
+		err = m.PushBack(key, e.Value)
+	case EditInsertAfter:
+		err = m.InsertAfter(key, e.Value, e.Mark)
+	case EditInsertBefore:
+		err = m.InsertBefore(key, e.Value, e.Mark)
+	case EditUpdate:
+		old, err := m.Update(key, e.Value)
+		if err != nil {
+			return nil, err
+		}
+		return func() { m.Update(key, old) }, nil
+	case EditSet:
+		if el, ok := m.m[key]; ok {
+			old := el.Value.Value
+			m.Update(key, e.Value)
+			return func() { m.Update(key, old) }, nil
+		}
+		m.PushBack(key, e.Value)
+	case EditDelete, EditEvict:
+		next, last, ok := m.nextKey(key)
+		if !ok {
+			if e.Op == EditEvict {
+				return func() {}, nil
+			}
+			return nil, ErrKeyMissing
+		}
+		value, _ := m.Delete(key)
+		return func() {
+			if last {
+				m.PushBack(key, value)
+			} else {
+				m.InsertBefore(key, value, next)
+			}
+		}, nil
+	case EditMoveToFront, EditMoveToBack, EditMoveAfter, EditMoveBefore:
+		next, last, ok := m.nextKey(key)
+		if !ok {
+			return nil, ErrKeyMissing
+		}
+		switch e.Op {
+		case EditMoveToFront:
+			err = m.MoveToFront(key)
+		case EditMoveToBack:
+			err = m.MoveToBack(key)
+		case EditMoveAfter:
+			err = m.MoveAfter(key, e.Mark)
+		default:
+			err = m.MoveBefore(key, e.Mark)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return func() {
+			if last {
+				m.MoveToBack(key)
+			} else {
+				m.MoveBefore(key, next)
+			}
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown operation %v", e.Op)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return func() { m.Delete(key) }, nil
+}
+
+// nextKey returns the key following key. If key is the last key of the map,
+// last is set to true. If key is missing, ok is set to false.
+func (m *OrderedMap[K, V]) nextKey(key K) (next K, last, ok bool) {
+	el, ok := m.m[key]
+	if !ok {
+		return next, false, false
+	}
+	if el = el.Next(); el == nil {
+		return next, true, true
+	}
+	return el.Value.Key, false, true
+}
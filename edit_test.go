@@ -0,0 +1,249 @@
+package orderedmap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestApply(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[string, int]
+		edits []Edit[string, int]
+		want  []Item[string, int]
+		err   error
+	}{
+		{
+			name:  "no edits",
+			items: []Item[string, int]{{"a", 1}},
+			edits: nil,
+			want:  []Item[string, int]{{"a", 1}},
+		},
+		{
+			name:  "insertions",
+			items: []Item[string, int]{{"c", 3}},
+			edits: []Edit[string, int]{
+				{Op: EditPushBack, Key: "e", Value: 5},
+				{Op: EditPushFront, Key: "a", Value: 1},
+				{Op: EditInsertAfter, Key: "d", Value: 4, Mark: "c"},
+				{Op: EditInsertBefore, Key: "b", Value: 2, Mark: "c"},
+			},
+			want: []Item[string, int]{{"a", 1}, {"b", 2}, {"c", 3}, {"d", 4}, {"e", 5}},
+		},
+		{
+			name:  "updates",
+			items: []Item[string, int]{{"a", 1}, {"b", 2}},
+			edits: []Edit[string, int]{
+				{Op: EditUpdate, Key: "a", Value: 10},
+				{Op: EditSet, Key: "b", Value: 20},
+				{Op: EditSet, Key: "c", Value: 30},
+			},
+			want: []Item[string, int]{{"a", 10}, {"b", 20}, {"c", 30}},
+		},
+		{
+			name:  "moves and deletions",
+			items: []Item[string, int]{{"a", 1}, {"b", 2}, {"c", 3}, {"d", 4}, {"e", 5}},
+			edits: []Edit[string, int]{
+				{Op: EditDelete, Key: "c"},
+				{Op: EditMoveToFront, Key: "e"},
+				{Op: EditMoveToBack, Key: "a"},
+				{Op: EditMoveAfter, Key: "b", Mark: "d"},
+				{Op: EditMoveBefore, Key: "d", Mark: "e"},
+			},
+			want: []Item[string, int]{{"d", 4}, {"e", 5}, {"b", 2}, {"a", 1}},
+		},
+		{
+			name:  "rollback insertions and updates",
+			items: []Item[string, int]{{"a", 1}, {"b", 2}},
+			edits: []Edit[string, int]{
+				{Op: EditPushFront, Key: "z", Value: 26},
+				{Op: EditUpdate, Key: "a", Value: 10},
+				{Op: EditSet, Key: "b", Value: 20},
+				{Op: EditSet, Key: "c", Value: 30},
+				{Op: EditPushBack, Key: "a", Value: 100},
+			},
+			want: []Item[string, int]{{"a", 1}, {"b", 2}},
+			err:  ErrKeyAlreadyPresent,
+		},
+		{
+			name:  "rollback moves and deletions",
+			items: []Item[string, int]{{"a", 1}, {"b", 2}, {"c", 3}, {"d", 4}},
+			edits: []Edit[string, int]{
+				{Op: EditDelete, Key: "d"},
+				{Op: EditDelete, Key: "b"},
+				{Op: EditMoveToFront, Key: "c"},
+				{Op: EditMoveToBack, Key: "c"},
+				{Op: EditMoveAfter, Key: "a", Mark: "c"},
+				{Op: EditPushBack, Key: "d", Value: 40},
+				{Op: EditMoveBefore, Key: "d", Mark: "c"},
+				{Op: EditDelete, Key: "b"},
+			},
+			want: []Item[string, int]{{"a", 1}, {"b", 2}, {"c", 3}, {"d", 4}},
+			err:  ErrKeyMissing,
+		},
+		{
+			name:  "update missing key",
+			items: []Item[string, int]{{"a", 1}},
+			edits: []Edit[string, int]{
+				{Op: EditDelete, Key: "a"},
+				{Op: EditUpdate, Key: "a", Value: 10},
+			},
+			want: []Item[string, int]{{"a", 1}},
+			err:  ErrKeyMissing,
+		},
+		{
+			name:  "move missing key",
+			items: []Item[string, int]{{"a", 1}},
+			edits: []Edit[string, int]{
+				{Op: EditMoveToFront, Key: "b"},
+			},
+			want: []Item[string, int]{{"a", 1}},
+			err:  ErrKeyMissing,
+		},
+		{
+			name:  "insert after missing mark",
+			items: []Item[string, int]{{"a", 1}},
+			edits: []Edit[string, int]{
+				{Op: EditPushBack, Key: "b", Value: 2},
+				{Op: EditInsertAfter, Key: "c", Value: 3, Mark: "z"},
+			},
+			want: []Item[string, int]{{"a", 1}},
+			err:  ErrMarkKeyMissing,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := m.Apply(c.edits); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestApplyPositionIndex(t *testing.T) {
+	m := newIndexedFromItems(t, []Item[string, int]{{"a", 1}, {"b", 2}, {"c", 3}})
+	err := m.Apply([]Edit[string, int]{
+		{Op: EditMoveToBack, Key: "a"},
+		{Op: EditDelete, Key: "b"},
+		{Op: EditInsertAfter, Key: "d", Value: 4, Mark: "x"},
+	})
+	if !errors.Is(err, ErrMarkKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrMarkKeyMissing, err)
+	}
+	checkAll(t, m, []Item[string, int]{{"a", 1}, {"b", 2}, {"c", 3}})
+}
+
+func TestApplyBounded(t *testing.T) {
+	var evicted []Item[string, int]
+	m := New[string, int](WithMaxEntries(2, EvictOldest, func(key string, value int) {
+		evicted = append(evicted, Item[string, int]{key, value})
+	}))
+	m.Set("a", 1)
+	m.Set("b", 2)
+	err := m.Apply([]Edit[string, int]{
+		{Op: EditPushBack, Key: "c", Value: 3},
+		{Op: EditUpdate, Key: "zz", Value: 26},
+	})
+	if !errors.Is(err, ErrKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrKeyMissing, err)
+	}
+	checkAll(t, m, []Item[string, int]{{"a", 1}, {"b", 2}})
+	if len(evicted) != 0 {
+		t.Fatalf("unexpected evictions: %v", evicted)
+	}
+
+	// excess items are evicted once all edits have been applied
+	err = m.Apply([]Edit[string, int]{
+		{Op: EditPushBack, Key: "c", Value: 3},
+		{Op: EditPushBack, Key: "d", Value: 4},
+		{Op: EditUpdate, Key: "a", Value: 10},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, []Item[string, int]{{"c", 3}, {"d", 4}})
+	want := []Item[string, int]{{"a", 10}, {"b", 2}}
+	if diff := cmp.Diff(want, evicted); diff != "" {
+		t.Fatalf("unexpected evictions (-want +got):\n%s", diff)
+	}
+}
+
+func TestApplyAccessOrder(t *testing.T) {
+	m := New[string, int](WithMaxEntries[string, int](3, EvictLRU, nil))
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+	err := m.Apply([]Edit[string, int]{
+		{Op: EditUpdate, Key: "a", Value: 10},
+		{Op: EditSet, Key: "b", Value: 20},
+		{Op: EditDelete, Key: "zz"},
+	})
+	if !errors.Is(err, ErrKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrKeyMissing, err)
+	}
+	checkAll(t, m, []Item[string, int]{{"a", 1}, {"b", 2}, {"c", 3}})
+}
+
+func TestApplyWriteHook(t *testing.T) {
+	var got []Edit[string, int]
+	m := New[string, int](WithWriteHook(func(edits []Edit[string, int]) {
+		got = append(got, edits...)
+	}, 0))
+	m.Set("a", 1)
+	got = nil
+	err := m.Apply([]Edit[string, int]{
+		{Op: EditPushBack, Key: "b", Value: 2},
+		{Op: EditDelete, Key: "a"},
+		{Op: EditDelete, Key: "zz"},
+	})
+	if !errors.Is(err, ErrKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrKeyMissing, err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("unexpected edits notified: %v", got)
+	}
+	edits := []Edit[string, int]{
+		{Op: EditPushBack, Key: "b", Value: 2},
+		{Op: EditDelete, Key: "a"},
+	}
+	if err := m.Apply(edits); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(edits, got); diff != "" {
+		t.Fatalf("unexpected edits notified (-want +got):\n%s", diff)
+	}
+}
+
+func TestApplyInvalidOp(t *testing.T) {
+	m := newFromItems(t, []Item[string, int]{{"a", 1}})
+	err := m.Apply([]Edit[string, int]{
+		{Op: EditDelete, Key: "a"},
+		{Op: EditOp(42), Key: "a"},
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	checkAll(t, m, []Item[string, int]{{"a", 1}})
+}
+
+func TestEditOpString(t *testing.T) {
+	cases := []struct {
+		op   EditOp
+		want string
+	}{
+		{EditPushFront, "PushFront"},
+		{EditMoveBefore, "MoveBefore"},
+		{EditEvict, "Evict"},
+		{EditOp(-1), "EditOp(-1)"},
+		{EditOp(42), "EditOp(42)"},
+	}
+	for _, c := range cases {
+		if got := c.op.String(); got != c.want {
+			t.Fatalf("unexpected string: want: %s, got %s", c.want, got)
+		}
+	}
+}
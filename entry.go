@@ -0,0 +1,169 @@
+package orderedmap
+
+import "github.com/lorenzosaino/go-orderedmap/list"
+
+// Entry is a handle to an item of an ordered map.
+//
+// Entries let callers walk the map and pass items back to it without
+// looking up their keys, for example to repeatedly scan from the front of the
+// map. An entry remains valid until its item is deleted from the map.
+type Entry[K comparable, V any] struct {
+	el *list.Element[Item[K, V]]
+}
+
+func newEntry[K comparable, V any](el *list.Element[Item[K, V]]) (Entry[K, V], bool) {
+	return Entry[K, V]{el: el}, el != nil
+}
+
+// Key returns the key of the entry.
+func (e Entry[K, V]) Key() K {
+	return e.el.Value.Key
+}
+
+// Value returns the value of the entry.
+func (e Entry[K, V]) Value() V {
+	return e.el.Value.Value
+}
+
+// Item returns the item of the entry.
+func (e Entry[K, V]) Item() Item[K, V] {
+	return e.el.Value
+}
+
+// Next returns the next entry of the map.
+//
+// If e is at the back of the map or has been deleted, ok is set to false.
+func (e Entry[K, V]) Next() (next Entry[K, V], ok bool) {
+	return newEntry(e.el.Next())
+}
+
+// Prev returns the previous entry of the map.
+//
+// If e is at the front of the map or has been deleted, ok is set to false.
+func (e Entry[K, V]) Prev() (prev Entry[K, V], ok bool) {
+	return newEntry(e.el.Prev())
+}
+
+// GetEntry returns the entry of a key.
+//
+// If the key is not in the map, ok is set to false.
+func (m *OrderedMap[K, V]) GetEntry(key K) (e Entry[K, V], ok bool) {
+	key = m.normalize(key)
+	return newEntry(m.m[key])
+}
+
+// FrontEntry returns the entry at the front of the map.
+//
+// If the map is empty, ok is set to false.
+func (m *OrderedMap[K, V]) FrontEntry() (e Entry[K, V], ok bool) {
+	return newEntry(m.l.Front())
+}
+
+// BackEntry returns the entry at the back of the map.
+//
+// If the map is empty, ok is set to false.
+func (m *OrderedMap[K, V]) BackEntry() (e Entry[K, V], ok bool) {
+	return newEntry(m.l.Back())
+}
+
+// element returns the element of an entry in the map, or nil if the item of
+// the entry has been deleted.
+func (m *OrderedMap[K, V]) element(e Entry[K, V]) *list.Element[Item[K, V]] {
+	if e.el == nil || e.el.List() != m.l {
+		return nil
+	}
+	return e.el
+}
+
+// UpdateEntry updates the value of an entry without moving it.
+//
+// It returns ErrKeyMissing if the item of the entry has been deleted.
+func (m *OrderedMap[K, V]) UpdateEntry(e Entry[K, V], value V) error {
+	m.beforeWrite()
+	el := m.element(e)
+	if el == nil {
+		return ErrKeyMissing
+	}
+	m.update(el, value)
+	return nil
+}
+
+// DeleteEntry deletes an entry from the map and returns its value.
+//
+// It returns ErrKeyMissing if the item of the entry has already been deleted.
+func (m *OrderedMap[K, V]) DeleteEntry(e Entry[K, V]) (V, error) {
+	m.beforeWrite()
+	el := m.element(e)
+	if el == nil {
+		var zero V
+		return zero, ErrKeyMissing
+	}
+	return m.remove(el).Value, nil
+}
+
+// MoveEntryToFront moves an entry to the front of the map.
+//
+// It returns ErrKeyMissing if the item of the entry has been deleted.
+func (m *OrderedMap[K, V]) MoveEntryToFront(e Entry[K, V]) error {
+	m.beforeWrite()
+	el := m.element(e)
+	if el == nil {
+		return ErrKeyMissing
+	}
+	m.move(el, m.l.Front())
+	return nil
+}
+
+// MoveEntryToBack moves an entry to the back of the map.
+//
+// It returns ErrKeyMissing if the item of the entry has been deleted.
+func (m *OrderedMap[K, V]) MoveEntryToBack(e Entry[K, V]) error {
+	m.beforeWrite()
+	el := m.element(e)
+	if el == nil {
+		return ErrKeyMissing
+	}
+	m.move(el, nil)
+	return nil
+}
+
+// MoveEntryAfter moves an entry immediately after a mark entry.
+//
+// It returns ErrKeyMissing if the item of the entry to be moved has been
+// deleted and ErrMarkKeyMissing if the item of the mark entry has been
+// deleted.
+func (m *OrderedMap[K, V]) MoveEntryAfter(e, mark Entry[K, V]) error {
+	m.beforeWrite()
+	el, markEl, err := m.elements(e, mark)
+	if err != nil || el == markEl {
+		return err
+	}
+	m.move(el, markEl.Next())
+	return nil
+}
+
+// MoveEntryBefore moves an entry immediately before a mark entry.
+//
+// It returns ErrKeyMissing if the item of the entry to be moved has been
+// deleted and ErrMarkKeyMissing if the item of the mark entry has been
+// deleted.
+func (m *OrderedMap[K, V]) MoveEntryBefore(e, mark Entry[K, V]) error {
+	m.beforeWrite()
+	el, markEl, err := m.elements(e, mark)
+	if err != nil {
+		return err
+	}
+	m.move(el, markEl)
+	return nil
+}
+
+// elements returns the elements of an entry and a mark entry.
+func (m *OrderedMap[K, V]) elements(e, mark Entry[K, V]) (el, markEl *list.Element[Item[K, V]], err error) {
+	if el = m.element(e); el == nil {
+		return nil, nil, ErrKeyMissing
+	}
+	if markEl = m.element(mark); markEl == nil {
+		return nil, nil, ErrMarkKeyMissing
+	}
+	return el, markEl, nil
+}
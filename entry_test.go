@@ -0,0 +1,94 @@
+package orderedmap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEntryIterate(t *testing.T) {
+	items := []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}}
+	m := newFromItems(t, items)
+
+	var got []Item[int, string]
+	for e, ok := m.FrontEntry(); ok; e, ok = e.Next() {
+		got = append(got, Item[int, string]{e.Key(), e.Value()})
+	}
+	if diff := cmp.Diff(items, got); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+
+	got = nil
+	for e, ok := m.BackEntry(); ok; e, ok = e.Prev() {
+		got = append(got, e.Item())
+	}
+	want := []Item[int, string]{{3, "three"}, {2, "two"}, {1, "one"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+
+	if _, ok := m.GetEntry(4); ok {
+		t.Fatal("unexpected entry for missing key")
+	}
+	empty := New[int, string]()
+	if _, ok := empty.FrontEntry(); ok {
+		t.Fatal("unexpected front entry of empty map")
+	}
+	if _, ok := empty.BackEntry(); ok {
+		t.Fatal("unexpected back entry of empty map")
+	}
+}
+
+func TestEntryModify(t *testing.T) {
+	m := newFromItems(t, []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}})
+	e1, _ := m.GetEntry(1)
+	e2, _ := m.GetEntry(2)
+	e3, _ := m.GetEntry(3)
+	e4, _ := m.GetEntry(4)
+
+	if err := m.MoveEntryToBack(e1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, []Item[int, string]{{2, "two"}, {3, "three"}, {4, "four"}, {1, "one"}})
+	if err := m.MoveEntryToFront(e4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, []Item[int, string]{{4, "four"}, {2, "two"}, {3, "three"}, {1, "one"}})
+	if err := m.MoveEntryAfter(e2, e3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, []Item[int, string]{{4, "four"}, {3, "three"}, {2, "two"}, {1, "one"}})
+	if err := m.MoveEntryBefore(e1, e4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, []Item[int, string]{{1, "one"}, {4, "four"}, {3, "three"}, {2, "two"}})
+	if err := m.UpdateEntry(e3, "THREE"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, err := m.DeleteEntry(e4); err != nil || v != "four" {
+		t.Fatalf("unexpected result: want: (four, <nil>), got (%v, %v)", v, err)
+	}
+	checkAll(t, m, []Item[int, string]{{1, "one"}, {3, "THREE"}, {2, "two"}})
+
+	// deleted entries
+	if _, ok := e4.Next(); ok {
+		t.Fatal("unexpected next entry of deleted entry")
+	}
+	if _, err := m.DeleteEntry(e4); !errors.Is(err, ErrKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrKeyMissing, err)
+	}
+	if err := m.UpdateEntry(e4, "x"); !errors.Is(err, ErrKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrKeyMissing, err)
+	}
+	if err := m.MoveEntryToFront(e4); !errors.Is(err, ErrKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrKeyMissing, err)
+	}
+	if err := m.MoveEntryAfter(e4, e1); !errors.Is(err, ErrKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrKeyMissing, err)
+	}
+	if err := m.MoveEntryBefore(e1, e4); !errors.Is(err, ErrMarkKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrMarkKeyMissing, err)
+	}
+	checkAll(t, m, []Item[int, string]{{1, "one"}, {3, "THREE"}, {2, "two"}})
+}
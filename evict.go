@@ -0,0 +1,182 @@
+package orderedmap
+
+import "github.com/lorenzosaino/go-orderedmap/list"
+
+// EvictionPolicy specifies which items are evicted from a map bounded with
+// WithMaxEntries.
+type EvictionPolicy int
+
+const (
+	// EvictOldest evicts the item at the front of the map, which is the
+	// least recently inserted one if items are inserted at the back.
+	EvictOldest EvictionPolicy = iota
+
+	// EvictLRU evicts the least recently used item, by keeping the map in
+	// access order as WithAccessOrder.
+	EvictLRU
+)
+
+// WithMaxEntries bounds the number of items of the map to n, which must be
+// positive. Whenever an insertion makes the map exceed n items, the item at
+// the front of the map is evicted according to policy and, if onEvict is not
+// nil, passed to onEvict. Note that the item evicted is the one just inserted
+// if it was inserted at the front of the map.
+//
+// EvictLRU implies WithAccessOrder, and EvictOldest combined with
+// WithAccessOrder is equivalent to EvictLRU.
+//
+// The key and value types of onEvict must match those of the map, or New
+// panics. If onEvict is nil, they must be specified explicitly.
+func WithMaxEntries[K comparable, V any](n int, policy EvictionPolicy, onEvict func(key K, value V)) Option {
+	if n < 1 {
+		panic("orderedmap: maximum number of entries must be positive")
+	}
+	return func(o *options) {
+		o.maxEntries = n
+		if policy == EvictLRU {
+			o.accessOrder = true
+		}
+		if onEvict != nil {
+			o.onEvict = onEvict
+		}
+	}
+}
+
+// WithRing bounds the number of items of the map to n, which must be
+// positive, making it a ring buffer of fixed capacity: whenever an insertion
+// makes the map exceed n items, the item at the front of the map is evicted.
+// It is equivalent to WithMaxEntries with EvictOldest and no onEvict
+// callback, but it does not require specifying the key and value types of
+// the map. PushBackEvict returns the items evicted by insertions at the back.
+func WithRing(n int) Option {
+	if n < 1 {
+		panic("orderedmap: ring capacity must be positive")
+	}
+	return func(o *options) {
+		o.maxEntries = n
+	}
+}
+
+// PushBackEvict inserts a new key and value at the back of a bounded map,
+// like PushBack, and returns the item previously at the front of the map if
+// it has been evicted by the insertion, in which case ok is set to true.
+//
+// For maps bounded with WithRing or WithMaxEntries, the front item is evicted
+// whenever the map was full. Maps bounded with WithMaxBytes may evict more
+// than one item, in which case only the first is returned, while all of them
+// are passed to the onEvict callback, if any.
+//
+// It returns ErrKeyAlreadyPresent if the key to be inserted is already present.
+func (m *OrderedMap[K, V]) PushBackEvict(key K, value V) (evicted Item[K, V], ok bool, err error) {
+	front, hasFront := m.Front()
+	if err := m.PushBack(key, value); err != nil {
+		return evicted, false, err
+	}
+	if hasFront && !m.Has(front.Key) {
+		return front, true, nil
+	}
+	return evicted, false, nil
+}
+
+// WithAccessOrder configures the map to be in access order, like a Java
+// LinkedHashMap with accessOrder set to true: accessing an item with Get,
+// GetOrCompute, GetOrLoad, Set, Update or Upsert moves it to the back of the
+// map, so that items are ordered from the least to the most recently used one.
+//
+// These methods then modify the map, so they must not be invoked concurrently
+// even if the map is only read.
+func WithAccessOrder() Option {
+	return func(o *options) {
+		o.accessOrder = true
+	}
+}
+
+// access must be called whenever an element is accessed by a method that
+// determines its recency in access order, after beforeWrite.
+func (m *OrderedMap[K, V]) access(el *list.Element[Item[K, V]]) {
+	if m.accessOrdered() {
+		m.move(el, nil)
+	}
+}
+
+// accessOrdered reports whether accessing a key moves it to the back of the
+// map, which is the case for maps in access order unless frozen.
+func (m *OrderedMap[K, V]) accessOrdered() bool {
+	return m.opts.accessOrder && !m.frozen && !m.applying
+}
+
+// WithMaxBytes bounds the total weight of the items of the map to n, which
+// must be positive, where the weight of each item is returned by weigh. This
+// is typically the size in bytes of variable-size values, making the map
+// suitable for caching them within a memory budget.
+//
+// Whenever an insertion or an update makes the total weight exceed n, items
+// are evicted from the front of the map, and passed to onEvict if not nil,
+// until it no longer does. An item heavier than n is therefore evicted as soon
+// as it is inserted, along with all other items. WithMaxBytes can be combined
+// with WithMaxEntries and WithAccessOrder, in which case the least recently
+// used items are evicted first; only one onEvict callback can be set.
+//
+// weigh must always return the same non-negative weight for the same key and
+// value. The key and value types of weigh and onEvict must match those of
+// the map, or New panics.
+func WithMaxBytes[K comparable, V any](n int64, weigh func(key K, value V) int64, onEvict func(key K, value V)) Option {
+	if n < 1 {
+		panic("orderedmap: maximum weight must be positive")
+	}
+	if weigh == nil {
+		panic("orderedmap: weigh function must not be nil")
+	}
+	return func(o *options) {
+		o.maxBytes = n
+		o.weigh = weigh
+		if onEvict != nil {
+			o.onEvict = onEvict
+		}
+	}
+}
+
+// Weight returns the total weight of the items of a map bounded with
+// WithMaxBytes, or 0 for other maps.
+func (m *OrderedMap[K, V]) Weight() int64 {
+	return m.weight
+}
+
+// weighIn adds the weight of an element to the weight of the map.
+func (m *OrderedMap[K, V]) weighIn(el *list.Element[Item[K, V]]) {
+	if m.weigh != nil {
+		m.weight += m.weigh(el.Value.Key, el.Value.Value)
+	}
+}
+
+// weighOut subtracts the weight of an element from the weight of the map.
+func (m *OrderedMap[K, V]) weighOut(el *list.Element[Item[K, V]]) {
+	if m.weigh != nil {
+		m.weight -= m.weigh(el.Value.Key, el.Value.Value)
+	}
+}
+
+// evictExcess evicts front elements until the map no longer exceeds its
+// maximum number of entries and weight.
+func (m *OrderedMap[K, V]) evictExcess() {
+	if m.applying {
+		return
+	}
+	for len(m.m) > 0 && ((m.opts.maxEntries > 0 && len(m.m) > m.opts.maxEntries) ||
+		(m.opts.maxBytes > 0 && m.weight > m.opts.maxBytes)) {
+		m.evict()
+	}
+}
+
+// evict evicts the front element.
+func (m *OrderedMap[K, V]) evict() {
+	el := m.l.Front()
+	item := m.unlink(el, EditEvict)
+	m.free(el)
+	if m.stats != nil {
+		m.stats.Evictions++
+	}
+	if m.onEvict != nil {
+		m.onEvict(item.Key, item.Value)
+	}
+}
@@ -0,0 +1,377 @@
+package orderedmap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithMaxEntries(t *testing.T) {
+	cases := []struct {
+		name    string
+		policy  EvictionPolicy
+		ops     func(m *OrderedMap[int, string])
+		want    []Item[int, string]
+		evicted []Item[int, string]
+	}{
+		{
+			name:   "below limit",
+			policy: EvictOldest,
+			ops: func(m *OrderedMap[int, string]) {
+				m.PushBack(1, "one")
+				m.PushBack(2, "two")
+			},
+			want:    []Item[int, string]{{1, "one"}, {2, "two"}},
+			evicted: []Item[int, string]{},
+		},
+		{
+			name:   "oldest",
+			policy: EvictOldest,
+			ops: func(m *OrderedMap[int, string]) {
+				m.PushBack(1, "one")
+				m.PushBack(2, "two")
+				m.PushBack(3, "three")
+				m.Get(1)
+				m.Set(2, "TWO")
+				m.PushBack(4, "four")
+				m.Set(5, "five")
+			},
+			want:    []Item[int, string]{{3, "three"}, {4, "four"}, {5, "five"}},
+			evicted: []Item[int, string]{{1, "one"}, {2, "TWO"}},
+		},
+		{
+			name:   "lru",
+			policy: EvictLRU,
+			ops: func(m *OrderedMap[int, string]) {
+				m.PushBack(1, "one")
+				m.PushBack(2, "two")
+				m.PushBack(3, "three")
+				m.Get(1)
+				m.Set(2, "TWO")
+				m.PushBack(4, "four")
+				m.Update(1, "ONE")
+				m.GetOrCompute(5, func() string { return "five" })
+				m.Upsert(4, "!", func(old, new string) string { return old + new })
+				m.GetOrCompute(1, func() string { return "uno" })
+			},
+			want:    []Item[int, string]{{5, "five"}, {4, "four!"}, {1, "ONE"}},
+			evicted: []Item[int, string]{{3, "three"}, {2, "TWO"}},
+		},
+		{
+			name:   "push front",
+			policy: EvictOldest,
+			ops: func(m *OrderedMap[int, string]) {
+				m.PushBack(1, "one")
+				m.PushBack(2, "two")
+				m.PushBack(3, "three")
+				m.PushFront(4, "four")
+				m.InsertAfter(5, "five", 1)
+			},
+			want:    []Item[int, string]{{5, "five"}, {2, "two"}, {3, "three"}},
+			evicted: []Item[int, string]{{4, "four"}, {1, "one"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			evicted := []Item[int, string]{}
+			m := New[int, string](WithMaxEntries(3, c.policy, func(key int, value string) {
+				evicted = append(evicted, Item[int, string]{key, value})
+			}))
+			c.ops(m)
+			checkAll(t, m, c.want)
+			if diff := cmp.Diff(c.evicted, evicted); diff != "" {
+				t.Fatalf("unexpected evicted items (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithMaxEntriesClone(t *testing.T) {
+	n := 0
+	m := New[int, string](WithMaxEntries(2, EvictLRU, func(key int, value string) { n++ }))
+	m.PushBack(1, "one")
+	m.PushBack(2, "two")
+	c := m.Clone()
+	c.Get(1)
+	c.PushBack(3, "three")
+	checkAll(t, c, []Item[int, string]{{1, "one"}, {3, "three"}})
+	checkAll(t, m, []Item[int, string]{{1, "one"}, {2, "two"}})
+	if n != 1 {
+		t.Fatalf("unexpected number of evictions: want: 1, got %d", n)
+	}
+}
+
+func TestWithMaxEntriesPositionIndex(t *testing.T) {
+	m := New[int, string](WithPositionIndex(), WithMaxEntries[int, string](2, EvictLRU, nil))
+	m.PushBack(1, "one")
+	m.PushBack(2, "two")
+	m.Get(1)
+	m.PushBack(3, "three")
+	checkAll(t, m, []Item[int, string]{{1, "one"}, {3, "three"}})
+}
+
+func TestWithMaxEntriesInvalid(t *testing.T) {
+	t.Run("non-positive", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected panic")
+			}
+		}()
+		WithMaxEntries[int, string](0, EvictOldest, nil)
+	})
+	t.Run("callback type", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected panic")
+			}
+		}()
+		New[int, int](WithMaxEntries(1, EvictOldest, func(key int, value string) {}))
+	})
+}
+
+func TestWithRing(t *testing.T) {
+	m := New[string, int](WithRing(3))
+	var evicted []Item[string, int]
+	for i, key := range []string{"a", "b", "c", "d", "e"} {
+		item, ok, err := m.PushBackEvict(key, i)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if ok {
+			evicted = append(evicted, item)
+		}
+	}
+	if _, ok, err := m.PushBackEvict("e", 5); ok || !errors.Is(err, ErrKeyAlreadyPresent) {
+		t.Fatalf("unexpected result: %v, %v", ok, err)
+	}
+	checkAll(t, m, []Item[string, int]{{"c", 2}, {"d", 3}, {"e", 4}})
+	if diff := cmp.Diff([]Item[string, int]{{"a", 0}, {"b", 1}}, evicted); diff != "" {
+		t.Fatalf("unexpected evicted items (-want +got):\n%s", diff)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	WithRing(0)
+}
+
+func TestPushBackEvictMaxBytes(t *testing.T) {
+	var evicted []string
+	m := New[string, string](WithMaxBytes(4, func(key, value string) int64 {
+		return int64(len(value))
+	}, func(key, value string) {
+		evicted = append(evicted, key)
+	}))
+	m.PushBack("a", "1")
+	m.PushBack("b", "22")
+	item, ok, err := m.PushBackEvict("c", "333")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !ok || item != (Item[string, string]{"a", "1"}) {
+		t.Fatalf("unexpected evicted item: %v, %v", item, ok)
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, evicted); diff != "" {
+		t.Fatalf("unexpected evicted keys (-want +got):\n%s", diff)
+	}
+	checkAll(t, m, []Item[string, string]{{"c", "333"}})
+
+	// unbounded maps never evict items
+	u := New[string, string]()
+	u.PushBack("a", "1")
+	if _, ok, err := u.PushBackEvict("b", "2"); ok || err != nil {
+		t.Fatalf("unexpected result: %v, %v", ok, err)
+	}
+}
+
+func TestWithAccessOrder(t *testing.T) {
+	m := New[int, string](WithAccessOrder())
+	m.PushBack(1, "one")
+	m.PushBack(2, "two")
+	m.PushBack(3, "three")
+	m.PushBack(4, "four")
+	m.PushBack(5, "five")
+
+	// reads not counting as accesses do not modify the order
+	m.Has(1)
+	m.Find(func(key int, value string) bool { return key == 1 })
+	checkAll(t, m, []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}, {5, "five"}})
+
+	m.Get(1)
+	m.Set(2, "TWO")
+	m.Update(3, "THREE")
+	m.Upsert(4, "!", func(old, new string) string { return old + new })
+	m.GetOrCompute(1, func() string { return "uno" })
+	if _, ok := m.Get(6); ok {
+		t.Fatal("missing key found")
+	}
+	checkAll(t, m, []Item[int, string]{{5, "five"}, {2, "TWO"}, {3, "THREE"}, {4, "four!"}, {1, "one"}})
+
+	// new items are inserted at the back
+	m.Set(6, "six")
+	checkAll(t, m, []Item[int, string]{{5, "five"}, {2, "TWO"}, {3, "THREE"}, {4, "four!"}, {1, "one"}, {6, "six"}})
+}
+
+func TestWithMaxBytes(t *testing.T) {
+	weigh := func(key int, value string) int64 { return int64(len(value)) }
+	cases := []struct {
+		name    string
+		opts    []Option
+		ops     func(m *OrderedMap[int, string])
+		want    []Item[int, string]
+		evicted []Item[int, string]
+	}{
+		{
+			name: "below limit",
+			ops: func(m *OrderedMap[int, string]) {
+				m.Set(1, "aaaa")
+				m.Set(2, "bbbbbb")
+			},
+			want:    []Item[int, string]{{1, "aaaa"}, {2, "bbbbbb"}},
+			evicted: []Item[int, string]{},
+		},
+		{
+			name: "insert",
+			ops: func(m *OrderedMap[int, string]) {
+				m.Set(1, "aaaa")
+				m.Set(2, "bb")
+				m.Set(3, "ccc")
+				m.Set(4, "dddddd")
+			},
+			want:    []Item[int, string]{{3, "ccc"}, {4, "dddddd"}},
+			evicted: []Item[int, string]{{1, "aaaa"}, {2, "bb"}},
+		},
+		{
+			name: "update",
+			ops: func(m *OrderedMap[int, string]) {
+				m.Set(1, "aaaa")
+				m.Set(2, "bb")
+				m.Set(3, "ccc")
+				m.Set(3, "cccccc")
+			},
+			want:    []Item[int, string]{{2, "bb"}, {3, "cccccc"}},
+			evicted: []Item[int, string]{{1, "aaaa"}},
+		},
+		{
+			name: "update front",
+			ops: func(m *OrderedMap[int, string]) {
+				m.Set(1, "a")
+				m.Set(2, "b")
+				m.Update(1, "aaaaaaaaaa")
+			},
+			want:    []Item[int, string]{{2, "b"}},
+			evicted: []Item[int, string]{{1, "aaaaaaaaaa"}},
+		},
+		{
+			name: "too heavy",
+			ops: func(m *OrderedMap[int, string]) {
+				m.Set(1, "a")
+				m.Set(2, "bbbbbbbbbbb")
+			},
+			want:    []Item[int, string]{},
+			evicted: []Item[int, string]{{1, "a"}, {2, "bbbbbbbbbbb"}},
+		},
+		{
+			name: "push front items",
+			ops: func(m *OrderedMap[int, string]) {
+				m.Set(1, "aaaa")
+				m.Set(2, "bbbb")
+				m.PushFrontItems(Item[int, string]{3, "c"}, Item[int, string]{4, "ddd"})
+			},
+			want:    []Item[int, string]{{1, "aaaa"}, {2, "bbbb"}},
+			evicted: []Item[int, string]{{3, "c"}, {4, "ddd"}},
+		},
+		{
+			name: "lru",
+			opts: []Option{WithAccessOrder()},
+			ops: func(m *OrderedMap[int, string]) {
+				m.Set(1, "aaaa")
+				m.Set(2, "bbbb")
+				m.Get(1)
+				m.Upsert(2, "bbb", func(old, new string) string { return old + new })
+			},
+			want:    []Item[int, string]{{2, "bbbbbbb"}},
+			evicted: []Item[int, string]{{1, "aaaa"}},
+		},
+		{
+			name: "max entries",
+			opts: []Option{WithMaxEntries[int, string](2, EvictOldest, nil)},
+			ops: func(m *OrderedMap[int, string]) {
+				m.Set(1, "a")
+				m.Set(2, "b")
+				m.Set(3, "c")
+			},
+			want:    []Item[int, string]{{2, "b"}, {3, "c"}},
+			evicted: []Item[int, string]{{1, "a"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			evicted := []Item[int, string]{}
+			opts := append([]Option{WithMaxBytes(10, weigh, func(key int, value string) {
+				evicted = append(evicted, Item[int, string]{key, value})
+			})}, c.opts...)
+			m := New[int, string](opts...)
+			c.ops(m)
+			checkAll(t, m, c.want)
+			if diff := cmp.Diff(c.evicted, evicted); diff != "" {
+				t.Fatalf("unexpected evicted items (-want +got):\n%s", diff)
+			}
+			var weight int64
+			for _, item := range c.want {
+				weight += weigh(item.Key, item.Value)
+			}
+			if got := m.Weight(); got != weight {
+				t.Fatalf("unexpected weight: want: %d, got %d", weight, got)
+			}
+		})
+	}
+}
+
+func TestWithMaxBytesWeight(t *testing.T) {
+	weigh := func(key string, value []byte) int64 { return int64(len(key) + len(value)) }
+	m := New[string, []byte](WithMaxBytes(100, weigh, nil))
+	m.Set("a", []byte("xyz"))
+	m.Set("bb", []byte("x"))
+	if err := m.ReplaceKey("a", "aaa"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w := m.Weight(); w != 9 {
+		t.Fatalf("unexpected weight: want: 9, got %d", w)
+	}
+	m.Delete("bb")
+	if w := m.Weight(); w != 6 {
+		t.Fatalf("unexpected weight: want: 6, got %d", w)
+	}
+	if w := m.Clone().Weight(); w != 6 {
+		t.Fatalf("unexpected weight of clone: want: 6, got %d", w)
+	}
+	m.Clear()
+	if w := m.Weight(); w != 0 {
+		t.Fatalf("unexpected weight: want: 0, got %d", w)
+	}
+	if w := New[string, []byte]().Weight(); w != 0 {
+		t.Fatalf("unexpected weight of unbounded map: want: 0, got %d", w)
+	}
+}
+
+func TestWithMaxBytesInvalid(t *testing.T) {
+	weigh := func(key, value int) int64 { return 1 }
+	for name, f := range map[string]func(){
+		"zero":     func() { WithMaxBytes(0, weigh, nil) },
+		"nil":      func() { WithMaxBytes[int, int](10, nil, nil) },
+		"mismatch": func() { New[string, int](WithMaxBytes(10, weigh, nil)) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("expected panic")
+				}
+			}()
+			f()
+		})
+	}
+}
@@ -88,3 +88,20 @@ func ExampleOrderedMap_Reverse() {
 	// 2 two
 	// 1 one
 }
+
+func ExampleOrderedMap_Upsert() {
+	m := orderedmap.New[string, int]()
+	for _, word := range []string{"to", "be", "or", "not", "to", "be"} {
+		m.Upsert(word, 1, func(old, new int) int { return old + new })
+	}
+
+	m.Range(func(word string, count int) bool {
+		fmt.Println(word, count)
+		return true
+	})
+	// Output:
+	// to 2
+	// be 2
+	// or 1
+	// not 1
+}
@@ -0,0 +1,30 @@
+package orderedmap
+
+import (
+	"encoding/json"
+	"expvar"
+	"sync"
+)
+
+// ExpvarVar returns an expvar.Var whose String method returns m encoded
+// as a JSON object, as returned by MarshalJSON, so that it can be published
+// with expvar.Publish with members in a deterministic order. If m cannot be
+// encoded, the variable holds the error message as a JSON string.
+//
+// If m is modified concurrently with its publication, mu must be the lock
+// protecting it, for example the value returned by the RLocker method of
+// a sync.RWMutex. It is acquired every time the variable is read. Otherwise,
+// mu can be nil.
+func ExpvarVar[K comparable, V any](m *OrderedMap[K, V], mu sync.Locker) expvar.Var {
+	return expvar.Func(func() any {
+		if mu != nil {
+			mu.Lock()
+			defer mu.Unlock()
+		}
+		b, err := m.MarshalJSON()
+		if err != nil {
+			return err.Error()
+		}
+		return json.RawMessage(b)
+	})
+}
@@ -0,0 +1,49 @@
+package orderedmap
+
+import (
+	"encoding/json"
+	"expvar"
+	"sync"
+	"testing"
+)
+
+func TestExpvarVar(t *testing.T) {
+	m := newFromItems(t, []Item[string, int]{{"b", 2}, {"a", 1}})
+	var mu sync.RWMutex
+	v := ExpvarVar(m, mu.RLocker())
+	if want, got := `{"b":2,"a":1}`, v.String(); got != want {
+		t.Fatalf("unexpected string: want: %s, got %s", want, got)
+	}
+
+	// the variable reflects later updates
+	mu.Lock()
+	m.PushFront("c", 3)
+	mu.Unlock()
+	if want, got := `{"c":3,"b":2,"a":1}`, v.String(); got != want {
+		t.Fatalf("unexpected string: want: %s, got %s", want, got)
+	}
+}
+
+func TestExpvarVarNilLock(t *testing.T) {
+	m := newFromItems(t, []Item[int, string]{{2, "two"}, {1, "one"}})
+	if want, got := `{"2":"two","1":"one"}`, ExpvarVar(m, nil).String(); got != want {
+		t.Fatalf("unexpected string: want: %s, got %s", want, got)
+	}
+}
+
+func TestExpvarVarError(t *testing.T) {
+	m := newFromItems(t, []Item[string, any]{{"a", make(chan int)}})
+	got := ExpvarVar(m, nil).String()
+	var s string
+	if err := json.Unmarshal([]byte(got), &s); err != nil {
+		t.Fatalf("expected a valid JSON string, got %s", got)
+	}
+}
+
+func TestExpvarVarPublish(t *testing.T) {
+	m := newFromItems(t, []Item[string, int]{{"b", 2}, {"a", 1}})
+	expvar.Publish("orderedmap-test", ExpvarVar(m, nil))
+	if want, got := `{"b":2,"a":1}`, expvar.Get("orderedmap-test").String(); got != want {
+		t.Fatalf("unexpected string: want: %s, got %s", want, got)
+	}
+}
@@ -0,0 +1,67 @@
+package orderedmap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Flag is a command-line flag value accumulating key=value arguments into an
+// ordered map, in the order in which they are supplied.
+//
+// It implements the flag.Value interface as well as the Type method required
+// by github.com/spf13/pflag. Keys and values are parsed from their textual
+// representation and must either implement encoding.TextUnmarshaler or be
+// strings, booleans, integers or floating point numbers.
+//
+// If the same key is supplied more than once, its value is overridden by the
+// last occurrence but its position is the one of the first occurrence.
+type Flag[K comparable, V any] struct {
+	m *OrderedMap[K, V]
+}
+
+// NewFlag returns a flag value storing the parsed arguments into m.
+func NewFlag[K comparable, V any](m *OrderedMap[K, V]) *Flag[K, V] {
+	return &Flag[K, V]{m: m}
+}
+
+// String returns the items of the map as a comma-separated list
+// of key=value pairs.
+func (f *Flag[K, V]) String() string {
+	if f == nil || f.m == nil {
+		return ""
+	}
+	var sb strings.Builder
+	for el := f.m.l.Front(); el != nil; el = el.Next() {
+		if el != f.m.l.Front() {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(formatTextOrDefault(el.Value.Key))
+		sb.WriteByte('=')
+		sb.WriteString(formatTextOrDefault(el.Value.Value))
+	}
+	return sb.String()
+}
+
+// Set parses a key=value argument and stores it into the map.
+func (f *Flag[K, V]) Set(s string) error {
+	k, v, ok := strings.Cut(s, "=")
+	if !ok {
+		return fmt.Errorf("invalid argument %q: expected key=value", s)
+	}
+	var key K
+	if err := parseText(k, &key); err != nil {
+		return fmt.Errorf("invalid key %q: %w", k, err)
+	}
+	var value V
+	if err := parseText(v, &value); err != nil {
+		return fmt.Errorf("invalid value %q: %w", v, err)
+	}
+	f.m.Set(key, value)
+	return nil
+}
+
+// Type returns the name of the type of the flag value, as shown in the
+// usage message of github.com/spf13/pflag.
+func (f *Flag[K, V]) Type() string {
+	return "key=value"
+}
@@ -0,0 +1,94 @@
+package orderedmap
+
+import (
+	"flag"
+	"io"
+	"testing"
+)
+
+var _ flag.Value = (*Flag[string, string])(nil)
+
+func TestFlag(t *testing.T) {
+	cases := []struct {
+		name  string
+		args  []string
+		want  []Item[string, int]
+		fails bool
+	}{
+		{
+			name: "no arguments",
+			args: []string{},
+			want: []Item[string, int]{},
+		},
+		{
+			name: "multiple arguments",
+			args: []string{"-set", "b=2", "-set", "a=1", "-set", "c=3"},
+			want: []Item[string, int]{{"b", 2}, {"a", 1}, {"c", 3}},
+		},
+		{
+			name: "repeated key",
+			args: []string{"-set", "b=2", "-set", "a=1", "-set", "b=3"},
+			want: []Item[string, int]{{"b", 3}, {"a", 1}},
+		},
+		{
+			name:  "missing separator",
+			args:  []string{"-set", "a"},
+			fails: true,
+		},
+		{
+			name:  "invalid value",
+			args:  []string{"-set", "a=one"},
+			fails: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := New[string, int]()
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(io.Discard)
+			fs.Var(NewFlag(m), "set", "set a key=value pair")
+			err := fs.Parse(c.args)
+			if c.fails {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestFlagString(t *testing.T) {
+	cases := []struct {
+		name string
+		flag *Flag[string, string]
+		want string
+	}{
+		{
+			name: "nil",
+			flag: nil,
+			want: "",
+		},
+		{
+			name: "zero value",
+			flag: &Flag[string, string]{},
+			want: "",
+		},
+		{
+			name: "multiple items",
+			flag: NewFlag(newFromItems(t, []Item[string, string]{{"b", "2"}, {"a", "1"}})),
+			want: "b=2,a=1",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.flag.String(); got != c.want {
+				t.Fatalf("unexpected string: want: %q, got %q", c.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package orderedmap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String returns a representation of the map listing its items in order,
+// in the form "orderedmap[key1:value1 key2:value2]".
+func (m *OrderedMap[K, V]) String() string {
+	return fmt.Sprintf("%v", m)
+}
+
+// Format implements the fmt.Formatter interface.
+//
+// Items are printed in order like the fmt package prints built-in maps,
+// formatting keys and values with the same verb and flags. The %#v verb
+// prints a Go-syntax representation of the map and its items.
+func (m *OrderedMap[K, V]) Format(f fmt.State, verb rune) {
+	if m == nil {
+		f.Write([]byte("<nil>"))
+		return
+	}
+	format := formatDirective(f, verb)
+	goSyntax := verb == 'v' && f.Flag('#')
+	if goSyntax {
+		fmt.Fprintf(f, "&%s{", strings.TrimPrefix(fmt.Sprintf("%T", m), "*"))
+	} else {
+		f.Write([]byte("orderedmap["))
+	}
+	if m.m != nil {
+		for el := m.l.Front(); el != nil; el = el.Next() {
+			if el != m.l.Front() {
+				if goSyntax {
+					f.Write([]byte(", "))
+				} else {
+					f.Write([]byte(" "))
+				}
+			}
+			fmt.Fprintf(f, format, el.Value.Key)
+			f.Write([]byte(":"))
+			fmt.Fprintf(f, format, el.Value.Value)
+		}
+	}
+	if goSyntax {
+		f.Write([]byte("}"))
+	} else {
+		f.Write([]byte("]"))
+	}
+}
+
+// formatDirective reconstructs the formatting directive, including flags,
+// width and precision, with which a fmt.Formatter has been invoked.
+func formatDirective(f fmt.State, verb rune) string {
+	var sb strings.Builder
+	sb.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			sb.WriteRune(flag)
+		}
+	}
+	if w, ok := f.Width(); ok {
+		sb.WriteString(strconv.Itoa(w))
+	}
+	if p, ok := f.Precision(); ok {
+		sb.WriteByte('.')
+		sb.WriteString(strconv.Itoa(p))
+	}
+	sb.WriteRune(verb)
+	return sb.String()
+}
@@ -0,0 +1,113 @@
+package orderedmap
+
+import (
+	"fmt"
+	"testing"
+)
+
+var (
+	_ fmt.Stringer  = (*OrderedMap[string, int])(nil)
+	_ fmt.Formatter = (*OrderedMap[string, int])(nil)
+)
+
+type testPoint struct{ X, Y int }
+
+func TestString(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		want  string
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			want:  "orderedmap[]",
+		},
+		{
+			name:  "multiple items",
+			items: []Item[int, string]{{2, "two"}, {1, "one"}},
+			want:  "orderedmap[2:two 1:one]",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if got := m.String(); got != c.want {
+				t.Fatalf("unexpected string: want: %q, got %q", c.want, got)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	m := newFromItems(t, []Item[string, testPoint]{{"b", testPoint{1, 2}}, {"a", testPoint{3, 4}}})
+	cases := []struct {
+		name   string
+		format string
+		m      *OrderedMap[string, testPoint]
+		want   string
+	}{
+		{
+			name:   "nil",
+			format: "%v",
+			m:      nil,
+			want:   "<nil>",
+		},
+		{
+			name:   "zero value",
+			format: "%v",
+			m:      &OrderedMap[string, testPoint]{},
+			want:   "orderedmap[]",
+		},
+		{
+			name:   "default format",
+			format: "%v",
+			m:      m,
+			want:   "orderedmap[b:{1 2} a:{3 4}]",
+		},
+		{
+			name:   "field names",
+			format: "%+v",
+			m:      m,
+			want:   "orderedmap[b:{X:1 Y:2} a:{X:3 Y:4}]",
+		},
+		{
+			name:   "Go syntax",
+			format: "%#v",
+			m:      m,
+			want:   `&orderedmap.OrderedMap[string,github.com/lorenzosaino/go-orderedmap.testPoint]{"b":orderedmap.testPoint{X:1, Y:2}, "a":orderedmap.testPoint{X:3, Y:4}}`,
+		},
+		{
+			name:   "width",
+			format: "%3v",
+			m:      newFromItems(t, []Item[string, testPoint]{{"a", testPoint{1, 2}}}),
+			want:   "orderedmap[  a:{  1   2}]",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := fmt.Sprintf(c.format, c.m); got != c.want {
+				t.Fatalf("unexpected output: want: %q, got %q", c.want, got)
+			}
+		})
+	}
+}
+
+func TestFormatVerbs(t *testing.T) {
+	m := newFromItems(t, []Item[int, float64]{{10, 1.5}, {2, 0.25}})
+	cases := []struct {
+		format string
+		want   string
+	}{
+		{"%d", "orderedmap[10:%!d(float64=1.5) 2:%!d(float64=0.25)]"},
+		{"%x", "orderedmap[a:0x1.8p+00 2:0x1p-02]"},
+		{"%.1f", "orderedmap[%!f(int=10):1.5 %!f(int=2):0.2]"},
+	}
+	for _, c := range cases {
+		t.Run(c.format, func(t *testing.T) {
+			if got := fmt.Sprintf(c.format, m); got != c.want {
+				t.Fatalf("unexpected output: want: %q, got %q", c.want, got)
+			}
+		})
+	}
+}
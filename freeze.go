@@ -0,0 +1,18 @@
+package orderedmap
+
+// Freeze makes the ordered map read-only, so that it can be handed out
+// without making a defensive copy. Any subsequent call to a method modifying
+// the map panics with ErrFrozen. A frozen map cannot be unfrozen.
+//
+// Reading a frozen map does not modify it: keys accessed are not moved if the
+// map is in access order and values loaded by Get or GetOrLoad are not
+// inserted if the map has a loader, so frozen maps can always be read
+// concurrently. Copies of a frozen map made with Clone are not frozen.
+func (m *OrderedMap[K, V]) Freeze() {
+	m.frozen = true
+}
+
+// Frozen reports whether the ordered map has been made read-only by Freeze.
+func (m *OrderedMap[K, V]) Frozen() bool {
+	return m.frozen
+}
@@ -0,0 +1,66 @@
+package orderedmap
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	items := []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}}
+	m := newFromItems(t, items)
+	if m.Frozen() {
+		t.Fatal("new map is frozen")
+	}
+	m.Freeze()
+	if !m.Frozen() {
+		t.Fatal("map is not frozen")
+	}
+
+	for name, f := range map[string]func(){
+		"Set":        func() { m.Set(1, "ONE") },
+		"PushFront":  func() { _ = m.PushFront(4, "four") },
+		"Delete":     func() { m.Delete(1) },
+		"MoveToBack": func() { _ = m.MoveToBack(1) },
+		"Clear":      func() { m.Clear() },
+		"Apply":      func() { _ = m.Apply([]Edit[int, string]{{Op: EditDelete, Key: 1}}) },
+		"Sort":       func() { SortKeys(m) },
+		"Cursor": func() {
+			c := m.Cursor()
+			c.First()
+			c.Delete()
+		},
+		"Entry": func() {
+			e, _ := m.FrontEntry()
+			_ = m.UpdateEntry(e, "ONE")
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if err, _ := recover().(error); !errors.Is(err, ErrFrozen) {
+					t.Fatalf("unexpected panic: want: %v, got %v", ErrFrozen, err)
+				}
+			}()
+			f()
+		})
+	}
+	checkAll(t, m, items)
+
+	clone := m.Clone()
+	if clone.Frozen() {
+		t.Fatal("clone is frozen")
+	}
+	clone.Set(1, "ONE")
+	checkAll(t, clone, []Item[int, string]{{1, "ONE"}, {2, "two"}, {3, "three"}})
+	checkAll(t, m, items)
+}
+
+func TestFreezeAccessOrder(t *testing.T) {
+	m := New[int, string](WithAccessOrder())
+	m.Set(1, "one")
+	m.Set(2, "two")
+	m.Freeze()
+	if v, ok := m.Get(1); !ok || v != "one" {
+		t.Fatalf("unexpected result: want: one (true), got %v (%v)", v, ok)
+	}
+	checkAll(t, m, []Item[int, string]{{1, "one"}, {2, "two"}})
+}
@@ -0,0 +1,86 @@
+package orderedmap
+
+import "github.com/lorenzosaino/go-orderedmap/list"
+
+// WithWriteHook registers hook to be notified of all modifications of the
+// map, each described as an Edit, so that the map can front a durable store
+// or be replicated: applying the edits, in order, with Apply to a copy of the
+// map taken when the hook was last notified reproduces the map.
+//
+// Modifications not directly expressible as a single Edit, such as Sort,
+// Clear or ReplaceKey, are described as a sequence of edits with the same
+// effect. Items evicted from bounded maps are described as EditEvict edits,
+// which copies of the map with the same bounds can apply too. Copies of the
+// map, such as those returned by Clone, do not notify the hook.
+//
+// If batch is lower than 2, hook is invoked synchronously with each edit.
+// Otherwise, edits are buffered and hook is invoked with batch edits at a
+// time, or with all pending edits when Flush is called. In both cases, hook is
+// invoked by the goroutine modifying the map, which blocks until it returns.
+// A write-behind store can make hook hand the edits over to another goroutine.
+//
+// hook must not modify the map nor retain the slice of edits after
+// returning. The key and value types of hook must match those of the map,
+// or New panics.
+func WithWriteHook[K comparable, V any](hook func(edits []Edit[K, V]), batch int) Option {
+	return func(o *options) {
+		o.writeHook = hook
+		o.hookBatch = batch
+	}
+}
+
+// writeHook buffers edits to be delivered to a hook registered with
+// WithWriteHook.
+type writeHook[K comparable, V any] struct {
+	f       func(edits []Edit[K, V])
+	batch   int
+	pending []Edit[K, V]
+
+	// journal is not nil if the map records its changes in a journal
+	journal *journal[K, V]
+}
+
+// Flush invokes the hook registered with WithWriteHook with all pending
+// edits, if any.
+func (m *OrderedMap[K, V]) Flush() {
+	if m.hook == nil || len(m.hook.pending) == 0 {
+		return
+	}
+	m.hook.f(m.hook.pending)
+	var zero Edit[K, V]
+	for i := range m.hook.pending {
+		m.hook.pending[i] = zero
+	}
+	m.hook.pending = m.hook.pending[:0]
+}
+
+// emit records an edit in the journal, if any, and delivers it to the hook,
+// if any, once the batch is complete, or defers it until Apply completes. It
+// must only be called if m.hook is not nil.
+func (m *OrderedMap[K, V]) emit(e Edit[K, V]) {
+	if m.applying {
+		m.applied = append(m.applied, e)
+		return
+	}
+	if m.hook.journal != nil {
+		m.hook.journal.record(e)
+	}
+	if m.hook.f == nil {
+		return
+	}
+	m.hook.pending = append(m.hook.pending, e)
+	if len(m.hook.pending) >= m.hook.batch {
+		m.Flush()
+	}
+}
+
+// emitInsert records the insertion of an element at its current position.
+// It must only be called if m.hook is not nil.
+func (m *OrderedMap[K, V]) emitInsert(el *list.Element[Item[K, V]]) {
+	e := Edit[K, V]{Op: EditPushBack, Key: el.Value.Key, Value: el.Value.Value}
+	if next := el.Next(); next != nil {
+		e.Op = EditInsertBefore
+		e.Mark = next.Value.Key
+	}
+	m.emit(e)
+}
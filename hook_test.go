@@ -0,0 +1,131 @@
+package orderedmap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteHook(t *testing.T) {
+	var got []Edit[int, string]
+	m := New[int, string](WithWriteHook(func(edits []Edit[int, string]) {
+		got = append(got, edits...)
+	}, 0))
+	m.PushBack(1, "one")
+	m.PushFront(2, "two")
+	m.Set(1, "ONE")
+	m.MoveToBack(2)
+	m.ReplaceKey(1, 3)
+	m.Delete(2)
+	m.Clear()
+	want := []Edit[int, string]{
+		{Op: EditPushBack, Key: 1, Value: "one"},
+		{Op: EditInsertBefore, Key: 2, Value: "two", Mark: 1},
+		{Op: EditUpdate, Key: 1, Value: "ONE"},
+		{Op: EditMoveToBack, Key: 2},
+		{Op: EditDelete, Key: 1},
+		{Op: EditInsertBefore, Key: 3, Value: "ONE", Mark: 2},
+		{Op: EditDelete, Key: 2},
+		{Op: EditDelete, Key: 3},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected edits (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteHookBatch(t *testing.T) {
+	var batches [][]Edit[int, string]
+	m := New[int, string](WithWriteHook(func(edits []Edit[int, string]) {
+		batches = append(batches, append([]Edit[int, string](nil), edits...))
+	}, 2))
+	m.PushBack(1, "one")
+	if len(batches) != 0 {
+		t.Fatalf("unexpected batches: %v", batches)
+	}
+	m.PushBack(2, "two")
+	m.PushBack(3, "three")
+	m.Flush()
+	m.Flush()
+	want := [][]Edit[int, string]{
+		{{Op: EditPushBack, Key: 1, Value: "one"}, {Op: EditPushBack, Key: 2, Value: "two"}},
+		{{Op: EditPushBack, Key: 3, Value: "three"}},
+	}
+	if diff := cmp.Diff(want, batches); diff != "" {
+		t.Fatalf("unexpected batches (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteHookCopies(t *testing.T) {
+	n := 0
+	m := New[int, string](WithWriteHook(func(edits []Edit[int, string]) { n += len(edits) }, 0))
+	m.PushBack(1, "one")
+	m.Clone().PushBack(2, "two")
+	m.PushBack(3, "three")
+	if n != 2 {
+		t.Fatalf("unexpected number of edits: want: 2, got %d", n)
+	}
+}
+
+// TestWriteHookReplica verifies that applying the edits notified to the hook
+// reproduces the map, whatever the modifications.
+func TestWriteHookReplica(t *testing.T) {
+	for _, opts := range [][]Option{
+		{},
+		{WithPositionIndex()},
+		{WithMaxEntries[int, int](20, EvictLRU, nil)},
+	} {
+		replica := New[int, int]()
+		m := New[int, int](append(opts, WithWriteHook(func(edits []Edit[int, int]) {
+			if err := replica.Apply(edits); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}, 7))...)
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 5000; i++ {
+			key := r.Intn(30)
+			switch r.Intn(16) {
+			case 0:
+				m.PushFront(key, i)
+			case 1:
+				m.Set(key, i)
+			case 2:
+				m.InsertAfter(key, i, r.Intn(30))
+			case 3:
+				m.MoveBefore(key, r.Intn(30))
+			case 4:
+				m.MoveBy(key, r.Intn(5)-2)
+			case 5:
+				m.Delete(key)
+			case 6:
+				m.Get(key)
+			case 7:
+				m.Upsert(key, i, func(old, new int) int { return old + new })
+			case 8:
+				m.ReplaceKey(key, r.Intn(30))
+			case 9:
+				if m.Len() > 0 {
+					m.RemoveAt(r.Intn(m.Len()))
+				}
+			case 10:
+				m.InsertAt(r.Intn(m.Len()+1), key, i)
+			case 11:
+				m.Touch(key)
+			case 12:
+				m.DeleteFunc(func(key, value int) bool { return value%7 == 0 })
+			case 13:
+				if r.Intn(10) == 0 {
+					m.Sort(func(a, b Item[int, int]) bool { return a.Value < b.Value })
+				}
+			case 14:
+				if r.Intn(50) == 0 {
+					m.Clear()
+				}
+			default:
+				m.PopFront()
+			}
+		}
+		m.Flush()
+		checkAll(t, replica, m.Items())
+	}
+}
@@ -0,0 +1,156 @@
+// Package httpx implements order-preserving HTTP headers and URL query
+// parameters on top of ordered maps.
+//
+// Values provides the methods of http.Header and url.Values with the same
+// signatures, but keeps keys in the order in which they were first added and
+// encodes them in that order, rather than sorting them. This is needed, for
+// example, by proxies forwarding headers unmodified and by middlewares
+// verifying signatures computed over parameters in their original order.
+//
+// This implementation is not safe for concurrent usage.
+package httpx
+
+import (
+	"io"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+// Values maps string keys to lists of values, in the order in which keys
+// were first added.
+type Values struct {
+	m *orderedmap.OrderedMap[string, []string]
+}
+
+// New returns new empty values whose keys are used as they are, like those
+// of url.Values.
+func New() *Values {
+	return &Values{m: orderedmap.New[string, []string]()}
+}
+
+// NewHeader returns new empty values whose keys are case insensitive, like
+// those of http.Header. Keys are canonicalized by
+// textproto.CanonicalMIMEHeaderKey, so "content-type" and "Content-Type"
+// refer to the same key.
+func NewHeader() *Values {
+	return &Values{m: orderedmap.New[string, []string](
+		orderedmap.WithKeyNormalizer(textproto.CanonicalMIMEHeaderKey),
+	)}
+}
+
+// ParseQuery parses a URL-encoded query string into values whose keys are
+// in the order in which they first appear in query, as described by
+// orderedmap.FromQuery.
+func ParseQuery(query string) (*Values, error) {
+	m, err := orderedmap.FromQuery(query)
+	return &Values{m: m}, err
+}
+
+// Get returns the first value associated to a key, or "" if there are none.
+// To access multiple values, use Values.
+func (v *Values) Get(key string) string {
+	values, _ := v.m.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Values returns all values associated to a key. The returned slice is not
+// a copy.
+func (v *Values) Values(key string) []string {
+	values, _ := v.m.Get(key)
+	return values
+}
+
+// Has reports whether a key is present.
+func (v *Values) Has(key string) bool {
+	return v.m.Has(key)
+}
+
+// Set sets the values associated to a key to the single value provided,
+// replacing any existing value. A key already present keeps its position,
+// while a new key is added at the back.
+func (v *Values) Set(key, value string) {
+	v.m.Set(key, []string{value})
+}
+
+// Add adds a value to those associated to a key. A new key is added at the
+// back.
+func (v *Values) Add(key, value string) {
+	v.m.Upsert(key, []string{value}, func(old, new []string) []string {
+		return append(old, new...)
+	})
+}
+
+// Del deletes the values associated to a key.
+func (v *Values) Del(key string) {
+	v.m.Delete(key)
+}
+
+// Len returns the number of keys.
+func (v *Values) Len() int {
+	return v.m.Len()
+}
+
+// Keys returns all keys in order.
+func (v *Values) Keys() []string {
+	return v.m.Keys()
+}
+
+// Range calls f sequentially for each key and its values, in order. If f
+// returns false, Range stops the iteration.
+func (v *Values) Range(f func(key string, values []string) bool) {
+	v.m.Range(f)
+}
+
+// Clone returns a copy of v, copying the lists of values as well.
+func (v *Values) Clone() *Values {
+	return &Values{m: v.m.CloneFunc(func(values []string) []string {
+		return append([]string(nil), values...)
+	})}
+}
+
+// Encode encodes the values into URL-encoded form ("bar=baz&foo=quux"), with
+// keys in order rather than sorted, as described by orderedmap.EncodeQuery.
+func (v *Values) Encode() string {
+	return orderedmap.EncodeQuery(v.m)
+}
+
+// headerNewlineToSpace replaces newlines in header values, which would
+// otherwise allow injecting other headers.
+var headerNewlineToSpace = strings.NewReplacer("\n", " ", "\r", " ")
+
+// Write writes the values in HTTP header wire format, with keys in order
+// rather than sorted.
+func (v *Values) Write(w io.Writer) error {
+	var err error
+	v.m.Range(func(key string, values []string) bool {
+		for _, value := range values {
+			value = textproto.TrimString(headerNewlineToSpace.Replace(value))
+			if _, err = io.WriteString(w, key+": "+value+"\r\n"); err != nil {
+				return false
+			}
+		}
+		return true
+	})
+	return err
+}
+
+// URLValues returns the values as url.Values, which does not retain the
+// order of keys. The lists of values are not copied.
+func (v *Values) URLValues() url.Values {
+	return url.Values(v.m.Map())
+}
+
+// Header returns the values as http.Header, which does not retain the order
+// of keys. Keys are used as they are, so they must already be canonical,
+// as those of values created with NewHeader are. The lists of values are not
+// copied.
+func (v *Values) Header() http.Header {
+	return http.Header(v.m.Map())
+}
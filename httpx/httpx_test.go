@@ -0,0 +1,117 @@
+package httpx
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValues(t *testing.T) {
+	v := New()
+	v.Add("z", "1")
+	v.Add("a", "2")
+	v.Add("z", "3")
+	v.Set("m", "4")
+	v.Set("a", "5")
+	if diff := cmp.Diff([]string{"z", "a", "m"}, v.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+	if got := v.Get("z"); got != "1" {
+		t.Fatalf("unexpected value: %q", got)
+	}
+	if diff := cmp.Diff([]string{"1", "3"}, v.Values("z")); diff != "" {
+		t.Fatalf("unexpected values (-want +got):\n%s", diff)
+	}
+	if got := v.Get("missing"); got != "" {
+		t.Fatalf("unexpected value: %q", got)
+	}
+	if v.Has("Z") {
+		t.Fatal("unexpected case-insensitive key")
+	}
+	if got, want := v.Encode(), "z=1&z=3&a=5&m=4"; got != want {
+		t.Fatalf("unexpected encoding: want: %q, got %q", want, got)
+	}
+	v.Del("a")
+	if v.Has("a") || v.Len() != 2 {
+		t.Fatalf("unexpected values after delete: %v", v.Keys())
+	}
+	if diff := cmp.Diff(url.Values{"z": {"1", "3"}, "m": {"4"}}, v.URLValues()); diff != "" {
+		t.Fatalf("unexpected url values (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseQuery(t *testing.T) {
+	v, err := ParseQuery("sig=x&b=2&a=1&b=3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"sig", "b", "a"}, v.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+	v.Del("sig")
+	if got, want := v.Encode(), "b=2&b=3&a=1"; got != want {
+		t.Fatalf("unexpected encoding: want: %q, got %q", want, got)
+	}
+}
+
+func TestHeader(t *testing.T) {
+	h := NewHeader()
+	h.Set("x-request-id", "1")
+	h.Add("Content-Type", "text/plain")
+	h.Add("accept", "text/html")
+	h.Add("ACCEPT", "text/plain")
+	if got := h.Get("content-type"); got != "text/plain" {
+		t.Fatalf("unexpected value: %q", got)
+	}
+	if diff := cmp.Diff([]string{"X-Request-Id", "Content-Type", "Accept"}, h.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+	want := http.Header{
+		"X-Request-Id": {"1"},
+		"Content-Type": {"text/plain"},
+		"Accept":       {"text/html", "text/plain"},
+	}
+	if diff := cmp.Diff(want, h.Header()); diff != "" {
+		t.Fatalf("unexpected header (-want +got):\n%s", diff)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	h := NewHeader()
+	h.Add("x-b", "1")
+	h.Add("x-a", " 2\r\nX-Injected: 3 ")
+	h.Add("x-b", "4")
+	var b strings.Builder
+	if err := h.Write(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "X-B: 1\r\nX-B: 4\r\nX-A: 2  X-Injected: 3\r\n"
+	if got := b.String(); got != want {
+		t.Fatalf("unexpected output: want: %q, got %q", want, got)
+	}
+}
+
+func TestClone(t *testing.T) {
+	v := New()
+	v.Add("a", "1")
+	c := v.Clone()
+	c.Add("a", "2")
+	c.Add("b", "3")
+	if got, want := v.Encode(), "a=1"; got != want {
+		t.Fatalf("unexpected encoding: want: %q, got %q", want, got)
+	}
+	if got, want := c.Encode(), "a=1&a=2&b=3"; got != want {
+		t.Fatalf("unexpected encoding: want: %q, got %q", want, got)
+	}
+	n := 0
+	c.Range(func(key string, values []string) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Fatalf("unexpected number of iterations: %d", n)
+	}
+}
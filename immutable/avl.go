@@ -0,0 +1,158 @@
+package immutable
+
+// node is a node of a persistent AVL tree.
+//
+// Nodes are never modified after being created: operations on a tree copy the
+// nodes on the path from the root to the node affected and share all others
+// with the original tree.
+type node[K, V any] struct {
+	key         K
+	value       V
+	left, right *node[K, V]
+	height      int
+}
+
+func height[K, V any](n *node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// newNode returns a new node with the children specified, which must have
+// heights differing by at most one.
+func newNode[K, V any](key K, value V, left, right *node[K, V]) *node[K, V] {
+	h := height(left)
+	if hr := height(right); hr > h {
+		h = hr
+	}
+	return &node[K, V]{key: key, value: value, left: left, right: right, height: h + 1}
+}
+
+// balance returns a new node with the children specified, which must have
+// heights differing by at most two, rotating it if needed to rebalance it.
+func balance[K, V any](key K, value V, left, right *node[K, V]) *node[K, V] {
+	hl, hr := height(left), height(right)
+	switch {
+	case hl > hr+1:
+		if height(left.left) >= height(left.right) {
+			return newNode(left.key, left.value, left.left, newNode(key, value, left.right, right))
+		}
+		lr := left.right
+		return newNode(lr.key, lr.value,
+			newNode(left.key, left.value, left.left, lr.left),
+			newNode(key, value, lr.right, right))
+	case hr > hl+1:
+		if height(right.right) >= height(right.left) {
+			return newNode(right.key, right.value, newNode(key, value, left, right.left), right.right)
+		}
+		rl := right.left
+		return newNode(rl.key, rl.value,
+			newNode(key, value, left, rl.left),
+			newNode(right.key, right.value, rl.right, right.right))
+	}
+	return newNode(key, value, left, right)
+}
+
+// get returns the node of a key or nil if the key is not in the tree.
+func get[K, V any](n *node[K, V], key K, cmp func(a, b K) int) *node[K, V] {
+	for n != nil {
+		switch c := cmp(key, n.key); {
+		case c < 0:
+			n = n.left
+		case c > 0:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// insert returns a tree with a key set to a value and whether the key was
+// already in the tree.
+func insert[K, V any](n *node[K, V], key K, value V, cmp func(a, b K) int) (t *node[K, V], replaced bool) {
+	if n == nil {
+		return newNode[K, V](key, value, nil, nil), false
+	}
+	switch c := cmp(key, n.key); {
+	case c < 0:
+		left, replaced := insert(n.left, key, value, cmp)
+		return balance(n.key, n.value, left, n.right), replaced
+	case c > 0:
+		right, replaced := insert(n.right, key, value, cmp)
+		return balance(n.key, n.value, n.left, right), replaced
+	}
+	return newNode(key, value, n.left, n.right), true
+}
+
+// remove returns a tree without a key and whether the key was in the tree.
+// If not, the tree is returned unchanged.
+func remove[K, V any](n *node[K, V], key K, cmp func(a, b K) int) (t *node[K, V], removed bool) {
+	if n == nil {
+		return nil, false
+	}
+	switch c := cmp(key, n.key); {
+	case c < 0:
+		left, removed := remove(n.left, key, cmp)
+		if !removed {
+			return n, false
+		}
+		return balance(n.key, n.value, left, n.right), true
+	case c > 0:
+		right, removed := remove(n.right, key, cmp)
+		if !removed {
+			return n, false
+		}
+		return balance(n.key, n.value, n.left, right), true
+	}
+	switch {
+	case n.left == nil:
+		return n.right, true
+	case n.right == nil:
+		return n.left, true
+	}
+	succ := first(n.right)
+	right, _ := remove(n.right, succ.key, cmp)
+	return balance(succ.key, succ.value, n.left, right), true
+}
+
+// first returns the node with the smallest key or nil if the tree is empty.
+func first[K, V any](n *node[K, V]) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n
+}
+
+// last returns the node with the largest key or nil if the tree is empty.
+func last[K, V any](n *node[K, V]) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n
+}
+
+// ascend calls f on the nodes of a tree in ascending key order until f
+// returns false, in which case it returns false.
+func ascend[K, V any](n *node[K, V], f func(n *node[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return ascend(n.left, f) && f(n) && ascend(n.right, f)
+}
+
+// descend calls f on the nodes of a tree in descending key order until f
+// returns false, in which case it returns false.
+func descend[K, V any](n *node[K, V], f func(n *node[K, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return descend(n.right, f) && f(n) && descend(n.left, f)
+}
@@ -0,0 +1,263 @@
+// Package immutable implements an immutable ordered map using generics.
+//
+// Operations modifying an immutable map return a new map and leave the
+// original unchanged. The new map shares most of its structure with the
+// original, so that each modification takes O(log n) time and space rather
+// than requiring a full copy.
+//
+// Immutable maps are safe for concurrent usage without locking, since they
+// are never modified after being created. This makes them well suited to
+// functional-style code and to publishing maps to other goroutines.
+//
+// Items can only be added or moved to either end of an immutable map: unlike
+// orderedmap.OrderedMap, it has no InsertAfter, InsertBefore, MoveAfter and
+// MoveBefore methods.
+package immutable
+
+import (
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+// Map is an immutable ordered map.
+//
+// K and V are respectively the types of keys and values.
+//
+// The zero value is not usable: maps must be created with New or NewFunc.
+type Map[K comparable, V any] struct {
+	// keys maps keys to their sequence numbers
+	keys *node[K, int64]
+	// order maps sequence numbers to items, in the order of the map
+	order *node[int64, orderedmap.Item[K, V]]
+	n     int
+	// front and back are the sequence numbers preceding the front item
+	// and following the back item
+	front, back int64
+	cmp         func(a, b K) int
+}
+
+func cmpSeq(a, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// New returns a new empty immutable map.
+func New[K orderedmap.Ordered, V any]() *Map[K, V] {
+	return NewFunc[K, V](func(a, b K) int {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	})
+}
+
+// NewFunc returns a new empty immutable map whose keys are compared with cmp,
+// which must return a negative number if a sorts before b, a positive number
+// if a sorts after b and zero if a and b are the same key.
+//
+// Keys are compared only to look them up: the order of the map is still the
+// order in which keys are inserted.
+func NewFunc[K comparable, V any](cmp func(a, b K) int) *Map[K, V] {
+	return &Map[K, V]{cmp: cmp, front: -1}
+}
+
+// Len returns the number of items in the map.
+func (m *Map[K, V]) Len() int {
+	return m.n
+}
+
+// Get returns the value of a key.
+//
+// If the key is not in the map, it returns the zero value of V and ok is
+// set to false.
+func (m *Map[K, V]) Get(key K) (value V, ok bool) {
+	if n := m.get(key); n != nil {
+		return n.value.Value, true
+	}
+	return value, false
+}
+
+// get returns the node of a key in the order tree or nil if the key is not
+// in the map.
+func (m *Map[K, V]) get(key K) *node[int64, orderedmap.Item[K, V]] {
+	if n := get(m.keys, key, m.cmp); n != nil {
+		return get(m.order, n.value, cmpSeq)
+	}
+	return nil
+}
+
+// Has reports whether a key is in the map.
+func (m *Map[K, V]) Has(key K) bool {
+	return get(m.keys, key, m.cmp) != nil
+}
+
+// Front returns the item at the front of the map.
+//
+// If the map is empty, it returns the zero value of Item[K, V]
+// and ok is set to false.
+func (m *Map[K, V]) Front() (item orderedmap.Item[K, V], ok bool) {
+	return itemOf(first(m.order))
+}
+
+// Back returns the item at the back of the map.
+//
+// If the map is empty, it returns the zero value of Item[K, V]
+// and ok is set to false.
+func (m *Map[K, V]) Back() (item orderedmap.Item[K, V], ok bool) {
+	return itemOf(last(m.order))
+}
+
+func itemOf[K comparable, V any](n *node[int64, orderedmap.Item[K, V]]) (item orderedmap.Item[K, V], ok bool) {
+	if n == nil {
+		return item, false
+	}
+	return n.value, true
+}
+
+// with returns a copy of the map with a key set to a value at a sequence
+// number, replacing the node old of the key in the order tree if not nil.
+func (m *Map[K, V]) with(key K, value V, seq int64, old *node[int64, orderedmap.Item[K, V]]) *Map[K, V] {
+	out := *m
+	out.keys, _ = insert(m.keys, key, seq, m.cmp)
+	order := m.order
+	if old != nil {
+		order, _ = remove(order, old.key, cmpSeq)
+	} else {
+		out.n++
+	}
+	out.order, _ = insert(order, seq, orderedmap.Item[K, V]{Key: key, Value: value}, cmpSeq)
+	return &out
+}
+
+// Set returns a map with a key set to a value.
+//
+// If the key is not in the map, it is added at the back of the map,
+// otherwise its position is unchanged.
+func (m *Map[K, V]) Set(key K, value V) *Map[K, V] {
+	if old := m.get(key); old != nil {
+		out := *m
+		out.order, _ = insert(m.order, old.key, orderedmap.Item[K, V]{Key: old.value.Key, Value: value}, cmpSeq)
+		return &out
+	}
+	return m.withBack(key, value, nil)
+}
+
+// PushFront returns a map with a new key and value inserted at the front of
+// the map.
+//
+// It returns ErrKeyAlreadyPresent if the key to be inserted is already in the
+// map.
+func (m *Map[K, V]) PushFront(key K, value V) (*Map[K, V], error) {
+	if m.Has(key) {
+		return m, orderedmap.ErrKeyAlreadyPresent
+	}
+	return m.withFront(key, value, nil), nil
+}
+
+// PushBack returns a map with a new key and value inserted at the back of the
+// map.
+//
+// It returns ErrKeyAlreadyPresent if the key to be inserted is already in the
+// map.
+func (m *Map[K, V]) PushBack(key K, value V) (*Map[K, V], error) {
+	if m.Has(key) {
+		return m, orderedmap.ErrKeyAlreadyPresent
+	}
+	return m.withBack(key, value, nil), nil
+}
+
+// withFront returns a copy of the map with a key set to a value at the front,
+// replacing the node old of the key in the order tree if not nil.
+func (m *Map[K, V]) withFront(key K, value V, old *node[int64, orderedmap.Item[K, V]]) *Map[K, V] {
+	out := m.with(key, value, m.front, old)
+	out.front--
+	return out
+}
+
+// withBack returns a copy of the map with a key set to a value at the back,
+// replacing the node old of the key in the order tree if not nil.
+func (m *Map[K, V]) withBack(key K, value V, old *node[int64, orderedmap.Item[K, V]]) *Map[K, V] {
+	out := m.with(key, value, m.back, old)
+	out.back++
+	return out
+}
+
+// MoveToFront returns a map with an existing key moved to the front.
+//
+// It returns ErrKeyMissing if the key to be moved is not in the map.
+func (m *Map[K, V]) MoveToFront(key K) (*Map[K, V], error) {
+	old := m.get(key)
+	if old == nil {
+		return m, orderedmap.ErrKeyMissing
+	}
+	return m.withFront(key, old.value.Value, old), nil
+}
+
+// MoveToBack returns a map with an existing key moved to the back.
+//
+// It returns ErrKeyMissing if the key to be moved is not in the map.
+func (m *Map[K, V]) MoveToBack(key K) (*Map[K, V], error) {
+	old := m.get(key)
+	if old == nil {
+		return m, orderedmap.ErrKeyMissing
+	}
+	return m.withBack(key, old.value.Value, old), nil
+}
+
+// Delete returns a map without a key.
+//
+// If the key is not in the map, it returns m.
+func (m *Map[K, V]) Delete(key K) *Map[K, V] {
+	seq := get(m.keys, key, m.cmp)
+	if seq == nil {
+		return m
+	}
+	out := *m
+	out.keys, _ = remove(m.keys, key, m.cmp)
+	out.order, _ = remove(m.order, seq.value, cmpSeq)
+	out.n--
+	return &out
+}
+
+// Range calls f sequentially for each key and value present in the map,
+// from front to back. If f returns false, range stops the iteration.
+func (m *Map[K, V]) Range(f func(key K, value V) bool) {
+	ascend(m.order, func(n *node[int64, orderedmap.Item[K, V]]) bool {
+		return f(n.value.Key, n.value.Value)
+	})
+}
+
+// RangeReverse calls f sequentially for each key and value present in the
+// map, from back to front. If f returns false, range stops the iteration.
+func (m *Map[K, V]) RangeReverse(f func(key K, value V) bool) {
+	descend(m.order, func(n *node[int64, orderedmap.Item[K, V]]) bool {
+		return f(n.value.Key, n.value.Value)
+	})
+}
+
+// Keys returns the keys of the map, from front to back.
+func (m *Map[K, V]) Keys() []K {
+	keys := make([]K, 0, m.n)
+	ascend(m.order, func(n *node[int64, orderedmap.Item[K, V]]) bool {
+		keys = append(keys, n.value.Key)
+		return true
+	})
+	return keys
+}
+
+// Items returns the items of the map, from front to back.
+func (m *Map[K, V]) Items() []orderedmap.Item[K, V] {
+	items := make([]orderedmap.Item[K, V], 0, m.n)
+	ascend(m.order, func(n *node[int64, orderedmap.Item[K, V]]) bool {
+		items = append(items, n.value)
+		return true
+	})
+	return items
+}
@@ -0,0 +1,177 @@
+package immutable
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+func TestEmpty(t *testing.T) {
+	m := New[int, string]()
+	checkAll(t, m, []orderedmap.Item[int, string]{})
+	if m.Delete(1) != m {
+		t.Fatal("deleting missing key returned a new map")
+	}
+	if _, err := m.MoveToFront(1); !errors.Is(err, orderedmap.ErrKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", orderedmap.ErrKeyMissing, err)
+	}
+	if _, err := m.MoveToBack(1); !errors.Is(err, orderedmap.ErrKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", orderedmap.ErrKeyMissing, err)
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	m0 := New[string, int]()
+	m1 := m0.Set("a", 1).Set("b", 2).Set("c", 3)
+	m2 := m1.Set("a", 10)
+	m3 := m2.Delete("b")
+	m4, err := m3.PushFront("d", 4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m5, err := m4.MoveToBack("d")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m6, err := m5.MoveToFront("c")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m7, err := m6.PushBack("e", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m8, err := m7.PushBack("c", 30); m8 != m7 || !errors.Is(err, orderedmap.ErrKeyAlreadyPresent) {
+		t.Fatalf("unexpected error: want: %v, got %v", orderedmap.ErrKeyAlreadyPresent, err)
+	}
+
+	checkAll(t, m0, []orderedmap.Item[string, int]{})
+	checkAll(t, m1, []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}})
+	checkAll(t, m2, []orderedmap.Item[string, int]{{Key: "a", Value: 10}, {Key: "b", Value: 2}, {Key: "c", Value: 3}})
+	checkAll(t, m3, []orderedmap.Item[string, int]{{Key: "a", Value: 10}, {Key: "c", Value: 3}})
+	checkAll(t, m4, []orderedmap.Item[string, int]{{Key: "d", Value: 4}, {Key: "a", Value: 10}, {Key: "c", Value: 3}})
+	checkAll(t, m5, []orderedmap.Item[string, int]{{Key: "a", Value: 10}, {Key: "c", Value: 3}, {Key: "d", Value: 4}})
+	checkAll(t, m6, []orderedmap.Item[string, int]{{Key: "c", Value: 3}, {Key: "a", Value: 10}, {Key: "d", Value: 4}})
+	checkAll(t, m7, []orderedmap.Item[string, int]{{Key: "c", Value: 3}, {Key: "a", Value: 10}, {Key: "d", Value: 4}, {Key: "e", Value: 5}})
+}
+
+func TestNewFunc(t *testing.T) {
+	m := NewFunc[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	m = m.Set("b", 1).Set("A", 2).Set("B", 3)
+	checkAll(t, m, []orderedmap.Item[string, int]{{Key: "b", Value: 3}, {Key: "A", Value: 2}})
+}
+
+func TestRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := New[int, int]()
+	ref := orderedmap.New[int, int]()
+	for i := 0; i < 20000; i++ {
+		key := r.Intn(2000)
+		switch r.Intn(5) {
+		case 0:
+			m = m.Delete(key)
+			ref.Delete(key)
+		case 1:
+			var err error
+			m, err = m.PushFront(key, i)
+			if refErr := ref.PushFront(key, i); !errors.Is(err, refErr) {
+				t.Fatalf("unexpected PushFront(%d) error: want: %v, got %v", key, refErr, err)
+			}
+		case 2:
+			var err error
+			m, err = m.MoveToBack(key)
+			if refErr := ref.MoveToBack(key); !errors.Is(err, refErr) {
+				t.Fatalf("unexpected MoveToBack(%d) error: want: %v, got %v", key, refErr, err)
+			}
+		default:
+			m = m.Set(key, i)
+			ref.Set(key, i)
+		}
+		if i%1000 == 0 {
+			checkAll(t, m, ref.Items())
+		}
+	}
+	checkAll(t, m, ref.Items())
+	for _, key := range ref.Keys() {
+		m = m.Delete(key)
+	}
+	checkAll(t, m, []orderedmap.Item[int, int]{})
+}
+
+func checkAll[K comparable, V any](t *testing.T, m *Map[K, V], items []orderedmap.Item[K, V]) {
+	t.Helper()
+
+	if want, got := len(items), m.Len(); want != got {
+		t.Fatalf("incorrect length: want: %d, got: %d", want, got)
+	}
+	if diff := cmp.Diff(items, m.Items()); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+	keys := make([]K, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, item.Key)
+	}
+	if diff := cmp.Diff(keys, m.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+	reverse := []orderedmap.Item[K, V]{}
+	m.RangeReverse(func(key K, value V) bool {
+		reverse = append([]orderedmap.Item[K, V]{{Key: key, Value: value}}, reverse...)
+		return true
+	})
+	if diff := cmp.Diff(items, reverse); diff != "" {
+		t.Fatalf("unexpected reverse items (-want +got):\n%s", diff)
+	}
+	for _, item := range items {
+		if value, ok := m.Get(item.Key); !ok || !cmp.Equal(value, item.Value) {
+			t.Fatalf("unexpected value of key %v: want: %v, got %v (%v)", item.Key, item.Value, value, ok)
+		}
+		if !m.Has(item.Key) {
+			t.Fatalf("missing key %v", item.Key)
+		}
+	}
+	front, frontOK := m.Front()
+	back, backOK := m.Back()
+	if frontOK != (len(items) > 0) || backOK != (len(items) > 0) {
+		t.Fatalf("unexpected front and back: %v (%v), %v (%v)", front, frontOK, back, backOK)
+	}
+	if len(items) > 0 && (!cmp.Equal(front, items[0]) || !cmp.Equal(back, items[len(items)-1])) {
+		t.Fatalf("unexpected front and back: %v, %v", front, back)
+	}
+	checkNode(t, m.keys, m.cmp)
+	checkNode(t, m.order, cmpSeq)
+}
+
+// checkNode verifies the invariants of the AVL tree rooted at n and returns
+// its height.
+func checkNode[K, V any](t *testing.T, n *node[K, V], cmp func(a, b K) int) int {
+	t.Helper()
+
+	if n == nil {
+		return 0
+	}
+	if n.left != nil && cmp(n.left.key, n.key) >= 0 {
+		t.Fatalf("unordered keys: %v, %v", n.left.key, n.key)
+	}
+	if n.right != nil && cmp(n.right.key, n.key) <= 0 {
+		t.Fatalf("unordered keys: %v, %v", n.key, n.right.key)
+	}
+	hl, hr := checkNode(t, n.left, cmp), checkNode(t, n.right, cmp)
+	if hl > hr+1 || hr > hl+1 {
+		t.Fatalf("unbalanced tree: heights %d and %d", hl, hr)
+	}
+	h := hl
+	if hr > h {
+		h = hr
+	}
+	if n.height != h+1 {
+		t.Fatalf("unexpected height: want: %d, got %d", h+1, n.height)
+	}
+	return n.height
+}
@@ -0,0 +1,79 @@
+// Package bson implements a minimal BSON encoder and decoder.
+//
+// It supports nil, booleans, integers, floating point numbers, strings,
+// byte slices, time.Time, slices, arrays, maps with string keys, pointers,
+// interfaces, structs and types implementing Marshaler and Unmarshaler.
+//
+// The encoding follows the default behavior of go.mongodb.org/mongo-driver:
+// integers are encoded as int32 when they fit in 32 bits (except for int64
+// and uint32 values which are always encoded as int64), struct fields are
+// named after the lowercased field name unless a "bson" tag is specified and
+// time.Time values are encoded as UTC datetimes with millisecond precision.
+package bson
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Marshaler is the interface implemented by types that can marshal themselves
+// into a BSON document.
+type Marshaler interface {
+	MarshalBSON() ([]byte, error)
+}
+
+// Unmarshaler is the interface implemented by types that can unmarshal a BSON
+// document representation of themselves.
+type Unmarshaler interface {
+	UnmarshalBSON([]byte) error
+}
+
+// ErrInvalidDocument indicates that the data to decode is not a valid BSON document.
+var ErrInvalidDocument = errors.New("bson: invalid document")
+
+// Type is the type of a BSON element.
+type Type byte
+
+// BSON element types supported by this package.
+const (
+	TypeDouble   Type = 0x01
+	TypeString   Type = 0x02
+	TypeDocument Type = 0x03
+	TypeArray    Type = 0x04
+	TypeBinary   Type = 0x05
+	TypeBoolean  Type = 0x08
+	TypeDateTime Type = 0x09
+	TypeNull     Type = 0x0a
+	TypeInt32    Type = 0x10
+	TypeInt64    Type = 0x12
+)
+
+var (
+	marshalerType   = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+	timeType        = reflect.TypeOf(time.Time{})
+)
+
+// fieldName returns the name under which a struct field is encoded and
+// whether the field should be encoded at all.
+func fieldName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() {
+		return "", false
+	}
+	tag := f.Tag.Get("bson")
+	if tag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+	return strings.ToLower(f.Name), true
+}
+
+// typeError returns the error reported when a type cannot be encoded or decoded.
+func typeError(t reflect.Type) error {
+	return fmt.Errorf("bson: unsupported type %s", t)
+}
@@ -0,0 +1,258 @@
+package bson
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAppendElement(t *testing.T) {
+	cases := []struct {
+		name string
+		v    any
+		want []byte
+	}{
+		{"nil", nil, []byte{0x0a, 'k', 0}},
+		{"bool", true, []byte{0x08, 'k', 0, 1}},
+		{"int", 1, []byte{0x10, 'k', 0, 1, 0, 0, 0}},
+		{"large int", 1 << 40, []byte{0x12, 'k', 0, 0, 0, 0, 0, 0, 1, 0, 0}},
+		{"int64", int64(1), []byte{0x12, 'k', 0, 1, 0, 0, 0, 0, 0, 0, 0}},
+		{"uint8", uint8(1), []byte{0x10, 'k', 0, 1, 0, 0, 0}},
+		{"uint32", uint32(1), []byte{0x12, 'k', 0, 1, 0, 0, 0, 0, 0, 0, 0}},
+		{"double", 1.0, []byte{0x01, 'k', 0, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f}},
+		{"string", "ab", []byte{0x02, 'k', 0, 3, 0, 0, 0, 'a', 'b', 0}},
+		{"binary", []byte{7}, []byte{0x05, 'k', 0, 1, 0, 0, 0, 0, 7}},
+		{"datetime", time.UnixMilli(1), []byte{0x09, 'k', 0, 1, 0, 0, 0, 0, 0, 0, 0}},
+		{"array", []bool{true}, []byte{0x04, 'k', 0, 9, 0, 0, 0, 0x08, '0', 0, 1, 0}},
+		{"map", map[string]bool{"a": true}, []byte{0x03, 'k', 0, 9, 0, 0, 0, 0x08, 'a', 0, 1, 0}},
+		{"struct", struct {
+			Abc bool
+			D   bool `bson:"x"`
+			E   bool `bson:"-"`
+		}{true, false, true}, []byte{0x03, 'k', 0, 15, 0, 0, 0, 0x08, 'a', 'b', 'c', 0, 1, 0x08, 'x', 0, 0, 0}},
+		{"marshaler", custom{"a"}, []byte{0x03, 'k', 0, 14, 0, 0, 0, 0x02, 'v', 0, 2, 0, 0, 0, 'a', 0, 0}},
+		{"nil marshaler", (*custom)(nil), []byte{0x0a, 'k', 0}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := AppendElement(nil, "k", c.v)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, c.want) {
+				t.Fatalf("unexpected encoding: want: %x, got %x", c.want, got)
+			}
+		})
+	}
+}
+
+func TestAppendElementErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		key  string
+		v    any
+	}{
+		{"null byte in name", "a\x00", 1},
+		{"unsupported type", "a", make(chan int)},
+		{"non-string map key", "a", map[int]int{1: 1}},
+		{"uint64 overflow", "a", uint64(math.MaxUint64)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := AppendElement(nil, c.key, c.v); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	type inner struct {
+		X []int
+		Y map[string]float64
+	}
+	type outer struct {
+		A int8
+		B uint
+		C string
+		D *inner
+		E []byte
+		F bool
+		G [2]string
+		H time.Time
+		I custom
+		J *int
+	}
+	in := outer{
+		A: -5,
+		B: 1 << 33,
+		C: "hello",
+		D: &inner{X: []int{1, -200, 70000}, Y: map[string]float64{"pi": 3.14}},
+		E: []byte("raw"),
+		F: true,
+		G: [2]string{"x", "y"},
+		H: time.UnixMilli(1680948000123),
+		I: custom{"c"},
+	}
+	b, start := StartDocument(nil)
+	b, err := AppendElement(b, "v", in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b = EndDocument(b, start)
+
+	r, err := NewReader(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	name, typ, raw, ok, err := r.Next()
+	if err != nil || !ok {
+		t.Fatalf("unexpected result: ok: %t, err: %v", ok, err)
+	}
+	if name != "v" || typ != TypeDocument {
+		t.Fatalf("unexpected element: name: %q, type: %#x", name, typ)
+	}
+	var out outer
+	var d Decoder
+	if err := d.Decode(typ, raw, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(in, out, cmp.AllowUnexported(custom{})); diff != "" {
+		t.Fatalf("unexpected value (-want +got):\n%s", diff)
+	}
+	if _, _, _, ok, err := r.Next(); ok || err != nil {
+		t.Fatalf("unexpected result: ok: %t, err: %v", ok, err)
+	}
+}
+
+func TestDecodeInterface(t *testing.T) {
+	b, start := StartDocument(nil)
+	b, err := AppendElement(b, "v", map[string]any{
+		"int32":  1,
+		"int64":  int64(2),
+		"double": 2.5,
+		"array":  []any{nil, "s"},
+		"doc":    map[string]any{"a": true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b = EndDocument(b, start)
+	r, err := NewReader(b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, typ, raw, _, err := r.Next()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var out any
+	var d Decoder
+	if err := d.Decode(typ, raw, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]any{
+		"int32":  int32(1),
+		"int64":  int64(2),
+		"double": 2.5,
+		"array":  []any{nil, "s"},
+		"doc":    map[string]any{"a": true},
+	}
+	if diff := cmp.Diff(want, out); diff != "" {
+		t.Fatalf("unexpected value (-want +got):\n%s", diff)
+	}
+}
+
+func TestDecodeObjectID(t *testing.T) {
+	raw := []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	var id [12]byte
+	var d Decoder
+	if err := d.Decode(typeObjectID, raw, &id); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(id[:], raw) {
+		t.Fatalf("unexpected value: %x", id)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	var d Decoder
+	cases := []struct {
+		name string
+		t    Type
+		raw  []byte
+		dst  any
+	}{
+		{"type mismatch", TypeString, []byte{2, 0, 0, 0, 'a', 0}, new(int)},
+		{"overflow", TypeInt32, []byte{0, 1, 0, 0}, new(int8)},
+		{"negative into unsigned", TypeInt32, []byte{0xff, 0xff, 0xff, 0xff}, new(uint)},
+		{"malformed string", TypeString, []byte{2, 0, 0, 0, 'a', 'b'}, new(string)},
+		{"array length mismatch", TypeArray, []byte{5, 0, 0, 0, 0}, new([1]int)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := d.Decode(c.t, c.raw, c.dst); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestNewReaderErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		doc  []byte
+	}{
+		{"too short", []byte{5, 0, 0}},
+		{"length mismatch", []byte{6, 0, 0, 0, 0}},
+		{"missing terminator", []byte{5, 0, 0, 0, 1}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if _, err := NewReader(c.doc); !errors.Is(err, ErrInvalidDocument) {
+				t.Fatalf("unexpected error: want: %v, got %v", ErrInvalidDocument, err)
+			}
+		})
+	}
+}
+
+func TestReaderTruncatedElement(t *testing.T) {
+	r, err := NewReader([]byte{10, 0, 0, 0, 0x10, 'a', 0, 1, 0, 0})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, _, _, err := r.Next(); !errors.Is(err, ErrInvalidDocument) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrInvalidDocument, err)
+	}
+}
+
+// custom is a type implementing Marshaler and Unmarshaler
+type custom struct {
+	s string
+}
+
+func (c custom) MarshalBSON() ([]byte, error) {
+	b, start := StartDocument(nil)
+	b, err := AppendElement(b, "v", c.s)
+	if err != nil {
+		return nil, err
+	}
+	return EndDocument(b, start), nil
+}
+
+func (c *custom) UnmarshalBSON(doc []byte) error {
+	r, err := NewReader(doc)
+	if err != nil {
+		return err
+	}
+	_, t, raw, _, err := r.Next()
+	if err != nil {
+		return err
+	}
+	var d Decoder
+	return d.Decode(t, raw, &c.s)
+}
@@ -0,0 +1,376 @@
+package bson
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// typeObjectID is only supported for decoding, into 12-byte arrays such as
+// the ObjectID type of go.mongodb.org/mongo-driver.
+const typeObjectID Type = 0x07
+
+// Reader iterates over the elements of a document.
+type Reader struct {
+	b   []byte
+	off int
+}
+
+// NewReader returns a reader iterating over the elements of doc.
+func NewReader(doc []byte) (*Reader, error) {
+	if len(doc) < 5 {
+		return nil, fmt.Errorf("%w: too short", ErrInvalidDocument)
+	}
+	if n := binary.LittleEndian.Uint32(doc); int64(n) != int64(len(doc)) {
+		return nil, fmt.Errorf("%w: length %d does not match size %d", ErrInvalidDocument, n, len(doc))
+	}
+	if doc[len(doc)-1] != 0 {
+		return nil, fmt.Errorf("%w: missing terminator", ErrInvalidDocument)
+	}
+	return &Reader{b: doc[:len(doc)-1], off: 4}, nil
+}
+
+// Next returns the next element of the document. Its value is returned raw
+// and can be decoded with Decoder.Decode. ok is false after the last element.
+func (r *Reader) Next() (name string, t Type, raw []byte, ok bool, err error) {
+	if r.off >= len(r.b) {
+		return "", 0, nil, false, nil
+	}
+	t = Type(r.b[r.off])
+	r.off++
+	end := bytes.IndexByte(r.b[r.off:], 0)
+	if end < 0 {
+		return "", 0, nil, false, fmt.Errorf("%w: unterminated element name", ErrInvalidDocument)
+	}
+	name = string(r.b[r.off : r.off+end])
+	r.off += end + 1
+	n, err := valueSize(t, r.b[r.off:])
+	if err != nil {
+		return "", 0, nil, false, fmt.Errorf("element %q: %w", name, err)
+	}
+	raw = r.b[r.off : r.off+n]
+	r.off += n
+	return name, t, raw, true, nil
+}
+
+// valueSize returns the size of the value of type t at the start of b.
+func valueSize(t Type, b []byte) (int, error) {
+	n := 0
+	switch t {
+	case TypeNull:
+	case TypeBoolean:
+		n = 1
+	case TypeInt32:
+		n = 4
+	case TypeDouble, TypeInt64, TypeDateTime:
+		n = 8
+	case typeObjectID:
+		n = 12
+	case TypeString, TypeBinary, TypeDocument, TypeArray:
+		if len(b) < 4 {
+			return 0, fmt.Errorf("%w: truncated value", ErrInvalidDocument)
+		}
+		l := int64(binary.LittleEndian.Uint32(b))
+		switch t {
+		case TypeString:
+			l += 4
+		case TypeBinary:
+			l += 5
+		}
+		if l > int64(len(b)) {
+			return 0, fmt.Errorf("%w: truncated value", ErrInvalidDocument)
+		}
+		n = int(l)
+	default:
+		return 0, fmt.Errorf("bson: unsupported element type %#x", byte(t))
+	}
+	if n > len(b) {
+		return 0, fmt.Errorf("%w: truncated value", ErrInvalidDocument)
+	}
+	return n, nil
+}
+
+// Decoder decodes element values.
+type Decoder struct {
+	// DecodeDocument, if not nil, is used to decode embedded documents
+	// into empty interfaces. Otherwise they are decoded as map[string]any.
+	DecodeDocument func(doc []byte) (any, error)
+}
+
+// Decode decodes the raw value of type t, as returned by Reader.Next,
+// and stores it in the value pointed to by dst.
+func (d *Decoder) Decode(t Type, raw []byte, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("bson: non-pointer destination %T", dst)
+	}
+	return d.decodeValue(t, raw, rv.Elem())
+}
+
+func (d *Decoder) decodeValue(t Type, raw []byte, v reflect.Value) error {
+	if v.Kind() != reflect.Pointer && v.CanAddr() && v.Addr().Type().Implements(unmarshalerType) {
+		if t == TypeNull {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		if t != TypeDocument {
+			return d.mismatch(t, v.Type())
+		}
+		return v.Addr().Interface().(Unmarshaler).UnmarshalBSON(raw)
+	}
+	if t == TypeNull {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	if v.Type() == timeType {
+		if t != TypeDateTime {
+			return d.mismatch(t, v.Type())
+		}
+		v.Set(reflect.ValueOf(time.UnixMilli(int64(binary.LittleEndian.Uint64(raw)))))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return d.decodeValue(t, raw, v.Elem())
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return typeError(v.Type())
+		}
+		x, err := d.decodeInterface(t, raw)
+		if err != nil {
+			return err
+		}
+		if x == nil {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		v.Set(reflect.ValueOf(x))
+		return nil
+	case reflect.Bool:
+		if t != TypeBoolean {
+			return d.mismatch(t, v.Type())
+		}
+		v.SetBool(raw[0] != 0)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, ok := integer(t, raw)
+		if !ok {
+			return d.mismatch(t, v.Type())
+		}
+		if v.OverflowInt(i) {
+			return d.overflow(i, v.Type())
+		}
+		v.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, ok := integer(t, raw)
+		if !ok {
+			return d.mismatch(t, v.Type())
+		}
+		if i < 0 || v.OverflowUint(uint64(i)) {
+			return d.overflow(i, v.Type())
+		}
+		v.SetUint(uint64(i))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		if t == TypeDouble {
+			v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(raw)))
+			return nil
+		}
+		i, ok := integer(t, raw)
+		if !ok {
+			return d.mismatch(t, v.Type())
+		}
+		v.SetFloat(float64(i))
+		return nil
+	case reflect.String:
+		if t != TypeString {
+			return d.mismatch(t, v.Type())
+		}
+		s, err := str(raw)
+		if err != nil {
+			return err
+		}
+		v.SetString(s)
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 && t == TypeBinary {
+			v.SetBytes(append([]byte{}, raw[5:]...))
+			return nil
+		}
+		if t != TypeArray {
+			return d.mismatch(t, v.Type())
+		}
+		s := reflect.MakeSlice(v.Type(), 0, 0)
+		err := d.forEach(raw, func(_ string, t Type, raw []byte) error {
+			e := reflect.New(v.Type().Elem()).Elem()
+			if err := d.decodeValue(t, raw, e); err != nil {
+				return err
+			}
+			s = reflect.Append(s, e)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		v.Set(s)
+		return nil
+	case reflect.Array:
+		if t == typeObjectID && v.Type().Elem().Kind() == reflect.Uint8 && v.Len() == len(raw) {
+			reflect.Copy(v, reflect.ValueOf(raw))
+			return nil
+		}
+		if t != TypeArray {
+			return d.mismatch(t, v.Type())
+		}
+		i := 0
+		err := d.forEach(raw, func(_ string, t Type, raw []byte) error {
+			if i >= v.Len() {
+				return fmt.Errorf("bson: too many elements for %s", v.Type())
+			}
+			i++
+			return d.decodeValue(t, raw, v.Index(i-1))
+		})
+		if err != nil {
+			return err
+		}
+		if i != v.Len() {
+			return fmt.Errorf("bson: cannot decode array of %d elements into %s", i, v.Type())
+		}
+		return nil
+	case reflect.Map:
+		if t != TypeDocument || v.Type().Key().Kind() != reflect.String {
+			return d.mismatch(t, v.Type())
+		}
+		m := reflect.MakeMap(v.Type())
+		err := d.forEach(raw, func(name string, t Type, raw []byte) error {
+			e := reflect.New(v.Type().Elem()).Elem()
+			if err := d.decodeValue(t, raw, e); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(name).Convert(v.Type().Key()), e)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		v.Set(m)
+		return nil
+	case reflect.Struct:
+		if t != TypeDocument {
+			return d.mismatch(t, v.Type())
+		}
+		fields := make(map[string]int, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			if name, ok := fieldName(v.Type().Field(i)); ok {
+				fields[name] = i
+			}
+		}
+		return d.forEach(raw, func(name string, t Type, raw []byte) error {
+			if i, ok := fields[name]; ok {
+				return d.decodeValue(t, raw, v.Field(i))
+			}
+			return nil
+		})
+	}
+	return typeError(v.Type())
+}
+
+// decodeInterface decodes a value into its natural Go representation.
+func (d *Decoder) decodeInterface(t Type, raw []byte) (any, error) {
+	switch t {
+	case TypeNull:
+		return nil, nil
+	case TypeBoolean:
+		return raw[0] != 0, nil
+	case TypeInt32:
+		return int32(binary.LittleEndian.Uint32(raw)), nil
+	case TypeInt64:
+		return int64(binary.LittleEndian.Uint64(raw)), nil
+	case TypeDouble:
+		return math.Float64frombits(binary.LittleEndian.Uint64(raw)), nil
+	case TypeDateTime:
+		return time.UnixMilli(int64(binary.LittleEndian.Uint64(raw))), nil
+	case TypeString:
+		return str(raw)
+	case TypeBinary:
+		return append([]byte{}, raw[5:]...), nil
+	case typeObjectID:
+		var id [12]byte
+		copy(id[:], raw)
+		return id, nil
+	case TypeArray:
+		s := []any{}
+		err := d.forEach(raw, func(_ string, t Type, raw []byte) error {
+			x, err := d.decodeInterface(t, raw)
+			s = append(s, x)
+			return err
+		})
+		return s, err
+	case TypeDocument:
+		if d.DecodeDocument != nil {
+			return d.DecodeDocument(raw)
+		}
+		m := map[string]any{}
+		err := d.forEach(raw, func(name string, t Type, raw []byte) error {
+			x, err := d.decodeInterface(t, raw)
+			m[name] = x
+			return err
+		})
+		return m, err
+	}
+	return nil, fmt.Errorf("bson: unsupported element type %#x", byte(t))
+}
+
+// forEach calls f for each element of the document or array doc.
+func (d *Decoder) forEach(doc []byte, f func(name string, t Type, raw []byte) error) error {
+	r, err := NewReader(doc)
+	if err != nil {
+		return err
+	}
+	for {
+		name, t, raw, ok, err := r.Next()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+		if err := f(name, t, raw); err != nil {
+			return err
+		}
+	}
+}
+
+// integer returns the value of an int32 or int64 element.
+func integer(t Type, raw []byte) (int64, bool) {
+	switch t {
+	case TypeInt32:
+		return int64(int32(binary.LittleEndian.Uint32(raw))), true
+	case TypeInt64:
+		return int64(binary.LittleEndian.Uint64(raw)), true
+	}
+	return 0, false
+}
+
+// str returns the value of a string element.
+func str(raw []byte) (string, error) {
+	if len(raw) < 5 || raw[len(raw)-1] != 0 {
+		return "", fmt.Errorf("%w: malformed string", ErrInvalidDocument)
+	}
+	return string(raw[4 : len(raw)-1]), nil
+}
+
+func (d *Decoder) mismatch(t Type, typ reflect.Type) error {
+	return fmt.Errorf("bson: cannot decode element type %#x into %s", byte(t), typ)
+}
+
+func (d *Decoder) overflow(n int64, typ reflect.Type) error {
+	return fmt.Errorf("bson: value %d overflows %s", n, typ)
+}
@@ -0,0 +1,165 @@
+package bson
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StartDocument appends the header of a document to b and returns the
+// offset at which the document starts, to be passed to EndDocument.
+func StartDocument(b []byte) ([]byte, int) {
+	return append(b, 0, 0, 0, 0), len(b)
+}
+
+// EndDocument terminates the document started at offset start.
+func EndDocument(b []byte, start int) []byte {
+	b = append(b, 0)
+	binary.LittleEndian.PutUint32(b[start:], uint32(len(b)-start))
+	return b
+}
+
+// AppendElement appends an element named name with value v to b.
+func AppendElement(b []byte, name string, v any) ([]byte, error) {
+	return appendElement(b, name, reflect.ValueOf(v))
+}
+
+func appendElement(b []byte, name string, v reflect.Value) ([]byte, error) {
+	if strings.IndexByte(name, 0) >= 0 {
+		return b, fmt.Errorf("bson: element name %q contains null byte", name)
+	}
+	// reserve space for the type which is only known after encoding the value
+	typePos := len(b)
+	b = append(b, 0)
+	b = append(append(b, name...), 0)
+	b, t, err := appendValue(b, v)
+	if err != nil {
+		return b, err
+	}
+	b[typePos] = byte(t)
+	return b, nil
+}
+
+func appendValue(b []byte, v reflect.Value) ([]byte, Type, error) {
+	if !v.IsValid() {
+		return b, TypeNull, nil
+	}
+	if v.Type().Implements(marshalerType) {
+		if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+			return b, TypeNull, nil
+		}
+		doc, err := v.Interface().(Marshaler).MarshalBSON()
+		if err != nil {
+			return b, 0, err
+		}
+		return append(b, doc...), TypeDocument, nil
+	}
+	if v.Kind() != reflect.Pointer && v.CanAddr() && v.Addr().Type().Implements(marshalerType) {
+		return appendValue(b, v.Addr())
+	}
+	if v.Type() == timeType {
+		ms := v.Interface().(time.Time).UnixMilli()
+		return appendUint64(b, uint64(ms)), TypeDateTime, nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return b, TypeNull, nil
+		}
+		return appendValue(b, v.Elem())
+	case reflect.Bool:
+		if v.Bool() {
+			return append(b, 1), TypeBoolean, nil
+		}
+		return append(b, 0), TypeBoolean, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return appendUint32(b, uint32(v.Int())), TypeInt32, nil
+	case reflect.Int:
+		if i := v.Int(); i >= math.MinInt32 && i <= math.MaxInt32 {
+			return appendUint32(b, uint32(i)), TypeInt32, nil
+		}
+		return appendUint64(b, uint64(v.Int())), TypeInt64, nil
+	case reflect.Int64:
+		return appendUint64(b, uint64(v.Int())), TypeInt64, nil
+	case reflect.Uint8, reflect.Uint16:
+		return appendUint32(b, uint32(v.Uint())), TypeInt32, nil
+	case reflect.Uint, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		u := v.Uint()
+		if u > math.MaxInt64 {
+			return b, 0, fmt.Errorf("bson: value %d overflows int64", u)
+		}
+		return appendUint64(b, u), TypeInt64, nil
+	case reflect.Float32, reflect.Float64:
+		return appendUint64(b, math.Float64bits(v.Float())), TypeDouble, nil
+	case reflect.String:
+		s := v.String()
+		b = appendUint32(b, uint32(len(s)+1))
+		return append(append(b, s...), 0), TypeString, nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return b, TypeNull, nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			b = appendUint32(b, uint32(v.Len()))
+			b = append(b, 0) // generic binary subtype
+			return append(b, v.Bytes()...), TypeBinary, nil
+		}
+		b, err := appendArray(b, v)
+		return b, TypeArray, err
+	case reflect.Array:
+		b, err := appendArray(b, v)
+		return b, TypeArray, err
+	case reflect.Map:
+		if v.IsNil() {
+			return b, TypeNull, nil
+		}
+		if v.Type().Key().Kind() != reflect.String {
+			return b, 0, typeError(v.Type())
+		}
+		b, start := StartDocument(b)
+		var err error
+		for it := v.MapRange(); it.Next(); {
+			if b, err = appendElement(b, it.Key().String(), it.Value()); err != nil {
+				return b, 0, err
+			}
+		}
+		return EndDocument(b, start), TypeDocument, nil
+	case reflect.Struct:
+		b, start := StartDocument(b)
+		var err error
+		for i := 0; i < v.NumField(); i++ {
+			name, ok := fieldName(v.Type().Field(i))
+			if !ok {
+				continue
+			}
+			if b, err = appendElement(b, name, v.Field(i)); err != nil {
+				return b, 0, err
+			}
+		}
+		return EndDocument(b, start), TypeDocument, nil
+	}
+	return b, 0, typeError(v.Type())
+}
+
+func appendArray(b []byte, v reflect.Value) ([]byte, error) {
+	b, start := StartDocument(b)
+	var err error
+	for i := 0; i < v.Len(); i++ {
+		if b, err = appendElement(b, strconv.Itoa(i), v.Index(i)); err != nil {
+			return b, err
+		}
+	}
+	return EndDocument(b, start), nil
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24), byte(v>>32), byte(v>>40), byte(v>>48), byte(v>>56))
+}
@@ -0,0 +1,516 @@
+package msgpack
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Decoder reads MessagePack values from a byte slice.
+type Decoder struct {
+	b   []byte
+	off int
+}
+
+// NewDecoder returns a new decoder reading from b.
+func NewDecoder(b []byte) *Decoder {
+	return &Decoder{b: b}
+}
+
+// Remaining returns the number of bytes not yet consumed.
+func (d *Decoder) Remaining() int {
+	return len(d.b) - d.off
+}
+
+// IsNil reports whether the next value is nil without consuming it.
+func (d *Decoder) IsNil() bool {
+	return d.off < len(d.b) && d.b[d.off] == codeNil
+}
+
+// ReadMapHeader reads the header of a map and returns its number of entries.
+func (d *Decoder) ReadMapHeader() (int, error) {
+	c, err := d.readByte()
+	if err != nil {
+		return 0, err
+	}
+	return d.mapLen(c)
+}
+
+// Decode decodes the next value and stores it in the value pointed to by dst.
+func (d *Decoder) Decode(dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("msgpack: non-pointer destination %T", dst)
+	}
+	return d.decodeValue(rv.Elem())
+}
+
+// Skip consumes the next value and returns its raw encoding.
+func (d *Decoder) Skip() ([]byte, error) {
+	start := d.off
+	if err := d.skip(); err != nil {
+		return nil, err
+	}
+	return d.b[start:d.off], nil
+}
+
+func (d *Decoder) readByte() (byte, error) {
+	if d.off >= len(d.b) {
+		return 0, ErrShortBuffer
+	}
+	c := d.b[d.off]
+	d.off++
+	return c, nil
+}
+
+func (d *Decoder) readN(n int) ([]byte, error) {
+	if n < 0 || d.Remaining() < n {
+		return nil, ErrShortBuffer
+	}
+	b := d.b[d.off : d.off+n]
+	d.off += n
+	return b, nil
+}
+
+func (d *Decoder) readUint(size int) (uint64, error) {
+	b, err := d.readN(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	}
+	return binary.BigEndian.Uint64(b), nil
+}
+
+func (d *Decoder) readLen(size int) (int, error) {
+	n, err := d.readUint(size)
+	if err != nil {
+		return 0, err
+	}
+	if n > uint64(d.Remaining()) {
+		return 0, ErrShortBuffer
+	}
+	return int(n), nil
+}
+
+func (d *Decoder) mapLen(c byte) (int, error) {
+	switch {
+	case c >= codeFixMapLow && c <= codeFixMapHigh:
+		return int(c & 0x0f), nil
+	case c == codeMap16:
+		return d.readLen(2)
+	case c == codeMap32:
+		return d.readLen(4)
+	}
+	return 0, fmt.Errorf("msgpack: unexpected code %#x, expected map", c)
+}
+
+func (d *Decoder) arrayLen(c byte) (int, bool, error) {
+	switch {
+	case c >= codeFixArrLow && c <= codeFixArrHigh:
+		return int(c & 0x0f), true, nil
+	case c == codeArray16:
+		n, err := d.readLen(2)
+		return n, true, err
+	case c == codeArray32:
+		n, err := d.readLen(4)
+		return n, true, err
+	}
+	return 0, false, nil
+}
+
+// bytesOf returns the payload of a string or binary value and whether c
+// identifies a string or binary value.
+func (d *Decoder) bytesOf(c byte) ([]byte, bool, error) {
+	var n int
+	var err error
+	switch {
+	case c >= codeFixStrLow && c <= codeFixStrHigh:
+		n = int(c & 0x1f)
+	case c == codeStr8 || c == codeBin8:
+		n, err = d.readLen(1)
+	case c == codeStr16 || c == codeBin16:
+		n, err = d.readLen(2)
+	case c == codeStr32 || c == codeBin32:
+		n, err = d.readLen(4)
+	default:
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, true, err
+	}
+	b, err := d.readN(n)
+	return b, true, err
+}
+
+// number decodes an integer or floating point number. Exactly one of the
+// returned values is meaningful depending on the returned kind.
+func (d *Decoder) number(c byte) (i int64, u uint64, f float64, kind reflect.Kind, err error) {
+	switch {
+	case c <= 0x7f:
+		return int64(c), 0, 0, reflect.Int64, nil
+	case c >= codeNegFixIntLo:
+		return int64(int8(c)), 0, 0, reflect.Int64, nil
+	}
+	switch c {
+	case codeUint8, codeUint16, codeUint32, codeUint64:
+		u, err = d.readUint(1 << (c - codeUint8))
+		return 0, u, 0, reflect.Uint64, err
+	case codeInt8:
+		u, err = d.readUint(1)
+		return int64(int8(u)), 0, 0, reflect.Int64, err
+	case codeInt16:
+		u, err = d.readUint(2)
+		return int64(int16(u)), 0, 0, reflect.Int64, err
+	case codeInt32:
+		u, err = d.readUint(4)
+		return int64(int32(u)), 0, 0, reflect.Int64, err
+	case codeInt64:
+		u, err = d.readUint(8)
+		return int64(u), 0, 0, reflect.Int64, err
+	case codeFloat32:
+		u, err = d.readUint(4)
+		return 0, 0, float64(math.Float32frombits(uint32(u))), reflect.Float32, err
+	case codeFloat64:
+		u, err = d.readUint(8)
+		return 0, 0, math.Float64frombits(u), reflect.Float64, err
+	}
+	return 0, 0, 0, reflect.Invalid, nil
+}
+
+func (d *Decoder) skip() error {
+	c, err := d.readByte()
+	if err != nil {
+		return err
+	}
+	if c == codeNil || c == codeTrue || c == codeFalse {
+		return nil
+	}
+	if _, _, _, kind, err := d.number(c); err != nil || kind != reflect.Invalid {
+		return err
+	}
+	if _, ok, err := d.bytesOf(c); ok {
+		return err
+	}
+	if n, ok, err := d.arrayLen(c); ok {
+		if err != nil {
+			return err
+		}
+		for i := 0; i < n; i++ {
+			if err := d.skip(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	n, err := d.mapLen(c)
+	if err != nil {
+		return fmt.Errorf("msgpack: unsupported code %#x", c)
+	}
+	for i := 0; i < 2*n; i++ {
+		if err := d.skip(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Decoder) decodeValue(v reflect.Value) error {
+	if v.Kind() != reflect.Pointer && v.CanAddr() && v.Addr().Type().Implements(unmarshalerType) {
+		raw, err := d.Skip()
+		if err != nil {
+			return err
+		}
+		return v.Addr().Interface().(Unmarshaler).UnmarshalMsgpack(raw)
+	}
+	if d.IsNil() {
+		d.off++
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return d.decodeValue(v.Elem())
+	case reflect.Interface:
+		if v.NumMethod() != 0 {
+			return typeError(v.Type())
+		}
+		x, err := d.decodeInterface()
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(x))
+		return nil
+	}
+
+	c, err := d.readByte()
+	if err != nil {
+		return err
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		if c != codeTrue && c != codeFalse {
+			return d.mismatch(c, v.Type())
+		}
+		v.SetBool(c == codeTrue)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, u, _, kind, err := d.number(c)
+		if err != nil {
+			return err
+		}
+		switch kind {
+		case reflect.Int64:
+		case reflect.Uint64:
+			if u > math.MaxInt64 {
+				return d.overflow(u, v.Type())
+			}
+			i = int64(u)
+		default:
+			return d.mismatch(c, v.Type())
+		}
+		if v.OverflowInt(i) {
+			return d.overflow(i, v.Type())
+		}
+		v.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		i, u, _, kind, err := d.number(c)
+		if err != nil {
+			return err
+		}
+		switch kind {
+		case reflect.Uint64:
+		case reflect.Int64:
+			if i < 0 {
+				return d.overflow(i, v.Type())
+			}
+			u = uint64(i)
+		default:
+			return d.mismatch(c, v.Type())
+		}
+		if v.OverflowUint(u) {
+			return d.overflow(u, v.Type())
+		}
+		v.SetUint(u)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		i, u, f, kind, err := d.number(c)
+		if err != nil {
+			return err
+		}
+		switch kind {
+		case reflect.Int64:
+			f = float64(i)
+		case reflect.Uint64:
+			f = float64(u)
+		case reflect.Invalid:
+			return d.mismatch(c, v.Type())
+		}
+		v.SetFloat(f)
+		return nil
+	case reflect.String:
+		b, ok, err := d.bytesOf(c)
+		if !ok {
+			return d.mismatch(c, v.Type())
+		}
+		if err != nil {
+			return err
+		}
+		v.SetString(string(b))
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			if b, ok, err := d.bytesOf(c); ok {
+				if err != nil {
+					return err
+				}
+				v.SetBytes(append([]byte{}, b...))
+				return nil
+			}
+		}
+		n, ok, err := d.arrayLen(c)
+		if !ok {
+			return d.mismatch(c, v.Type())
+		}
+		if err != nil {
+			return err
+		}
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			if err := d.decodeValue(s.Index(i)); err != nil {
+				return err
+			}
+		}
+		v.Set(s)
+		return nil
+	case reflect.Array:
+		n, ok, err := d.arrayLen(c)
+		if !ok {
+			return d.mismatch(c, v.Type())
+		}
+		if err != nil {
+			return err
+		}
+		if n != v.Len() {
+			return fmt.Errorf("msgpack: cannot decode array of %d elements into %s", n, v.Type())
+		}
+		for i := 0; i < n; i++ {
+			if err := d.decodeValue(v.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Map:
+		n, err := d.mapLen(c)
+		if err != nil {
+			return err
+		}
+		m := reflect.MakeMapWithSize(v.Type(), n)
+		for i := 0; i < n; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			if err := d.decodeValue(key); err != nil {
+				return err
+			}
+			val := reflect.New(v.Type().Elem()).Elem()
+			if err := d.decodeValue(val); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, val)
+		}
+		v.Set(m)
+		return nil
+	case reflect.Struct:
+		n, err := d.mapLen(c)
+		if err != nil {
+			return err
+		}
+		fields := make(map[string]int, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			if name, ok := fieldName(v.Type().Field(i)); ok {
+				fields[name] = i
+			}
+		}
+		for i := 0; i < n; i++ {
+			var name string
+			if err := d.decodeValue(reflect.ValueOf(&name).Elem()); err != nil {
+				return err
+			}
+			idx, ok := fields[name]
+			if !ok {
+				if err := d.skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := d.decodeValue(v.Field(idx)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return typeError(v.Type())
+}
+
+// decodeInterface decodes the next value into its natural Go representation.
+//
+// Signed integers are decoded as int64, unsigned integers as uint64, strings
+// as string, binary blobs as []byte, arrays as []any and maps as
+// map[string]any if all keys are strings or map[any]any otherwise.
+func (d *Decoder) decodeInterface() (any, error) {
+	c, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	switch c {
+	case codeNil:
+		return nil, nil
+	case codeTrue:
+		return true, nil
+	case codeFalse:
+		return false, nil
+	}
+	if i, u, f, kind, err := d.number(c); err != nil || kind != reflect.Invalid {
+		switch kind {
+		case reflect.Int64:
+			return i, err
+		case reflect.Uint64:
+			return u, err
+		case reflect.Float32:
+			return float32(f), err
+		}
+		return f, err
+	}
+	if b, ok, err := d.bytesOf(c); ok {
+		if err != nil {
+			return nil, err
+		}
+		if c == codeBin8 || c == codeBin16 || c == codeBin32 {
+			return append([]byte{}, b...), nil
+		}
+		return string(b), nil
+	}
+	if n, ok, err := d.arrayLen(c); ok {
+		if err != nil {
+			return nil, err
+		}
+		s := make([]any, n)
+		for i := range s {
+			if s[i], err = d.decodeInterface(); err != nil {
+				return nil, err
+			}
+		}
+		return s, nil
+	}
+	n, err := d.mapLen(c)
+	if err != nil {
+		return nil, fmt.Errorf("msgpack: unsupported code %#x", c)
+	}
+	keys := make([]any, n)
+	values := make([]any, n)
+	allStrings := true
+	for i := 0; i < n; i++ {
+		if keys[i], err = d.decodeInterface(); err != nil {
+			return nil, err
+		}
+		if _, ok := keys[i].(string); !ok {
+			allStrings = false
+		}
+		if values[i], err = d.decodeInterface(); err != nil {
+			return nil, err
+		}
+	}
+	if allStrings {
+		m := make(map[string]any, n)
+		for i := range keys {
+			m[keys[i].(string)] = values[i]
+		}
+		return m, nil
+	}
+	m := make(map[any]any, n)
+	for i := range keys {
+		if keys[i] != nil && !reflect.TypeOf(keys[i]).Comparable() {
+			return nil, fmt.Errorf("msgpack: unhashable map key of type %T", keys[i])
+		}
+		m[keys[i]] = values[i]
+	}
+	return m, nil
+}
+
+func (d *Decoder) mismatch(c byte, t reflect.Type) error {
+	return fmt.Errorf("msgpack: cannot decode code %#x into %s", c, t)
+}
+
+func (d *Decoder) overflow(n any, t reflect.Type) error {
+	return fmt.Errorf("msgpack: value %v overflows %s", n, t)
+}
@@ -0,0 +1,232 @@
+package msgpack
+
+import (
+	"math"
+	"reflect"
+)
+
+// AppendNil appends a nil value to b.
+func AppendNil(b []byte) []byte {
+	return append(b, codeNil)
+}
+
+// AppendBool appends a boolean to b.
+func AppendBool(b []byte, v bool) []byte {
+	if v {
+		return append(b, codeTrue)
+	}
+	return append(b, codeFalse)
+}
+
+// AppendInt appends a signed integer to b using the most compact representation.
+func AppendInt(b []byte, v int64) []byte {
+	switch {
+	case v >= 0:
+		return AppendUint(b, uint64(v))
+	case v >= -32:
+		return append(b, byte(v))
+	case v >= math.MinInt8:
+		return append(b, codeInt8, byte(v))
+	case v >= math.MinInt16:
+		return appendUint16(append(b, codeInt16), uint16(v))
+	case v >= math.MinInt32:
+		return appendUint32(append(b, codeInt32), uint32(v))
+	}
+	return appendUint64(append(b, codeInt64), uint64(v))
+}
+
+// AppendUint appends an unsigned integer to b using the most compact representation.
+func AppendUint(b []byte, v uint64) []byte {
+	switch {
+	case v <= math.MaxInt8:
+		return append(b, byte(v))
+	case v <= math.MaxUint8:
+		return append(b, codeUint8, byte(v))
+	case v <= math.MaxUint16:
+		return appendUint16(append(b, codeUint16), uint16(v))
+	case v <= math.MaxUint32:
+		return appendUint32(append(b, codeUint32), uint32(v))
+	}
+	return appendUint64(append(b, codeUint64), v)
+}
+
+// AppendFloat32 appends a single precision floating point number to b.
+func AppendFloat32(b []byte, v float32) []byte {
+	return appendUint32(append(b, codeFloat32), math.Float32bits(v))
+}
+
+// AppendFloat64 appends a double precision floating point number to b.
+func AppendFloat64(b []byte, v float64) []byte {
+	return appendUint64(append(b, codeFloat64), math.Float64bits(v))
+}
+
+// AppendString appends a string to b.
+func AppendString(b []byte, v string) []byte {
+	n := len(v)
+	switch {
+	case n < 32:
+		b = append(b, byte(codeFixStrLow|n))
+	case n <= math.MaxUint8:
+		b = append(b, codeStr8, byte(n))
+	case n <= math.MaxUint16:
+		b = appendUint16(append(b, codeStr16), uint16(n))
+	default:
+		b = appendUint32(append(b, codeStr32), uint32(n))
+	}
+	return append(b, v...)
+}
+
+// AppendBytes appends a binary blob to b.
+func AppendBytes(b []byte, v []byte) []byte {
+	n := len(v)
+	switch {
+	case n <= math.MaxUint8:
+		b = append(b, codeBin8, byte(n))
+	case n <= math.MaxUint16:
+		b = appendUint16(append(b, codeBin16), uint16(n))
+	default:
+		b = appendUint32(append(b, codeBin32), uint32(n))
+	}
+	return append(b, v...)
+}
+
+// AppendArrayHeader appends the header of an array of n elements to b.
+func AppendArrayHeader(b []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, byte(codeFixArrLow|n))
+	case n <= math.MaxUint16:
+		return appendUint16(append(b, codeArray16), uint16(n))
+	}
+	return appendUint32(append(b, codeArray32), uint32(n))
+}
+
+// AppendMapHeader appends the header of a map of n entries to b.
+//
+// The header must be followed by n key-value pairs.
+func AppendMapHeader(b []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, byte(codeFixMapLow|n))
+	case n <= math.MaxUint16:
+		return appendUint16(append(b, codeMap16), uint16(n))
+	}
+	return appendUint32(append(b, codeMap32), uint32(n))
+}
+
+// Append appends the encoding of v to b.
+func Append(b []byte, v any) ([]byte, error) {
+	return appendValue(b, reflect.ValueOf(v))
+}
+
+func appendValue(b []byte, v reflect.Value) ([]byte, error) {
+	if !v.IsValid() {
+		return AppendNil(b), nil
+	}
+	if v.Type().Implements(marshalerType) {
+		if (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+			return AppendNil(b), nil
+		}
+		data, err := v.Interface().(Marshaler).MarshalMsgpack()
+		if err != nil {
+			return b, err
+		}
+		return append(b, data...), nil
+	}
+	if v.Kind() != reflect.Pointer && v.CanAddr() && v.Addr().Type().Implements(marshalerType) {
+		return appendValue(b, v.Addr())
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return AppendNil(b), nil
+		}
+		return appendValue(b, v.Elem())
+	case reflect.Bool:
+		return AppendBool(b, v.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return AppendInt(b, v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return AppendUint(b, v.Uint()), nil
+	case reflect.Float32:
+		return AppendFloat32(b, float32(v.Float())), nil
+	case reflect.Float64:
+		return AppendFloat64(b, v.Float()), nil
+	case reflect.String:
+		return AppendString(b, v.String()), nil
+	case reflect.Slice:
+		if v.IsNil() {
+			return AppendNil(b), nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return AppendBytes(b, v.Bytes()), nil
+		}
+		return appendArray(b, v)
+	case reflect.Array:
+		return appendArray(b, v)
+	case reflect.Map:
+		if v.IsNil() {
+			return AppendNil(b), nil
+		}
+		b = AppendMapHeader(b, v.Len())
+		var err error
+		for it := v.MapRange(); it.Next(); {
+			if b, err = appendValue(b, it.Key()); err != nil {
+				return b, err
+			}
+			if b, err = appendValue(b, it.Value()); err != nil {
+				return b, err
+			}
+		}
+		return b, nil
+	case reflect.Struct:
+		return appendStruct(b, v)
+	}
+	return b, typeError(v.Type())
+}
+
+func appendArray(b []byte, v reflect.Value) ([]byte, error) {
+	b = AppendArrayHeader(b, v.Len())
+	var err error
+	for i := 0; i < v.Len(); i++ {
+		if b, err = appendValue(b, v.Index(i)); err != nil {
+			return b, err
+		}
+	}
+	return b, nil
+}
+
+func appendStruct(b []byte, v reflect.Value) ([]byte, error) {
+	t := v.Type()
+	n := 0
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := fieldName(t.Field(i)); ok {
+			n++
+		}
+	}
+	b = AppendMapHeader(b, n)
+	var err error
+	for i := 0; i < t.NumField(); i++ {
+		name, ok := fieldName(t.Field(i))
+		if !ok {
+			continue
+		}
+		b = AppendString(b, name)
+		if b, err = appendValue(b, v.Field(i)); err != nil {
+			return b, err
+		}
+	}
+	return b, nil
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	return append(b, byte(v>>8), byte(v))
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	return append(b, byte(v>>56), byte(v>>48), byte(v>>40), byte(v>>32), byte(v>>24), byte(v>>16), byte(v>>8), byte(v))
+}
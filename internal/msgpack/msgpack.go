@@ -0,0 +1,100 @@
+// Package msgpack implements a minimal MessagePack encoder and decoder.
+//
+// It supports nil, booleans, integers, floating point numbers, strings,
+// byte slices, slices, arrays, maps, pointers, interfaces, structs and types
+// implementing Marshaler and Unmarshaler. Extension types are not supported.
+//
+// The encoding is compatible with the one of github.com/vmihailenco/msgpack,
+// including the use of the "msgpack" struct tag to rename or skip fields.
+package msgpack
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Marshaler is the interface implemented by types that can marshal themselves
+// into valid MessagePack.
+type Marshaler interface {
+	MarshalMsgpack() ([]byte, error)
+}
+
+// Unmarshaler is the interface implemented by types that can unmarshal a
+// MessagePack description of themselves.
+type Unmarshaler interface {
+	UnmarshalMsgpack([]byte) error
+}
+
+// ErrShortBuffer indicates that the data to decode is truncated.
+var ErrShortBuffer = errors.New("msgpack: short buffer")
+
+const (
+	codeNil     = 0xc0
+	codeFalse   = 0xc2
+	codeTrue    = 0xc3
+	codeBin8    = 0xc4
+	codeBin16   = 0xc5
+	codeBin32   = 0xc6
+	codeExt8    = 0xc7
+	codeExt16   = 0xc8
+	codeExt32   = 0xc9
+	codeFloat32 = 0xca
+	codeFloat64 = 0xcb
+	codeUint8   = 0xcc
+	codeUint16  = 0xcd
+	codeUint32  = 0xce
+	codeUint64  = 0xcf
+	codeInt8    = 0xd0
+	codeInt16   = 0xd1
+	codeInt32   = 0xd2
+	codeInt64   = 0xd3
+	codeFixExt1 = 0xd4
+	codeFixExt2 = 0xd5
+	codeFixExt4 = 0xd6
+	codeFixExt8 = 0xd7
+	codeFixExt6 = 0xd8
+	codeStr8    = 0xd9
+	codeStr16   = 0xda
+	codeStr32   = 0xdb
+	codeArray16 = 0xdc
+	codeArray32 = 0xdd
+	codeMap16   = 0xde
+	codeMap32   = 0xdf
+
+	codeFixMapLow   = 0x80
+	codeFixMapHigh  = 0x8f
+	codeFixArrLow   = 0x90
+	codeFixArrHigh  = 0x9f
+	codeFixStrLow   = 0xa0
+	codeFixStrHigh  = 0xbf
+	codeNegFixIntLo = 0xe0
+)
+
+var (
+	marshalerType   = reflect.TypeOf((*Marshaler)(nil)).Elem()
+	unmarshalerType = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
+	bytesType       = reflect.TypeOf([]byte(nil))
+)
+
+// fieldName returns the name under which a struct field is encoded and
+// whether the field should be encoded at all.
+func fieldName(f reflect.StructField) (string, bool) {
+	if !f.IsExported() {
+		return "", false
+	}
+	tag := f.Tag.Get("msgpack")
+	if tag == "-" {
+		return "", false
+	}
+	if name, _, _ := strings.Cut(tag, ","); name != "" {
+		return name, true
+	}
+	return f.Name, true
+}
+
+// typeError returns the error reported when a type cannot be encoded or decoded.
+func typeError(t reflect.Type) error {
+	return fmt.Errorf("msgpack: unsupported type %s", t)
+}
@@ -0,0 +1,225 @@
+package msgpack
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestAppend(t *testing.T) {
+	cases := []struct {
+		name string
+		v    any
+		want []byte
+	}{
+		{"nil", nil, []byte{0xc0}},
+		{"false", false, []byte{0xc2}},
+		{"true", true, []byte{0xc3}},
+		{"positive fixint", 7, []byte{0x07}},
+		{"negative fixint", -1, []byte{0xff}},
+		{"uint8", uint8(200), []byte{0xcc, 0xc8}},
+		{"uint16", 1000, []byte{0xcd, 0x03, 0xe8}},
+		{"uint32", 1 << 20, []byte{0xce, 0x00, 0x10, 0x00, 0x00}},
+		{"uint64", uint64(1) << 40, []byte{0xcf, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00}},
+		{"int8", -100, []byte{0xd0, 0x9c}},
+		{"int16", -1000, []byte{0xd1, 0xfc, 0x18}},
+		{"int32", -(1 << 20), []byte{0xd2, 0xff, 0xf0, 0x00, 0x00}},
+		{"int64", int64(math.MinInt64), []byte{0xd3, 0x80, 0, 0, 0, 0, 0, 0, 0}},
+		{"float32", float32(1.5), []byte{0xca, 0x3f, 0xc0, 0x00, 0x00}},
+		{"float64", 1.5, []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{"fixstr", "abc", []byte{0xa3, 'a', 'b', 'c'}},
+		{"bin", []byte{1, 2}, []byte{0xc4, 0x02, 0x01, 0x02}},
+		{"nil slice", []int(nil), []byte{0xc0}},
+		{"fixarray", []int{1, 2}, []byte{0x92, 0x01, 0x02}},
+		{"array", [2]bool{true, false}, []byte{0x92, 0xc3, 0xc2}},
+		{"fixmap", map[string]int{"a": 1}, []byte{0x81, 0xa1, 'a', 0x01}},
+		{"struct", struct {
+			A int
+			B string `msgpack:"b"`
+			C int    `msgpack:"-"`
+			d int
+		}{1, "x", 2, 3}, []byte{0x82, 0xa1, 'A', 0x01, 0xa1, 'b', 0xa1, 'x'}},
+		{"nil pointer", (*int)(nil), []byte{0xc0}},
+		{"marshaler", custom{"x"}, []byte{0xa2, 'x', '!'}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := Append(nil, c.v)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, c.want) {
+				t.Fatalf("unexpected encoding: want: %x, got %x", c.want, got)
+			}
+		})
+	}
+}
+
+func TestAppendLong(t *testing.T) {
+	s := strings.Repeat("a", 300)
+	got, err := Append(nil, s)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []byte{0xda, 0x01, 0x2c}; !bytes.Equal(got[:3], want) {
+		t.Fatalf("unexpected header: want: %x, got %x", want, got[:3])
+	}
+	var out string
+	if err := NewDecoder(got).Decode(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != s {
+		t.Fatal("unexpected decoded string")
+	}
+}
+
+func TestAppendUnsupported(t *testing.T) {
+	if _, err := Append(nil, make(chan int)); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	type inner struct {
+		X []int
+		Y map[string]float64
+	}
+	type outer struct {
+		A int8
+		B uint
+		C string
+		D *inner
+		E []byte
+		F bool
+		G [2]string
+		H custom
+	}
+	in := outer{
+		A: -5,
+		B: 1 << 33,
+		C: "hello",
+		D: &inner{X: []int{1, -200, 70000}, Y: map[string]float64{"pi": 3.14}},
+		E: []byte("raw"),
+		F: true,
+		G: [2]string{"x", "y"},
+		H: custom{"c"},
+	}
+	data, err := Append(nil, in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var out outer
+	d := NewDecoder(data)
+	if err := d.Decode(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if d.Remaining() != 0 {
+		t.Fatalf("unexpected remaining bytes: %d", d.Remaining())
+	}
+	if diff := cmp.Diff(in, out, cmp.AllowUnexported(custom{})); diff != "" {
+		t.Fatalf("unexpected value (-want +got):\n%s", diff)
+	}
+}
+
+func TestDecodeInterface(t *testing.T) {
+	in := map[string]any{
+		"int":    -3,
+		"uint":   uint64(math.MaxUint64),
+		"float":  2.5,
+		"string": "s",
+		"bytes":  []byte{1},
+		"array":  []any{nil, true},
+		"map":    map[int]string{1: "one"},
+	}
+	data, err := Append(nil, in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var out any
+	if err := NewDecoder(data).Decode(&out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string]any{
+		"int":    int64(-3),
+		"uint":   uint64(math.MaxUint64),
+		"float":  2.5,
+		"string": "s",
+		"bytes":  []byte{1},
+		"array":  []any{nil, true},
+		"map":    map[any]any{int64(1): "one"},
+	}
+	if diff := cmp.Diff(want, out); diff != "" {
+		t.Fatalf("unexpected value (-want +got):\n%s", diff)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+		dst  any
+	}{
+		{"short buffer", []byte{0xcd, 0x01}, new(int)},
+		{"overflow", []byte{0xcd, 0x01, 0x00}, new(int8)},
+		{"negative into unsigned", []byte{0xff}, new(uint)},
+		{"type mismatch", []byte{0xa1, 'a'}, new(int)},
+		{"array length mismatch", []byte{0x91, 0x01}, new([2]int)},
+		{"truncated string", []byte{0xd9, 0x05, 'a'}, new(string)},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if err := NewDecoder(c.data).Decode(c.dst); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestDecodeShortBuffer(t *testing.T) {
+	var n int
+	if err := NewDecoder(nil).Decode(&n); !errors.Is(err, ErrShortBuffer) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrShortBuffer, err)
+	}
+}
+
+func TestSkip(t *testing.T) {
+	data, err := Append(nil, []any{map[string]any{"a": []int{1, 2}}, "x"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data = AppendBool(data, true)
+	d := NewDecoder(data)
+	raw, err := d.Skip()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := data[:len(data)-1]; !bytes.Equal(raw, want) {
+		t.Fatalf("unexpected raw value: want: %x, got %x", want, raw)
+	}
+	var b bool
+	if err := d.Decode(&b); err != nil || !b {
+		t.Fatalf("unexpected value: %t, err: %v", b, err)
+	}
+}
+
+// custom is a type implementing Marshaler and Unmarshaler
+type custom struct {
+	s string
+}
+
+func (c custom) MarshalMsgpack() ([]byte, error) {
+	return AppendString(nil, c.s+"!"), nil
+}
+
+func (c *custom) UnmarshalMsgpack(b []byte) error {
+	var s string
+	if err := NewDecoder(b).Decode(&s); err != nil {
+		return err
+	}
+	c.s = strings.TrimSuffix(s, "!")
+	return nil
+}
@@ -0,0 +1,168 @@
+// Package seqtree implements a sequence of values supporting positional
+// access, insertion, removal and lookup of the position of a value in
+// O(log n) expected time.
+//
+// It is implemented as an implicit treap, i.e. a randomized binary search
+// tree ordered by position, whose nodes are augmented with the size of their
+// subtree and linked to their parent, so that the position of a node can be
+// computed by walking up to the root.
+package seqtree
+
+// Node is a node of a tree, holding a value of the sequence.
+type Node[V any] struct {
+	// Value is the value stored in this node.
+	Value V
+
+	parent, left, right *Node[V]
+	size                int
+	priority            uint64
+}
+
+// Tree is a sequence of values.
+// The zero value for Tree is an empty tree ready to use.
+type Tree[V any] struct {
+	root *Node[V]
+	seed uint64
+}
+
+// Len returns the number of values of t.
+func (t *Tree[V]) Len() int {
+	return size(t.root)
+}
+
+// Clear removes all values from t.
+func (t *Tree[V]) Clear() {
+	t.root = nil
+}
+
+// At returns the node at position i of t, or nil if i is out of range.
+func (t *Tree[V]) At(i int) *Node[V] {
+	if i < 0 || i >= t.Len() {
+		return nil
+	}
+	n := t.root
+	for {
+		l := size(n.left)
+		switch {
+		case i < l:
+			n = n.left
+		case i > l:
+			i -= l + 1
+			n = n.right
+		default:
+			return n
+		}
+	}
+}
+
+// Index returns the position of n in t.
+// n must be a node of t.
+func (t *Tree[V]) Index(n *Node[V]) int {
+	i := size(n.left)
+	for ; n.parent != nil; n = n.parent {
+		if n == n.parent.right {
+			i += size(n.parent.left) + 1
+		}
+	}
+	return i
+}
+
+// InsertAt inserts v at position i of t, which must be in the range
+// [0, t.Len()], and returns the new node.
+func (t *Tree[V]) InsertAt(i int, v V) *Node[V] {
+	n := &Node[V]{Value: v}
+	t.insertNode(i, n)
+	return n
+}
+
+// Remove removes n from t.
+// n must be a node of t.
+func (t *Tree[V]) Remove(n *Node[V]) {
+	l, r := split(t.root, t.Index(n))
+	_, r = split(r, 1)
+	t.root = setParent(merge(l, r), nil)
+}
+
+// Move moves n to position i of t, where i is the position the node
+// will have after the move and must be in the range [0, t.Len()-1].
+// n must be a node of t.
+func (t *Tree[V]) Move(n *Node[V], i int) {
+	t.Remove(n)
+	t.insertNode(i, n)
+}
+
+// insertNode inserts the detached node n at position i of t.
+func (t *Tree[V]) insertNode(i int, n *Node[V]) {
+	n.parent, n.left, n.right, n.size = nil, nil, nil, 1
+	n.priority = t.nextPriority()
+	l, r := split(t.root, i)
+	t.root = setParent(merge(merge(l, n), r), nil)
+}
+
+// nextPriority returns a pseudo-random priority using a xorshift generator.
+func (t *Tree[V]) nextPriority() uint64 {
+	if t.seed == 0 {
+		t.seed = 0x9e3779b97f4a7c15
+	}
+	t.seed ^= t.seed << 13
+	t.seed ^= t.seed >> 7
+	t.seed ^= t.seed << 17
+	return t.seed
+}
+
+// split splits the subtree rooted at n into two subtrees containing
+// respectively the first i values and the remaining ones.
+func split[V any](n *Node[V], i int) (l, r *Node[V]) {
+	if n == nil {
+		return nil, nil
+	}
+	if size(n.left) < i {
+		n.right, r = split(n.right, i-size(n.left)-1)
+		update(n)
+		return setParent(n, nil), setParent(r, nil)
+	}
+	l, n.left = split(n.left, i)
+	update(n)
+	return setParent(l, nil), setParent(n, nil)
+}
+
+// merge concatenates the subtrees rooted at l and r.
+func merge[V any](l, r *Node[V]) *Node[V] {
+	if l == nil {
+		return r
+	}
+	if r == nil {
+		return l
+	}
+	if l.priority > r.priority {
+		l.right = merge(l.right, r)
+		update(l)
+		return l
+	}
+	r.left = merge(l, r.left)
+	update(r)
+	return r
+}
+
+// update recomputes the size of n and links its children to it.
+func update[V any](n *Node[V]) {
+	n.size = size(n.left) + size(n.right) + 1
+	setParent(n.left, n)
+	setParent(n.right, n)
+}
+
+// setParent sets the parent of n, if not nil, and returns n.
+func setParent[V any](n, parent *Node[V]) *Node[V] {
+	if n != nil {
+		n.parent = parent
+	}
+	return n
+}
+
+// size returns the size of the subtree rooted at n.
+func size[V any](n *Node[V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
@@ -0,0 +1,92 @@
+package seqtree
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// checkTree verifies that t holds the values in want, in order,
+// and that the nodes are consistently linked.
+func checkTree(t *testing.T, tree *Tree[int], nodes map[int]*Node[int], want []int) {
+	t.Helper()
+	if tree.Len() != len(want) {
+		t.Fatalf("unexpected length: want: %d, got %d", len(want), tree.Len())
+	}
+	if tree.root != nil && tree.root.parent != nil {
+		t.Fatal("root has a parent")
+	}
+	for i, v := range want {
+		n := tree.At(i)
+		if n == nil || n.Value != v {
+			t.Fatalf("unexpected node at %d: want value %d, got %v", i, v, n)
+		}
+		if n != nodes[v] {
+			t.Fatalf("unexpected node at %d: node of value %d has been replaced", i, v)
+		}
+		if got := tree.Index(n); got != i {
+			t.Fatalf("unexpected index of value %d: want: %d, got %d", v, i, got)
+		}
+	}
+	if n := tree.At(-1); n != nil {
+		t.Fatalf("unexpected node at -1: %v", n)
+	}
+	if n := tree.At(len(want)); n != nil {
+		t.Fatalf("unexpected node at %d: %v", len(want), n)
+	}
+}
+
+func TestZeroTree(t *testing.T) {
+	var tree Tree[int]
+	checkTree(t, &tree, nil, nil)
+}
+
+func TestTree(t *testing.T) {
+	var tree Tree[int]
+	nodes := map[int]*Node[int]{}
+	nodes[1] = tree.InsertAt(0, 1)
+	nodes[3] = tree.InsertAt(1, 3)
+	nodes[2] = tree.InsertAt(1, 2)
+	nodes[0] = tree.InsertAt(0, 0)
+	checkTree(t, &tree, nodes, []int{0, 1, 2, 3})
+
+	tree.Move(nodes[0], 3)
+	checkTree(t, &tree, nodes, []int{1, 2, 3, 0})
+
+	tree.Move(nodes[3], 0)
+	checkTree(t, &tree, nodes, []int{3, 1, 2, 0})
+
+	tree.Remove(nodes[1])
+	checkTree(t, &tree, nodes, []int{3, 2, 0})
+
+	tree.Clear()
+	checkTree(t, &tree, nodes, nil)
+}
+
+func TestTreeRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	var tree Tree[int]
+	nodes := map[int]*Node[int]{}
+	var want []int
+	for v := 0; v < 2000; v++ {
+		switch op := rnd.Intn(4); {
+		case op < 2 || len(want) == 0:
+			i := rnd.Intn(len(want) + 1)
+			nodes[v] = tree.InsertAt(i, v)
+			want = append(want[:i], append([]int{v}, want[i:]...)...)
+		case op == 2:
+			i := rnd.Intn(len(want))
+			tree.Remove(nodes[want[i]])
+			want = append(want[:i], want[i+1:]...)
+		default:
+			i, j := rnd.Intn(len(want)), rnd.Intn(len(want))
+			moved := want[i]
+			tree.Move(nodes[moved], j)
+			want = append(want[:i], want[i+1:]...)
+			want = append(want[:j], append([]int{moved}, want[j:]...)...)
+		}
+		if v%100 == 0 {
+			checkTree(t, &tree, nodes, want)
+		}
+	}
+	checkTree(t, &tree, nodes, want)
+}
@@ -0,0 +1,108 @@
+package orderedmap
+
+import "fmt"
+
+// Change is a modification of an ordered map recorded in its journal.
+type Change[K comparable, V any] struct {
+	// Seq is the sequence number of the change. The first change of a map
+	// has sequence number 1, and each following change has the sequence
+	// number of the previous one plus one.
+	Seq uint64
+
+	// Edit describes the change.
+	Edit Edit[K, V]
+}
+
+// WithJournal configures the map to retain its last size changes in memory,
+// so that they can be retrieved with Journal, for example to stream them to
+// replicas of the map, which can apply them with Replay.
+//
+// Changes are recorded as the edits notified to a hook registered with
+// WithWriteHook, regardless of whether one is registered. Copies of the map,
+// such as those returned by Clone, do not record their changes.
+// WithJournal panics if size is lower than 1.
+func WithJournal(size int) Option {
+	if size < 1 {
+		panic(fmt.Sprintf("orderedmap: invalid journal size %d", size))
+	}
+	return func(o *options) {
+		o.journalSize = size
+	}
+}
+
+// journal is a ring buffer of the last changes of a map.
+type journal[K comparable, V any] struct {
+	changes []Change[K, V]
+	// head is the index of the oldest change
+	head int
+	seq  uint64
+}
+
+func newJournal[K comparable, V any](size int) *journal[K, V] {
+	return &journal[K, V]{changes: make([]Change[K, V], 0, size)}
+}
+
+// record records an edit as a new change.
+func (j *journal[K, V]) record(e Edit[K, V]) {
+	j.seq++
+	c := Change[K, V]{Seq: j.seq, Edit: e}
+	if len(j.changes) < cap(j.changes) {
+		j.changes = append(j.changes, c)
+		return
+	}
+	j.changes[j.head] = c
+	j.head = (j.head + 1) % len(j.changes)
+}
+
+// JournalSeq returns the sequence number of the last change of the map
+// recorded in its journal, or 0 if none has been recorded.
+func (m *OrderedMap[K, V]) JournalSeq() uint64 {
+	if m.hook == nil || m.hook.journal == nil {
+		return 0
+	}
+	return m.hook.journal.seq
+}
+
+// Journal returns the changes of the map with sequence numbers greater than
+// since, in order. A replica that has applied all changes up to since can
+// apply them with Replay to catch up with the map.
+//
+// It returns ErrJournalTruncated if some of the changes are no longer
+// retained, in which case the replica must be rebuilt from a full copy of the
+// map, or if the map has not been configured with WithJournal. It returns
+// ErrIndexOutOfRange if since is greater than the sequence number of the last
+// change.
+func (m *OrderedMap[K, V]) Journal(since uint64) ([]Change[K, V], error) {
+	if m.hook == nil || m.hook.journal == nil {
+		return nil, ErrJournalTruncated
+	}
+	j := m.hook.journal
+	if since > j.seq {
+		return nil, ErrIndexOutOfRange
+	}
+	n := j.seq - since
+	if n > uint64(len(j.changes)) {
+		return nil, ErrJournalTruncated
+	}
+	changes := make([]Change[K, V], 0, n)
+	for i := len(j.changes) - int(n); i < len(j.changes); i++ {
+		changes = append(changes, j.changes[(j.head+i)%len(j.changes)])
+	}
+	return changes, nil
+}
+
+// Replay applies changes returned by Journal to the map, in order,
+// atomically, as Apply does.
+//
+// It returns an error if the sequence numbers of the changes are not
+// consecutive, without modifying the map.
+func (m *OrderedMap[K, V]) Replay(changes []Change[K, V]) error {
+	edits := make([]Edit[K, V], len(changes))
+	for i, c := range changes {
+		if i > 0 && c.Seq != changes[i-1].Seq+1 {
+			return fmt.Errorf("change %d follows change %d: %w", c.Seq, changes[i-1].Seq, ErrInvalidRange)
+		}
+		edits[i] = c.Edit
+	}
+	return m.Apply(edits)
+}
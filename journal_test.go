@@ -0,0 +1,170 @@
+package orderedmap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestJournal(t *testing.T) {
+	m := New[string, int](WithJournal(100))
+	replica := New[string, int]()
+	var seq uint64
+
+	// sync applies the changes since the last sync to the replica
+	sync := func() {
+		t.Helper()
+		changes, err := m.Journal(seq)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := replica.Replay(changes); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		seq = m.JournalSeq()
+		checkAll(t, replica, m.Items())
+	}
+
+	m.Set("a", 1)
+	m.Set("b", 2)
+	if err := m.PushFront("c", 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seq := m.JournalSeq(); seq != 3 {
+		t.Fatalf("unexpected sequence number: want: 3, got %d", seq)
+	}
+	sync()
+	m.Set("a", 10)
+	SortKeys(m)
+	sync()
+	m.Delete("b")
+	if err := m.MoveAfter("c", "a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sync()
+	sync()
+
+	if _, err := m.Journal(m.JournalSeq() + 1); !errors.Is(err, ErrIndexOutOfRange) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrIndexOutOfRange, err)
+	}
+	// copies do not record changes
+	clone := m.Clone()
+	clone.Set("z", 26)
+	if _, err := clone.Journal(0); !errors.Is(err, ErrJournalTruncated) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrJournalTruncated, err)
+	}
+}
+
+func TestJournalTruncated(t *testing.T) {
+	m := New[int, int](WithJournal(3))
+	for i := 0; i < 5; i++ {
+		m.Set(i, i)
+	}
+	if _, err := m.Journal(1); !errors.Is(err, ErrJournalTruncated) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrJournalTruncated, err)
+	}
+	changes, err := m.Journal(2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Change[int, int]{
+		{Seq: 3, Edit: Edit[int, int]{Op: EditPushBack, Key: 2, Value: 2}},
+		{Seq: 4, Edit: Edit[int, int]{Op: EditPushBack, Key: 3, Value: 3}},
+		{Seq: 5, Edit: Edit[int, int]{Op: EditPushBack, Key: 4, Value: 4}},
+	}
+	if diff := cmp.Diff(want, changes); diff != "" {
+		t.Fatalf("unexpected changes (-want +got):\n%s", diff)
+	}
+	if changes, err := m.Journal(5); err != nil || len(changes) != 0 {
+		t.Fatalf("unexpected result: want: no changes, got %v (%v)", changes, err)
+	}
+
+	if _, err := New[int, int]().Journal(0); !errors.Is(err, ErrJournalTruncated) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrJournalTruncated, err)
+	}
+}
+
+func TestJournalWithWriteHook(t *testing.T) {
+	var edits []Edit[int, int]
+	m := New[int, int](WithJournal(10), WithWriteHook(func(e []Edit[int, int]) {
+		edits = append(edits, e...)
+	}, 0))
+	m.Set(1, 1)
+	m.Set(1, 2)
+	changes, err := m.Journal(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != len(edits) {
+		t.Fatalf("unexpected number of changes: want: %d, got %d", len(edits), len(changes))
+	}
+	for i, c := range changes {
+		if diff := cmp.Diff(edits[i], c.Edit); diff != "" {
+			t.Fatalf("unexpected change %d (-want +got):\n%s", i, diff)
+		}
+	}
+}
+
+func TestJournalBounded(t *testing.T) {
+	opts := []Option{WithMaxEntries[string, int](2, EvictOldest, nil)}
+	for _, name := range []string{"replica with same bounds", "unbounded replica"} {
+		t.Run(name, func(t *testing.T) {
+			m := New[string, int](append(opts, WithJournal(100))...)
+			replica := New[string, int](opts...)
+			if name == "unbounded replica" {
+				replica = New[string, int]()
+			}
+			var seq uint64
+			// sync applies the changes since the last sync to the replica,
+			// one at a time
+			sync := func() {
+				t.Helper()
+				changes, err := m.Journal(seq)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				for _, c := range changes {
+					if err := replica.Replay([]Change[string, int]{c}); err != nil {
+						t.Fatalf("unexpected error: %v", err)
+					}
+				}
+				seq = m.JournalSeq()
+				checkAll(t, replica, m.Items())
+			}
+
+			m.Set("a", 1)
+			m.Set("b", 2)
+			m.Set("c", 3)
+			changes, _ := m.Journal(0)
+			if op := changes[len(changes)-1].Edit.Op; op != EditEvict {
+				t.Fatalf("unexpected operation: want: %v, got %v", EditEvict, op)
+			}
+			sync()
+			m.Set("d", 4)
+			m.PushFront("e", 5)
+			sync()
+		})
+	}
+}
+
+func TestReplayNonConsecutive(t *testing.T) {
+	m := New[int, int]()
+	err := m.Replay([]Change[int, int]{
+		{Seq: 1, Edit: Edit[int, int]{Op: EditPushBack, Key: 1, Value: 1}},
+		{Seq: 3, Edit: Edit[int, int]{Op: EditPushBack, Key: 2, Value: 2}},
+	})
+	if !errors.Is(err, ErrInvalidRange) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrInvalidRange, err)
+	}
+	checkAll(t, m, []Item[int, int]{})
+}
+
+func TestWithJournalInvalidSize(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	WithJournal(0)
+}
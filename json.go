@@ -0,0 +1,133 @@
+package orderedmap
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// The map is encoded as a JSON object whose members are written in order.
+// Keys must either implement encoding.TextMarshaler or be strings, booleans,
+// integers or floating point numbers. Values are encoded with encoding/json.
+func (m *OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	if m.m != nil {
+		for el := m.l.Front(); el != nil; el = el.Next() {
+			if el != m.l.Front() {
+				buf.WriteByte(',')
+			}
+			key, err := formatText(el.Value.Key)
+			if err != nil {
+				return nil, fmt.Errorf("cannot encode key %v: %w", el.Value.Key, err)
+			}
+			b, err := json.Marshal(key)
+			if err != nil {
+				return nil, err
+			}
+			buf.Write(b)
+			buf.WriteByte(':')
+			if b, err = json.Marshal(el.Value.Value); err != nil {
+				return nil, fmt.Errorf("cannot encode value of key %v: %w", el.Value.Key, err)
+			}
+			buf.Write(b)
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// It replaces the content of the map with the members of the JSON object data,
+// in the order in which they appear. If V is the empty interface, nested objects
+// are decoded as *OrderedMap[string, any] so that their order is preserved too.
+// If a key appears more than once, it returns an error wrapping
+// ErrKeyAlreadyPresent.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	m.lazyInit()
+	m.Clear()
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("cannot decode %v into an ordered map", tok)
+	}
+	return m.decodeJSONObject(dec)
+}
+
+// decodeJSONObject decodes the members of a JSON object whose opening
+// delimiter has already been consumed.
+func (m *OrderedMap[K, V]) decodeJSONObject(dec *json.Decoder) error {
+	t := reflect.TypeOf((*V)(nil)).Elem()
+	anyValue := t.Kind() == reflect.Interface && t.NumMethod() == 0
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		k := tok.(string)
+		var key K
+		if err := parseText(k, &key); err != nil {
+			return fmt.Errorf("cannot decode key %q: %w", k, err)
+		}
+		var value V
+		if anyValue {
+			v, err := decodeJSONValue(dec)
+			if err != nil {
+				return err
+			}
+			if v != nil {
+				value, _ = v.(V)
+			}
+		} else if err := dec.Decode(&value); err != nil {
+			return err
+		}
+		if err := m.PushBack(key, value); err != nil {
+			return fmt.Errorf("cannot decode key %q: %w", k, err)
+		}
+	}
+	// consume closing delimiter
+	_, err := dec.Token()
+	return err
+}
+
+// decodeJSONValue decodes the next JSON value decoding objects
+// as *OrderedMap[string, any] instead of map[string]any.
+func decodeJSONValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		m := New[string, any]()
+		if err := m.decodeJSONObject(dec); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case json.Delim('['):
+		s := []any{}
+		for dec.More() {
+			v, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, v)
+		}
+		// consume closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return s, nil
+	}
+	return tok, nil
+}
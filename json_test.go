@@ -0,0 +1,180 @@
+package orderedmap
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[string, any]
+		want  string
+	}{
+		{
+			name:  "empty",
+			items: []Item[string, any]{},
+			want:  `{}`,
+		},
+		{
+			name:  "multiple items",
+			items: []Item[string, any]{{"b", 2}, {"a", "one"}, {"c", []int{3}}, {"d", nil}},
+			want:  `{"b":2,"a":"one","c":[3],"d":null}`,
+		},
+		{
+			name:  "escaped keys",
+			items: []Item[string, any]{{`"quoted"`, true}},
+			want:  `{"\"quoted\"":true}`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			got, err := json.Marshal(m)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != c.want {
+				t.Fatalf("unexpected JSON: want: %s, got %s", c.want, got)
+			}
+		})
+	}
+}
+
+func TestMarshalJSONNonStringKeys(t *testing.T) {
+	m := newFromItems(t, []Item[int, string]{{2, "two"}, {1, "one"}})
+	got, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"2":"two","1":"one"}`; string(got) != want {
+		t.Fatalf("unexpected JSON: want: %s, got %s", want, got)
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		name  string
+		data  string
+		items []Item[string, int]
+		want  []Item[string, int]
+		err   error
+	}{
+		{
+			name: "null",
+			data: `null`,
+			want: []Item[string, int]{},
+		},
+		{
+			name: "empty",
+			data: `{}`,
+			want: []Item[string, int]{},
+		},
+		{
+			name: "multiple items",
+			data: `{"b": 2, "a": 1, "c": 3}`,
+			want: []Item[string, int]{{"b", 2}, {"a", 1}, {"c", 3}},
+		},
+		{
+			name:  "replace existing content",
+			data:  `{"a": 1}`,
+			items: []Item[string, int]{{"z", 26}},
+			want:  []Item[string, int]{{"a", 1}},
+		},
+		{
+			name: "duplicate key",
+			data: `{"a": 1, "a": 2}`,
+			want: []Item[string, int]{{"a", 1}},
+			err:  ErrKeyAlreadyPresent,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := json.Unmarshal([]byte(c.data), m); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestUnmarshalJSONInvalid(t *testing.T) {
+	cases := []struct {
+		name string
+		data string
+	}{
+		{"array", `[1, 2]`},
+		{"wrong value type", `{"a": "one"}`},
+		{"invalid key", `{"one": 1}`},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := New[int, int]()
+			if err := json.Unmarshal([]byte(c.data), m); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONNested(t *testing.T) {
+	data := `{"z": {"y": 1, "x": [true, {"b": null, "a": "s"}]}, "a": 2}`
+	var m OrderedMap[string, any]
+	if err := json.Unmarshal([]byte(data), &m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"z", "a"}, m.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+	z, _ := m.Get("z")
+	inner, ok := z.(*OrderedMap[string, any])
+	if !ok {
+		t.Fatalf("unexpected type %T", z)
+	}
+	if diff := cmp.Diff([]string{"y", "x"}, inner.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+	x, _ := inner.Get("x")
+	arr, ok := x.([]any)
+	if !ok || len(arr) != 2 {
+		t.Fatalf("unexpected value %v", x)
+	}
+	innermost, ok := arr[1].(*OrderedMap[string, any])
+	if !ok {
+		t.Fatalf("unexpected type %T", arr[1])
+	}
+	checkAll(t, innermost, []Item[string, any]{{"b", nil}, {"a", "s"}})
+
+	// encoding the decoded map yields the original document
+	got, err := json.Marshal(&m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"z":{"y":1,"x":[true,{"b":null,"a":"s"}]},"a":2}`; string(got) != want {
+		t.Fatalf("unexpected JSON: want: %s, got %s", want, got)
+	}
+}
+
+func TestJSONStructField(t *testing.T) {
+	type config struct {
+		Name    string
+		Options *OrderedMap[string, string]
+	}
+	data := `{"Name":"x","Options":{"zeta":"z","alpha":"a"}}`
+	var c config
+	if err := json.Unmarshal([]byte(data), &c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, c.Options, []Item[string, string]{{"zeta", "z"}, {"alpha", "a"}})
+	got, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != data {
+		t.Fatalf("unexpected JSON: want: %s, got %s", data, got)
+	}
+}
@@ -0,0 +1,74 @@
+package orderedmap
+
+import "fmt"
+
+// Key2 is a composite key made of two comparable values, which can be used
+// as the key of an ordered map indexed by more than one field, for example
+// by tenant and name.
+type Key2[A, B comparable] struct {
+	a A
+	b B
+}
+
+// NewKey2 returns the composite key made of a and b.
+func NewKey2[A, B comparable](a A, b B) Key2[A, B] {
+	return Key2[A, B]{a, b}
+}
+
+// First returns the first value of the key.
+func (k Key2[A, B]) First() A {
+	return k.a
+}
+
+// Second returns the second value of the key.
+func (k Key2[A, B]) Second() B {
+	return k.b
+}
+
+// Values returns the values of the key.
+func (k Key2[A, B]) Values() (A, B) {
+	return k.a, k.b
+}
+
+// String returns the values of the key formatted as "(a, b)".
+func (k Key2[A, B]) String() string {
+	return fmt.Sprintf("(%v, %v)", k.a, k.b)
+}
+
+// Key3 is a composite key made of three comparable values, which can be used
+// as the key of an ordered map indexed by more than one field.
+type Key3[A, B, C comparable] struct {
+	a A
+	b B
+	c C
+}
+
+// NewKey3 returns the composite key made of a, b and c.
+func NewKey3[A, B, C comparable](a A, b B, c C) Key3[A, B, C] {
+	return Key3[A, B, C]{a, b, c}
+}
+
+// First returns the first value of the key.
+func (k Key3[A, B, C]) First() A {
+	return k.a
+}
+
+// Second returns the second value of the key.
+func (k Key3[A, B, C]) Second() B {
+	return k.b
+}
+
+// Third returns the third value of the key.
+func (k Key3[A, B, C]) Third() C {
+	return k.c
+}
+
+// Values returns the values of the key.
+func (k Key3[A, B, C]) Values() (A, B, C) {
+	return k.a, k.b, k.c
+}
+
+// String returns the values of the key formatted as "(a, b, c)".
+func (k Key3[A, B, C]) String() string {
+	return fmt.Sprintf("(%v, %v, %v)", k.a, k.b, k.c)
+}
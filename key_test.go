@@ -0,0 +1,57 @@
+package orderedmap
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestKey2(t *testing.T) {
+	m := New[Key2[string, int], string]()
+	m.Set(NewKey2("tenant", 1), "a")
+	m.Set(NewKey2("tenant", 2), "b")
+	m.Set(NewKey2("other", 1), "c")
+	if replaced := m.Set(NewKey2("tenant", 1), "d"); !replaced {
+		t.Fatal("expected value to be replaced")
+	}
+	if m.Len() != 3 {
+		t.Fatalf("unexpected length: %d", m.Len())
+	}
+	if v, ok := m.Get(NewKey2("other", 1)); !ok || v != "c" {
+		t.Fatalf("unexpected value: %v, %v", v, ok)
+	}
+
+	k := NewKey2("tenant", 2)
+	if k.First() != "tenant" || k.Second() != 2 {
+		t.Fatalf("unexpected values: %v, %v", k.First(), k.Second())
+	}
+	if a, b := k.Values(); a != "tenant" || b != 2 {
+		t.Fatalf("unexpected values: %v, %v", a, b)
+	}
+	if s := fmt.Sprint(k); s != "(tenant, 2)" {
+		t.Fatalf("unexpected string: %s", s)
+	}
+}
+
+func TestKey3(t *testing.T) {
+	m := New[Key3[string, string, int], string]()
+	m.Set(NewKey3("tenant", "name", 1), "a")
+	m.Set(NewKey3("tenant", "name", 2), "b")
+	m.Set(NewKey3("tenant", "other", 1), "c")
+	if replaced := m.Set(NewKey3("tenant", "name", 1), "d"); !replaced {
+		t.Fatal("expected value to be replaced")
+	}
+	if m.Len() != 3 {
+		t.Fatalf("unexpected length: %d", m.Len())
+	}
+
+	k := NewKey3("tenant", "name", 2)
+	if k.First() != "tenant" || k.Second() != "name" || k.Third() != 2 {
+		t.Fatalf("unexpected values: %v, %v, %v", k.First(), k.Second(), k.Third())
+	}
+	if a, b, c := k.Values(); a != "tenant" || b != "name" || c != 2 {
+		t.Fatalf("unexpected values: %v, %v, %v", a, b, c)
+	}
+	if s := fmt.Sprint(k); s != "(tenant, name, 2)" {
+		t.Fatalf("unexpected string: %s", s)
+	}
+}
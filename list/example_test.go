@@ -0,0 +1,46 @@
+// Copyright 2013 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package list_test
+
+import (
+	"fmt"
+
+	"github.com/lorenzosaino/go-orderedmap/list"
+)
+
+func Example() {
+	// Create a new list and put some numbers in it.
+	l := list.New[int]()
+	e4 := l.PushBack(4)
+	e1 := l.PushFront(1)
+	l.InsertBefore(3, e4)
+	l.InsertAfter(2, e1)
+
+	// Iterate through list and print its contents.
+	for e := l.Front(); e != nil; e = e.Next() {
+		fmt.Println(e.Value)
+	}
+
+	// Output:
+	// 1
+	// 2
+	// 3
+	// 4
+}
+
+func ExampleList_Sort() {
+	l := list.New[string]()
+	l.PushBack("c")
+	l.PushBack("a")
+	l.PushBack("b")
+	l.Sort(func(a, b string) bool { return a < b })
+	for e := l.Front(); e != nil; e = e.Next() {
+		fmt.Print(e.Value)
+	}
+	fmt.Println()
+
+	// Output:
+	// abc
+}
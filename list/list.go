@@ -2,7 +2,14 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-// Package list implements a doubly linked list.
+// Package list implements a generic doubly linked list.
+//
+// It is a fork of the container/list package of the standard library with
+// support for generics, so that values are stored with their type rather
+// than as interface values, and it is used by the ordered maps of the
+// github.com/lorenzosaino/go-orderedmap module. Its API matches the one of
+// container/list, with the addition of Element.List, InsertElementBefore and
+// Sort, and is stable.
 //
 // To iterate over a list (where l is a *List):
 //
@@ -43,6 +50,11 @@ func (e *Element[V]) Prev() *Element[V] {
 	return nil
 }
 
+// List returns the list e belongs to, or nil if e has been removed.
+func (e *Element[V]) List() *List[V] {
+	return e.list
+}
+
 // List represents a doubly linked list.
 // The zero value for List is an empty list ready to use.
 type List[V any] struct {
@@ -163,6 +175,26 @@ func (l *List[V]) InsertBefore(v V, mark *Element[V]) *Element[V] {
 	return l.insertValue(v, mark.prev)
 }
 
+// InsertElementBefore inserts e immediately before mark, or at the back of l
+// if mark is nil, and returns e. It allows elements removed from a list to be
+// reused, for example through a sync.Pool, without allocating new ones.
+// If e is an element of a list, or mark is not nil and not an element of l,
+// the list is not modified and nil is returned.
+// The element must not be nil.
+func (l *List[V]) InsertElementBefore(e, mark *Element[V]) *Element[V] {
+	if e.list != nil {
+		return nil
+	}
+	if mark == nil {
+		l.lazyInit()
+		return l.insert(e, l.root.prev)
+	}
+	if mark.list != l {
+		return nil
+	}
+	return l.insert(e, mark.prev)
+}
+
 // InsertAfter inserts a new element e with value v immediately after mark and returns e.
 // If mark is not an element of l, the list is not modified.
 // The mark must not be nil.
@@ -233,3 +265,80 @@ func (l *List[V]) PushFrontList(other *List[V]) {
 		l.insertValue(e.Value, &l.root)
 	}
 }
+
+// Sort sorts the elements of l in place according to less, which must
+// define a strict weak ordering. The sort is stable and runs in O(n log n)
+// time. Elements are relinked rather than copied, so that references to
+// them remain valid.
+func (l *List[V]) Sort(less func(a, b V) bool) {
+	if l.len < 2 {
+		return
+	}
+	// detach the elements into a nil-terminated singly-linked chain
+	// and merge runs of doubling size bottom-up
+	head := l.root.next
+	l.root.prev.next = nil
+	for size := 1; size < l.len; size *= 2 {
+		var newHead, tail *Element[V]
+		for p := head; p != nil; {
+			a := p
+			b := split(a, size)
+			p = split(b, size)
+			merged, mergedTail := merge(a, b, less)
+			if tail == nil {
+				newHead = merged
+			} else {
+				tail.next = merged
+			}
+			tail = mergedTail
+		}
+		head = newHead
+	}
+	// restore prev pointers and the ring
+	prev := &l.root
+	for e := head; e != nil; e = e.next {
+		e.prev = prev
+		prev.next = e
+		prev = e
+	}
+	prev.next = &l.root
+	l.root.prev = prev
+}
+
+// split cuts the chain starting at e after n elements and returns
+// the head of the remaining chain.
+func split[V any](e *Element[V], n int) *Element[V] {
+	for ; e != nil && n > 1; n-- {
+		e = e.next
+	}
+	if e == nil {
+		return nil
+	}
+	rest := e.next
+	e.next = nil
+	return rest
+}
+
+// merge merges the sorted chains a and b, taking elements from a first
+// on ties, and returns the head and tail of the merged chain.
+func merge[V any](a, b *Element[V], less func(a, b V) bool) (head, tail *Element[V]) {
+	var sentinel Element[V]
+	tail = &sentinel
+	for a != nil && b != nil {
+		if less(b.Value, a.Value) {
+			tail.next, b = b, b.next
+		} else {
+			tail.next, a = a, a.next
+		}
+		tail = tail.next
+	}
+	if a != nil {
+		tail.next = a
+	} else {
+		tail.next = b
+	}
+	for tail.next != nil {
+		tail = tail.next
+	}
+	return sentinel.next, tail
+}
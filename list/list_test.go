@@ -285,6 +285,38 @@ func TestMove(t *testing.T) {
 	checkListPointers(t, l, []*Element[int]{e1, e3, e2, e4})
 }
 
+func TestSort(t *testing.T) {
+	type pair struct{ k, v int }
+	less := func(a, b pair) bool { return a.k < b.k }
+
+	l := New[pair]()
+	l.Sort(less)
+	checkListLen(t, l, 0)
+
+	e := l.PushBack(pair{1, 0})
+	l.Sort(less)
+	checkListPointers(t, l, []*Element[pair]{e})
+
+	for _, n := range []int{2, 3, 7, 8, 9, 100} {
+		l := New[pair]()
+		var es []*Element[pair]
+		for i := 0; i < n; i++ {
+			// few distinct keys in reverse order to exercise stability
+			es = append(es, l.PushBack(pair{(n - i) % 4, i}))
+		}
+		l.Sort(less)
+		want := make([]*Element[pair], 0, n)
+		for k := 0; k < 4; k++ {
+			for _, e := range es {
+				if e.Value.k == k {
+					want = append(want, e)
+				}
+			}
+		}
+		checkListPointers(t, l, want)
+	}
+}
+
 // Test PushFront, PushBack, PushFrontList, PushBackList with uninitialized List
 func TestZeroList(t *testing.T) {
 	var l1 = new(List[int])
@@ -340,3 +372,41 @@ func TestMoveUnknownMark(t *testing.T) {
 	checkList(t, &l1, []int{1})
 	checkList(t, &l2, []int{2})
 }
+
+func TestInsertElementBefore(t *testing.T) {
+	var l List[int]
+	e1 := l.InsertElementBefore(&Element[int]{Value: 1}, nil)
+	e3 := l.InsertElementBefore(&Element[int]{Value: 3}, nil)
+	e2 := l.InsertElementBefore(&Element[int]{Value: 2}, e3)
+	checkListPointers(t, &l, []*Element[int]{e1, e2, e3})
+
+	// removed elements can be reused
+	l.Remove(e1)
+	e1.Value = 4
+	if e := l.InsertElementBefore(e1, e2); e != e1 {
+		t.Errorf("InsertElementBefore = %p, want %p", e, e1)
+	}
+	checkListPointers(t, &l, []*Element[int]{e1, e2, e3})
+	checkList(t, &l, []int{4, 2, 3})
+}
+
+// Test that a list l is not modified when calling InsertElementBefore with an element of a list or a mark that is not an element of l.
+func TestInsertElementBeforeUnknown(t *testing.T) {
+	var l1 List[int]
+	e1 := l1.PushBack(1)
+
+	var l2 List[int]
+	e2 := l2.PushBack(2)
+
+	if e := l1.InsertElementBefore(e2, nil); e != nil {
+		t.Errorf("InsertElementBefore = %p, want nil", e)
+	}
+	if e := l1.InsertElementBefore(e1, nil); e != nil {
+		t.Errorf("InsertElementBefore = %p, want nil", e)
+	}
+	if e := l1.InsertElementBefore(new(Element[int]), e2); e != nil {
+		t.Errorf("InsertElementBefore = %p, want nil", e)
+	}
+	checkList(t, &l1, []int{1})
+	checkList(t, &l2, []int{2})
+}
@@ -0,0 +1,105 @@
+package orderedmap
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// WithLoader configures the map to load the values of missing keys with
+// loader when accessed with Get or GetOrLoad, making it usable as a
+// read-through cache. Loaded values are inserted at the back of the map.
+//
+// Get and GetOrLoad may then be called concurrently with each other, but not
+// with any other method modifying the map. The loader is invoked without
+// holding any lock and at most once at a time for each key: callers missing a
+// key which is already being loaded wait for the load in progress and share
+// its result, while keys not being loaded are loaded concurrently. The loader
+// must therefore not access the map itself.
+//
+// The key and value types of loader must match those of the map, or New
+// panics.
+func WithLoader[K comparable, V any](loader func(ctx context.Context, key K) (V, error)) Option {
+	return func(o *options) {
+		o.loader = loader
+	}
+}
+
+// errLoaderPanicked is the error returned to the callers sharing a load whose
+// loader panicked.
+var errLoaderPanicked = errors.New("loader panicked")
+
+// loadGroup serializes the accesses to a map with a loader and tracks the
+// loads in progress, so that each key is loaded once at a time.
+type loadGroup[K comparable, V any] struct {
+	mu    sync.Mutex
+	calls map[K]*loadCall[V]
+}
+
+// loadCall is a load in progress, whose result is set before done is closed.
+type loadCall[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+func newLoadGroup[K comparable, V any]() *loadGroup[K, V] {
+	return &loadGroup[K, V]{calls: make(map[K]*loadCall[V])}
+}
+
+// GetOrLoad returns the value associated to a key in the map.
+//
+// If the key is not present, the loader configured with WithLoader is invoked
+// to load its value, which is inserted at the back of the map, unless frozen,
+// and returned. If the loader returns an error, the map is not modified and
+// the error is returned to all callers sharing the load. If ctx is done while
+// waiting for a load started by another caller, ctx.Err() is returned. If no
+// loader is configured, it returns ErrKeyMissing.
+func (m *OrderedMap[K, V]) GetOrLoad(ctx context.Context, key K) (V, error) {
+	key = m.normalize(key)
+	g := m.loads
+	if g == nil {
+		if value, ok := m.get(key); ok {
+			return value, nil
+		}
+		var zero V
+		return zero, ErrKeyMissing
+	}
+	g.mu.Lock()
+	if value, ok := m.get(key); ok {
+		g.mu.Unlock()
+		return value, nil
+	}
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-c.done:
+			return c.value, c.err
+		case <-ctx.Done():
+			var zero V
+			return zero, ctx.Err()
+		}
+	}
+	c := &loadCall[V]{done: make(chan struct{})}
+	g.calls[key] = c
+	g.mu.Unlock()
+	m.load(ctx, key, c)
+	return c.value, c.err
+}
+
+// load invokes the loader for a key and inserts the value loaded, completing
+// c even if the loader panics.
+func (m *OrderedMap[K, V]) load(ctx context.Context, key K, c *loadCall[V]) {
+	g := m.loads
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		if c.err == nil && !m.frozen {
+			m.Set(key, c.value)
+		}
+		g.mu.Unlock()
+		close(c.done)
+	}()
+	c.err = errLoaderPanicked
+	c.value, c.err = m.loader(ctx, key)
+}
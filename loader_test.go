@@ -0,0 +1,244 @@
+package orderedmap
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetOrLoad(t *testing.T) {
+	errLoad := errors.New("load failed")
+	loads := 0
+	m := New[int, string](WithLoader(func(ctx context.Context, key int) (string, error) {
+		loads++
+		if key < 0 {
+			return "", errLoad
+		}
+		return strconv.Itoa(key), nil
+	}))
+	m.PushBack(1, "one")
+
+	cases := []struct {
+		name  string
+		key   int
+		value string
+		err   error
+		loads int
+		want  []Item[int, string]
+	}{
+		{
+			name:  "hit",
+			key:   1,
+			value: "one",
+			want:  []Item[int, string]{{1, "one"}},
+		},
+		{
+			name:  "miss",
+			key:   2,
+			value: "2",
+			loads: 1,
+			want:  []Item[int, string]{{1, "one"}, {2, "2"}},
+		},
+		{
+			name:  "loaded",
+			key:   2,
+			value: "2",
+			loads: 1,
+			want:  []Item[int, string]{{1, "one"}, {2, "2"}},
+		},
+		{
+			name:  "error",
+			key:   -1,
+			err:   errLoad,
+			loads: 2,
+			want:  []Item[int, string]{{1, "one"}, {2, "2"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			value, err := m.GetOrLoad(context.Background(), c.key)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			if value != c.value {
+				t.Fatalf("unexpected value: want: %v, got %v", c.value, value)
+			}
+			if loads != c.loads {
+				t.Fatalf("unexpected number of loads: want: %d, got %d", c.loads, loads)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestGetOrLoadWithoutLoader(t *testing.T) {
+	m := newFromItems(t, []Item[int, string]{{1, "one"}})
+	if _, err := m.GetOrLoad(context.Background(), 2); !errors.Is(err, ErrKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrKeyMissing, err)
+	}
+	checkAll(t, m, []Item[int, string]{{1, "one"}})
+}
+
+func TestGetOrLoadContext(t *testing.T) {
+	m := New[int, string](WithLoader(func(ctx context.Context, key int) (string, error) {
+		return "", ctx.Err()
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := m.GetOrLoad(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: want: %v, got %v", context.Canceled, err)
+	}
+	checkAll(t, m, []Item[int, string]{})
+}
+
+func TestGetLoads(t *testing.T) {
+	errLoad := errors.New("load failed")
+	m := New[int, string](WithLoader(func(ctx context.Context, key int) (string, error) {
+		if key < 0 {
+			return "", errLoad
+		}
+		return strconv.Itoa(key), nil
+	}))
+	if value, ok := m.Get(1); !ok || value != "1" {
+		t.Fatalf("unexpected value: want: 1 (true), got %q (%v)", value, ok)
+	}
+	if _, ok := m.Get(-1); ok {
+		t.Fatal("unexpected value of key failing to load")
+	}
+	checkAll(t, m, []Item[int, string]{{1, "1"}})
+}
+
+func TestGetOrLoadSingleFlight(t *testing.T) {
+	const callers = 10
+	var loads int32
+	release := make(chan struct{})
+	m := New[int, string](WithLoader(func(ctx context.Context, key int) (string, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		return strconv.Itoa(key), nil
+	}))
+	var wg sync.WaitGroup
+	values := make([]string, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			value, err := m.GetOrLoad(context.Background(), 1)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			values[i] = value
+		}(i)
+	}
+	// wait for all callers to wait for the load in progress
+	for {
+		m.loads.mu.Lock()
+		c := m.loads.calls[1]
+		m.loads.mu.Unlock()
+		if c != nil && atomic.LoadInt32(&loads) == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if n := atomic.LoadInt32(&loads); n != 1 {
+		t.Fatalf("unexpected number of loads: want: 1, got %d", n)
+	}
+	for _, value := range values {
+		if value != "1" {
+			t.Fatalf("unexpected value: want: 1, got %q", value)
+		}
+	}
+	checkAll(t, m, []Item[int, string]{{1, "1"}})
+}
+
+func TestGetOrLoadConcurrentKeys(t *testing.T) {
+	// the load of each key completes only once the other one has started
+	started := map[int]chan struct{}{1: make(chan struct{}), 2: make(chan struct{})}
+	m := New[int, string](WithLoader(func(ctx context.Context, key int) (string, error) {
+		close(started[key])
+		select {
+		case <-started[3-key]:
+		case <-time.After(10 * time.Second):
+			return "", errors.New("loads serialized")
+		}
+		return strconv.Itoa(key), nil
+	}))
+	var wg sync.WaitGroup
+	for key := 1; key <= 2; key++ {
+		wg.Add(1)
+		go func(key int) {
+			defer wg.Done()
+			if _, err := m.GetOrLoad(context.Background(), key); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(key)
+	}
+	wg.Wait()
+	if n := m.Len(); n != 2 {
+		t.Fatalf("unexpected length: want: 2, got %d", n)
+	}
+}
+
+func TestGetOrLoadWaitContext(t *testing.T) {
+	release := make(chan struct{})
+	m := New[int, string](WithLoader(func(ctx context.Context, key int) (string, error) {
+		<-release
+		return strconv.Itoa(key), nil
+	}))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.GetOrLoad(context.Background(), 1)
+	}()
+	for {
+		m.loads.mu.Lock()
+		c := m.loads.calls[1]
+		m.loads.mu.Unlock()
+		if c != nil {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := m.GetOrLoad(ctx, 1); !errors.Is(err, context.Canceled) {
+		t.Fatalf("unexpected error: want: %v, got %v", context.Canceled, err)
+	}
+	close(release)
+	<-done
+	checkAll(t, m, []Item[int, string]{{1, "1"}})
+}
+
+func TestGetOrLoadPanic(t *testing.T) {
+	m := New[int, string](WithLoader(func(ctx context.Context, key int) (string, error) {
+		panic("load failed")
+	}))
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected panic")
+			}
+		}()
+		m.GetOrLoad(context.Background(), 1)
+	}()
+	if n := len(m.loads.calls); n != 0 {
+		t.Fatalf("unexpected loads in progress: %d", n)
+	}
+	checkAll(t, m, []Item[int, string]{})
+}
+
+func TestWithLoaderInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	New[string, string](WithLoader(func(ctx context.Context, key int) (string, error) { return "", nil }))
+}
@@ -0,0 +1,64 @@
+package orderedmap
+
+import (
+	"fmt"
+
+	"github.com/lorenzosaino/go-orderedmap/internal/msgpack"
+)
+
+// MarshalMsgpack implements the Marshaler interface of
+// github.com/vmihailenco/msgpack.
+//
+// The map is encoded as a MessagePack map whose entries are written in order.
+// Keys and values can be nil, booleans, numbers, strings, byte slices, slices,
+// arrays, maps, structs, pointers to any of these or types implementing
+// MarshalMsgpack themselves.
+func (m *OrderedMap[K, V]) MarshalMsgpack() ([]byte, error) {
+	if m.m == nil {
+		return msgpack.AppendMapHeader(nil, 0), nil
+	}
+	b := msgpack.AppendMapHeader(nil, m.Len())
+	for el := m.l.Front(); el != nil; el = el.Next() {
+		var err error
+		if b, err = msgpack.Append(b, el.Value.Key); err != nil {
+			return nil, fmt.Errorf("cannot encode key %v: %w", el.Value.Key, err)
+		}
+		if b, err = msgpack.Append(b, el.Value.Value); err != nil {
+			return nil, fmt.Errorf("cannot encode value of key %v: %w", el.Value.Key, err)
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalMsgpack implements the Unmarshaler interface of
+// github.com/vmihailenco/msgpack.
+//
+// It replaces the content of the map with the entries of the MessagePack map
+// encoded in data, in the order in which they are encoded. If a key is
+// encoded more than once, it returns an error wrapping ErrKeyAlreadyPresent.
+func (m *OrderedMap[K, V]) UnmarshalMsgpack(data []byte) error {
+	m.lazyInit()
+	m.Clear()
+	d := msgpack.NewDecoder(data)
+	if d.IsNil() {
+		return nil
+	}
+	n, err := d.ReadMapHeader()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		var key K
+		if err := d.Decode(&key); err != nil {
+			return err
+		}
+		var value V
+		if err := d.Decode(&value); err != nil {
+			return err
+		}
+		if err := m.PushBack(key, value); err != nil {
+			return fmt.Errorf("cannot decode key %v: %w", key, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package orderedmap
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMarshalMsgpack(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[string, int]
+		want  []byte
+	}{
+		{
+			name:  "empty",
+			items: []Item[string, int]{},
+			want:  []byte{0x80},
+		},
+		{
+			name:  "multiple items",
+			items: []Item[string, int]{{"b", 2}, {"a", 1}},
+			want:  []byte{0x82, 0xa1, 'b', 0x02, 0xa1, 'a', 0x01},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			got, err := m.MarshalMsgpack()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, c.want) {
+				t.Fatalf("unexpected encoding: want: %x, got %x", c.want, got)
+			}
+		})
+	}
+}
+
+func TestMarshalMsgpackUnsupportedValue(t *testing.T) {
+	m := newFromItems(t, []Item[string, func()]{{"a", func() {}}})
+	if _, err := m.MarshalMsgpack(); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestUnmarshalMsgpack(t *testing.T) {
+	cases := []struct {
+		name  string
+		data  []byte
+		items []Item[string, int]
+		want  []Item[string, int]
+		err   error
+	}{
+		{
+			name: "nil",
+			data: []byte{0xc0},
+			want: []Item[string, int]{},
+		},
+		{
+			name: "multiple items",
+			data: []byte{0x82, 0xa1, 'b', 0x02, 0xa1, 'a', 0x01},
+			want: []Item[string, int]{{"b", 2}, {"a", 1}},
+		},
+		{
+			name:  "replace existing content",
+			data:  []byte{0x81, 0xa1, 'a', 0x01},
+			items: []Item[string, int]{{"z", 26}},
+			want:  []Item[string, int]{{"a", 1}},
+		},
+		{
+			name: "duplicate key",
+			data: []byte{0x82, 0xa1, 'a', 0x01, 0xa1, 'a', 0x02},
+			want: []Item[string, int]{{"a", 1}},
+			err:  ErrKeyAlreadyPresent,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := m.UnmarshalMsgpack(c.data); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestUnmarshalMsgpackInvalid(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{"not a map", []byte{0x91, 0x01}},
+		{"truncated", []byte{0x82, 0xa1, 'a', 0x01}},
+		{"wrong value type", []byte{0x81, 0xa1, 'a', 0xa1, 'b'}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := New[string, int]()
+			if err := m.UnmarshalMsgpack(c.data); err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
+
+func TestMsgpackRoundTrip(t *testing.T) {
+	type value struct {
+		Name string
+		Tags []string
+	}
+	items := []Item[int, value]{{3, value{"c", []string{"x"}}}, {1, value{"a", nil}}, {2, value{"b", []string{}}}}
+	m := newFromItems(t, items)
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var out OrderedMap[int, value]
+	if err := out.UnmarshalMsgpack(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(items, out.Items()); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+}
+
+func TestMsgpackNested(t *testing.T) {
+	inner := newFromItems(t, []Item[string, any]{{"y", int64(2)}, {"x", int64(1)}})
+	m := newFromItems(t, []Item[string, *OrderedMap[string, any]]{{"inner", inner}, {"empty", nil}})
+	data, err := m.MarshalMsgpack()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := New[string, *OrderedMap[string, any]]()
+	if err := out.UnmarshalMsgpack(data); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff([]string{"inner", "empty"}, out.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+	got, _ := out.Get("inner")
+	checkAll(t, got, []Item[string, any]{{"y", int64(2)}, {"x", int64(1)}})
+	if got, _ := out.Get("empty"); got != nil {
+		t.Fatalf("unexpected value: %v", got)
+	}
+}
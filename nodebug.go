@@ -0,0 +1,7 @@
+//go:build !orderedmap_debug
+
+package orderedmap
+
+// debug enables additional consistency checks, at the cost of performance.
+// It is set by building with the orderedmap_debug build tag.
+const debug = false
@@ -0,0 +1,7 @@
+//go:build !race
+
+package orderedmap
+
+// raceEnabled is set if the tests are run with the race detector, which makes
+// sync.Pool randomly drop items.
+const raceEnabled = false
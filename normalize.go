@@ -0,0 +1,40 @@
+package orderedmap
+
+// WithKeyNormalizer configures the map to normalize keys with normalize
+// before using them in any operation, so that keys normalized to the same
+// value refer to the same item. For example, strings.ToLower makes string
+// keys case-insensitive, as HTTP header names are.
+//
+// Keys are stored in their normalized form, which is therefore the one
+// returned by methods such as Keys and Range. normalize must be idempotent,
+// that is normalizing a normalized key must return it unchanged. Copies of
+// the map, such as those returned by Clone, normalize keys in the same way.
+//
+// The key type of normalize must match the key type of the map, or New
+// panics.
+func WithKeyNormalizer[K comparable](normalize func(key K) K) Option {
+	return func(o *options) {
+		o.keyNormalizer = normalize
+	}
+}
+
+// normalize returns the normalized form of key.
+func (m *OrderedMap[K, V]) normalize(key K) K {
+	if m.normalizeKey == nil {
+		return key
+	}
+	return m.normalizeKey(key)
+}
+
+// normalizeItems returns items with their keys normalized, copying them only
+// if the map normalizes keys.
+func (m *OrderedMap[K, V]) normalizeItems(items []Item[K, V]) []Item[K, V] {
+	if m.normalizeKey == nil {
+		return items
+	}
+	out := make([]Item[K, V], len(items))
+	for i, item := range items {
+		out[i] = Item[K, V]{m.normalizeKey(item.Key), item.Value}
+	}
+	return out
+}
@@ -0,0 +1,87 @@
+package orderedmap
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithKeyNormalizer(t *testing.T) {
+	m := New[string, int](WithKeyNormalizer(strings.ToLower))
+	m.Set("Content-Type", 1)
+	if replaced := m.Set("content-type", 2); !replaced {
+		t.Fatal("expected value to be replaced")
+	}
+	if err := m.PushBack("ACCEPT", 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.PushFront("Accept", 4); !errors.Is(err, ErrKeyAlreadyPresent) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.PushBackItems(Item[string, int]{"Host", 5}, Item[string, int]{"HOST", 6}); !errors.Is(err, ErrKeyAlreadyPresent) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.InsertBefore("X-Id", 7, "Accept"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.MoveToFront("ACCEPT"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, []Item[string, int]{{"accept", 3}, {"content-type", 2}, {"x-id", 7}})
+
+	if v, ok := m.Get("CONTENT-TYPE"); !ok || v != 2 {
+		t.Fatalf("unexpected value: %v, %v", v, ok)
+	}
+	if !m.Has("X-ID") {
+		t.Fatal("expected key to be present")
+	}
+	if i, ok := m.IndexOf("X-Id"); !ok || i != 2 {
+		t.Fatalf("unexpected index: %v, %v", i, ok)
+	}
+	if err := m.ReplaceKey("X-ID", "x-id"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := m.DeleteKeys("Accept", "Missing"); n != 1 {
+		t.Fatalf("unexpected number of deleted items: %d", n)
+	}
+	if _, ok := m.Delete("Content-Type"); !ok {
+		t.Fatal("expected key to be deleted")
+	}
+	checkAll(t, m, []Item[string, int]{{"x-id", 7}})
+
+	clone := m.Clone()
+	clone.Set("X-Id", 8)
+	checkAll(t, clone, []Item[string, int]{{"x-id", 8}})
+}
+
+func TestWithKeyNormalizerApply(t *testing.T) {
+	m := New[string, int](WithKeyNormalizer(strings.ToLower))
+	m.Set("a", 1)
+	m.Set("b", 2)
+	err := m.Apply([]Edit[string, int]{
+		{Op: EditSet, Key: "A", Value: 3},
+		{Op: EditMoveAfter, Key: "A", Mark: "B"},
+		{Op: EditDelete, Key: "C"},
+	})
+	if !errors.Is(err, ErrKeyMissing) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, []Item[string, int]{{"a", 1}, {"b", 2}})
+
+	if err := m.Apply([]Edit[string, int]{
+		{Op: EditSet, Key: "A", Value: 3},
+		{Op: EditMoveAfter, Key: "A", Mark: "B"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, []Item[string, int]{{"b", 2}, {"a", 3}})
+}
+
+func TestWithKeyNormalizerInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	New[int, int](WithKeyNormalizer(strings.ToLower))
+}
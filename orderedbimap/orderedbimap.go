@@ -0,0 +1,261 @@
+// Package orderedbimap implements an ordered bidirectional map using generics.
+//
+// An ordered bidirectional map is an ordered map whose values are unique,
+// so that each key can be looked up by its value in O(1) as well. Items are
+// ordered as in an orderedmap.OrderedMap and can be accessed by either their
+// key or their value.
+//
+// This implementation is not safe for concurrent usage.
+package orderedbimap
+
+import (
+	"errors"
+
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+var (
+	// ErrValueMissing indicates that the value specified is not present in the bidirectional map
+	ErrValueMissing = errors.New("value missing")
+
+	// ErrValueAlreadyPresent indicates that value to be inserted is already present in the bidirectional map
+	ErrValueAlreadyPresent = errors.New("value already present")
+)
+
+// BiMap is an implementation of an ordered bidirectional map.
+//
+// K and V are respectively the types of keys and values.
+type BiMap[K, V comparable] struct {
+	m   *orderedmap.OrderedMap[K, V]
+	inv map[V]K
+}
+
+// New returns a new bidirectional map instance.
+func New[K, V comparable]() *BiMap[K, V] {
+	return &BiMap[K, V]{
+		m:   orderedmap.New[K, V](),
+		inv: make(map[V]K),
+	}
+}
+
+// Get returns the value associated to a key in the map.
+//
+// If the key is not present in the map, it returns the zero value of V
+// and ok is set to false.
+func (m *BiMap[K, V]) Get(key K) (value V, ok bool) {
+	return m.m.Get(key)
+}
+
+// GetByValue returns the key associated to a value in the map.
+//
+// If the value is not present in the map, it returns the zero value of K
+// and ok is set to false.
+func (m *BiMap[K, V]) GetByValue(value V) (key K, ok bool) {
+	key, ok = m.inv[value]
+	return key, ok
+}
+
+// Has reports whether a key is present in the map.
+func (m *BiMap[K, V]) Has(key K) bool {
+	return m.m.Has(key)
+}
+
+// HasValue reports whether a value is present in the map.
+func (m *BiMap[K, V]) HasValue(value V) bool {
+	_, ok := m.inv[value]
+	return ok
+}
+
+// Update updates the value associated to an existing key and returns the old value.
+//
+// If the key is not present, then orderedmap.ErrKeyMissing is returned.
+// If the value is already associated to another key, then
+// ErrValueAlreadyPresent is returned.
+func (m *BiMap[K, V]) Update(key K, value V) (oldValue V, err error) {
+	oldValue, ok := m.m.Get(key)
+	if !ok {
+		return oldValue, orderedmap.ErrKeyMissing
+	}
+	if k, ok := m.inv[value]; ok && k != key {
+		return oldValue, ErrValueAlreadyPresent
+	}
+	m.m.Update(key, value)
+	delete(m.inv, oldValue)
+	m.inv[value] = key
+	return oldValue, nil
+}
+
+// Front returns the item at the front of the map.
+//
+// If the map is empty, it returns the zero value of orderedmap.Item[K, V]
+// and ok is set to false.
+func (m *BiMap[K, V]) Front() (item orderedmap.Item[K, V], ok bool) {
+	return m.m.Front()
+}
+
+// Back returns the item at the back of the map.
+//
+// If the map is empty, it returns the zero value of orderedmap.Item[K, V]
+// and ok is set to false.
+func (m *BiMap[K, V]) Back() (item orderedmap.Item[K, V], ok bool) {
+	return m.m.Back()
+}
+
+// PushFront insert a new key and value at the front of the map.
+//
+// It returns orderedmap.ErrKeyAlreadyPresent if the key to be inserted is
+// already present and ErrValueAlreadyPresent if the value to be inserted
+// is already present.
+func (m *BiMap[K, V]) PushFront(key K, value V) error {
+	return m.insert(key, value, m.m.PushFront)
+}
+
+// PushBack insert a new key and value at the back of the map.
+//
+// It returns orderedmap.ErrKeyAlreadyPresent if the key to be inserted is
+// already present and ErrValueAlreadyPresent if the value to be inserted
+// is already present.
+func (m *BiMap[K, V]) PushBack(key K, value V) error {
+	return m.insert(key, value, m.m.PushBack)
+}
+
+// InsertAfter insert a new key and value immediately after a mark key.
+//
+// It returns orderedmap.ErrKeyAlreadyPresent if the key to be inserted is
+// already present, ErrValueAlreadyPresent if the value to be inserted is
+// already present and orderedmap.ErrMarkKeyMissing if the mark key is not
+// present.
+func (m *BiMap[K, V]) InsertAfter(key K, value V, mark K) error {
+	return m.insert(key, value, func(key K, value V) error {
+		return m.m.InsertAfter(key, value, mark)
+	})
+}
+
+// InsertBefore insert a new key and value immediately before a mark key.
+//
+// It returns orderedmap.ErrKeyAlreadyPresent if the key to be inserted is
+// already present, ErrValueAlreadyPresent if the value to be inserted is
+// already present and orderedmap.ErrMarkKeyMissing if the mark key is not
+// present.
+func (m *BiMap[K, V]) InsertBefore(key K, value V, mark K) error {
+	return m.insert(key, value, func(key K, value V) error {
+		return m.m.InsertBefore(key, value, mark)
+	})
+}
+
+// insert inserts a new key and value with the insertion function provided,
+// after validating that the value is not already present.
+func (m *BiMap[K, V]) insert(key K, value V, f func(key K, value V) error) error {
+	if m.m.Has(key) {
+		return orderedmap.ErrKeyAlreadyPresent
+	}
+	if _, ok := m.inv[value]; ok {
+		return ErrValueAlreadyPresent
+	}
+	if err := f(key, value); err != nil {
+		return err
+	}
+	m.inv[value] = key
+	return nil
+}
+
+// MoveToFront moves an existing key to the front of the map.
+//
+// If the key is not present, then orderedmap.ErrKeyMissing is returned.
+func (m *BiMap[K, V]) MoveToFront(key K) error {
+	return m.m.MoveToFront(key)
+}
+
+// MoveToBack moves an existing key to the back of the map.
+//
+// If the key is not present, then orderedmap.ErrKeyMissing is returned.
+func (m *BiMap[K, V]) MoveToBack(key K) error {
+	return m.m.MoveToBack(key)
+}
+
+// MoveAfter moves an existing key immediately after a mark key.
+//
+// If either the key or the mark key is not present, an error is returned.
+func (m *BiMap[K, V]) MoveAfter(key K, mark K) error {
+	return m.m.MoveAfter(key, mark)
+}
+
+// MoveBefore moves an existing key immediately before a mark key.
+//
+// If either the key or the mark key is not present, an error is returned.
+func (m *BiMap[K, V]) MoveBefore(key K, mark K) error {
+	return m.m.MoveBefore(key, mark)
+}
+
+// Delete deletes an item from a map and returns the value deleted.
+//
+// If the item to be deleted was already missing from the map, ok is set to false.
+func (m *BiMap[K, V]) Delete(key K) (value V, ok bool) {
+	value, ok = m.m.Delete(key)
+	if ok {
+		delete(m.inv, value)
+	}
+	return value, ok
+}
+
+// DeleteByValue deletes the item with a given value from a map and returns
+// the key deleted.
+//
+// If the item to be deleted was already missing from the map, ok is set to false.
+func (m *BiMap[K, V]) DeleteByValue(value V) (key K, ok bool) {
+	key, ok = m.inv[value]
+	if ok {
+		m.m.Delete(key)
+		delete(m.inv, value)
+	}
+	return key, ok
+}
+
+// Len returns the number of items in the map.
+func (m *BiMap[K, V]) Len() int {
+	return m.m.Len()
+}
+
+// Clear removes all items from the map.
+func (m *BiMap[K, V]) Clear() {
+	m.m.Clear()
+	m.inv = make(map[V]K)
+}
+
+// Next returns the item following the one with the key specified.
+//
+// If the key is missing or is the last key of the map, ok is set to false.
+func (m *BiMap[K, V]) Next(key K) (next orderedmap.Item[K, V], ok bool) {
+	return m.m.Next(key)
+}
+
+// Prev returns the item preceding the one with the key specified.
+//
+// If the key is missing or is the first key of the map, ok is set to false.
+func (m *BiMap[K, V]) Prev(key K) (prev orderedmap.Item[K, V], ok bool) {
+	return m.m.Prev(key)
+}
+
+// Range calls f sequentially for each key and value present in the map,
+// starting from the front element. If f returns false, range stops the
+// iteration.
+//
+// The map must not be modified while iterating it.
+func (m *BiMap[K, V]) Range(f func(key K, value V) bool) {
+	m.m.Range(f)
+}
+
+// Keys returns the ordered list of keys of the map.
+func (m *BiMap[K, V]) Keys() []K {
+	return m.m.Keys()
+}
+
+// Values returns the ordered list of values of the map.
+func (m *BiMap[K, V]) Values() []V {
+	return m.m.Values()
+}
+
+// Items returns the ordered list of items of the map.
+func (m *BiMap[K, V]) Items() []orderedmap.Item[K, V] {
+	return m.m.Items()
+}
@@ -0,0 +1,218 @@
+package orderedbimap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+func TestInsert(t *testing.T) {
+	cases := []struct {
+		name   string
+		items  []orderedmap.Item[string, int]
+		insert func(m *BiMap[string, int]) error
+		want   []orderedmap.Item[string, int]
+		err    error
+	}{
+		{
+			name:   "push front",
+			items:  []orderedmap.Item[string, int]{{Key: "b", Value: 2}},
+			insert: func(m *BiMap[string, int]) error { return m.PushFront("a", 1) },
+			want:   []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}},
+		},
+		{
+			name:   "push back",
+			items:  []orderedmap.Item[string, int]{{Key: "b", Value: 2}},
+			insert: func(m *BiMap[string, int]) error { return m.PushBack("a", 1) },
+			want:   []orderedmap.Item[string, int]{{Key: "b", Value: 2}, {Key: "a", Value: 1}},
+		},
+		{
+			name:   "insert after",
+			items:  []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "c", Value: 3}},
+			insert: func(m *BiMap[string, int]) error { return m.InsertAfter("b", 2, "a") },
+			want:   []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}},
+		},
+		{
+			name:   "insert before",
+			items:  []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "c", Value: 3}},
+			insert: func(m *BiMap[string, int]) error { return m.InsertBefore("b", 2, "a") },
+			want:   []orderedmap.Item[string, int]{{Key: "b", Value: 2}, {Key: "a", Value: 1}, {Key: "c", Value: 3}},
+		},
+		{
+			name:   "key already present",
+			items:  []orderedmap.Item[string, int]{{Key: "a", Value: 1}},
+			insert: func(m *BiMap[string, int]) error { return m.PushBack("a", 2) },
+			want:   []orderedmap.Item[string, int]{{Key: "a", Value: 1}},
+			err:    orderedmap.ErrKeyAlreadyPresent,
+		},
+		{
+			name:   "value already present",
+			items:  []orderedmap.Item[string, int]{{Key: "a", Value: 1}},
+			insert: func(m *BiMap[string, int]) error { return m.PushFront("b", 1) },
+			want:   []orderedmap.Item[string, int]{{Key: "a", Value: 1}},
+			err:    ErrValueAlreadyPresent,
+		},
+		{
+			name:   "mark key missing",
+			items:  []orderedmap.Item[string, int]{{Key: "a", Value: 1}},
+			insert: func(m *BiMap[string, int]) error { return m.InsertAfter("b", 2, "z") },
+			want:   []orderedmap.Item[string, int]{{Key: "a", Value: 1}},
+			err:    orderedmap.ErrMarkKeyMissing,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := c.insert(m); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := []struct {
+		name     string
+		items    []orderedmap.Item[string, int]
+		key      string
+		value    int
+		oldValue int
+		want     []orderedmap.Item[string, int]
+		err      error
+	}{
+		{
+			name:     "new value",
+			items:    []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}},
+			key:      "a",
+			value:    10,
+			oldValue: 1,
+			want:     []orderedmap.Item[string, int]{{Key: "a", Value: 10}, {Key: "b", Value: 2}},
+		},
+		{
+			name:     "same value",
+			items:    []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}},
+			key:      "a",
+			value:    1,
+			oldValue: 1,
+			want:     []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}},
+		},
+		{
+			name:  "key missing",
+			items: []orderedmap.Item[string, int]{{Key: "a", Value: 1}},
+			key:   "b",
+			value: 2,
+			want:  []orderedmap.Item[string, int]{{Key: "a", Value: 1}},
+			err:   orderedmap.ErrKeyMissing,
+		},
+		{
+			name:     "value of another key",
+			items:    []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}},
+			key:      "a",
+			value:    2,
+			oldValue: 1,
+			want:     []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}},
+			err:      ErrValueAlreadyPresent,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			oldValue, err := m.Update(c.key, c.value)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			if oldValue != c.oldValue {
+				t.Fatalf("unexpected old value: want: %v, got %v", c.oldValue, oldValue)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	m := newFromItems(t, []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}})
+	if value, ok := m.Delete("b"); !ok || value != 2 {
+		t.Fatalf("unexpected result: want: 2 (true), got %v (%v)", value, ok)
+	}
+	if _, ok := m.Delete("b"); ok {
+		t.Fatal("deleted missing key")
+	}
+	checkAll(t, m, []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "c", Value: 3}})
+
+	if key, ok := m.DeleteByValue(3); !ok || key != "c" {
+		t.Fatalf("unexpected result: want: c (true), got %v (%v)", key, ok)
+	}
+	if _, ok := m.DeleteByValue(3); ok {
+		t.Fatal("deleted missing value")
+	}
+	checkAll(t, m, []orderedmap.Item[string, int]{{Key: "a", Value: 1}})
+
+	// deleted values can be reused
+	if err := m.PushBack("d", 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "d", Value: 2}})
+
+	m.Clear()
+	checkAll(t, m, []orderedmap.Item[string, int]{})
+}
+
+func TestMove(t *testing.T) {
+	m := newFromItems(t, []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}, {Key: "d", Value: 4}})
+	if err := m.MoveToFront("c"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.MoveToBack("a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.MoveAfter("c", "d"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.MoveBefore("b", "a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.MoveToFront("z"); !errors.Is(err, orderedmap.ErrKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", orderedmap.ErrKeyMissing, err)
+	}
+	checkAll(t, m, []orderedmap.Item[string, int]{{Key: "d", Value: 4}, {Key: "c", Value: 3}, {Key: "b", Value: 2}, {Key: "a", Value: 1}})
+}
+
+func newFromItems[K, V comparable](t *testing.T, items []orderedmap.Item[K, V]) *BiMap[K, V] {
+	t.Helper()
+
+	m := New[K, V]()
+	for _, item := range items {
+		if err := m.PushBack(item.Key, item.Value); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return m
+}
+
+func checkAll[K, V comparable](t *testing.T, m *BiMap[K, V], items []orderedmap.Item[K, V]) {
+	t.Helper()
+
+	if want, got := len(items), m.Len(); want != got {
+		t.Fatalf("incorrect length: want: %d, got: %d", want, got)
+	}
+	if want, got := m.Len(), len(m.inv); want != got {
+		t.Fatalf("incorrect length: want: %d, got: %d", want, got)
+	}
+	if diff := cmp.Diff(items, m.Items()); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+	for _, item := range items {
+		if value, ok := m.Get(item.Key); !ok || value != item.Value {
+			t.Fatalf("unexpected value of key %v: want: %v, got %v (%v)", item.Key, item.Value, value, ok)
+		}
+		if key, ok := m.GetByValue(item.Value); !ok || key != item.Key {
+			t.Fatalf("unexpected key of value %v: want: %v, got %v (%v)", item.Value, item.Key, key, ok)
+		}
+		if !m.Has(item.Key) || !m.HasValue(item.Value) {
+			t.Fatalf("item %v not found", item)
+		}
+	}
+}
@@ -9,24 +9,49 @@
 // This implementation is not safe for concurrent usage. You
 // may want to use a sync.RWLock to synchronize access to it
 // if you intend to use it concurrently.
+//
+// The map must not be structurally modified, by inserting, removing or moving
+// items, from within the functions passed to Range and the other methods
+// iterating it, which may otherwise stop early or skip items. Building with
+// the orderedmap_debug build tag makes these methods panic with
+// ErrConcurrentModification when this happens, at the cost of a small
+// overhead.
 package orderedmap
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sync"
 
-	"github.com/lorenzosaino/go-orderedmap/internal/list"
+	"github.com/lorenzosaino/go-orderedmap/list"
 )
 
 var (
 	// ErrKeyMissing indicates that the key specified is not present in the ordered map
 	ErrKeyMissing = errors.New("key missing")
 
-	// ErrMarkKeyMissing indicates that the mark key specified is not present in the ordered map
-	ErrMarkKeyMissing = errors.New("mark key missing")
+	// ErrMarkKeyMissing indicates that the mark key specified is not present in the ordered map.
+	// It wraps ErrKeyMissing, so errors.Is(err, ErrKeyMissing) also reports it.
+	ErrMarkKeyMissing = fmt.Errorf("mark %w", ErrKeyMissing)
 
 	// ErrKeyAlreadyPresent indicates that key to be inserted is already present in the ordered map
 	ErrKeyAlreadyPresent = errors.New("key already present")
+
+	// ErrIndexOutOfRange indicates that the position specified is out of the range of the ordered map
+	ErrIndexOutOfRange = errors.New("index out of range")
+
+	// ErrInvalidRange indicates that the end key of a range specified precedes its start key
+	ErrInvalidRange = errors.New("invalid range")
+
+	// ErrConcurrentModification indicates that the ordered map has been structurally modified while being iterated
+	ErrConcurrentModification = errors.New("concurrent modification")
+
+	// ErrJournalTruncated indicates that the changes requested from the journal of an ordered map are no longer retained
+	ErrJournalTruncated = errors.New("journal truncated")
+
+	// ErrFrozen indicates that an ordered map made read-only by Freeze has been modified
+	ErrFrozen = errors.New("ordered map is frozen")
 )
 
 // Item is a key-value item stored in the ordered map
@@ -35,46 +60,620 @@ type Item[K comparable, V any] struct {
 	Value V
 }
 
+// DuplicatePolicy specifies how items whose key is already present are handled
+// when multiple items are added to an ordered map at once.
+type DuplicatePolicy int
+
+const (
+	// DuplicateError causes an error wrapping ErrKeyAlreadyPresent
+	// to be returned.
+	DuplicateError DuplicatePolicy = iota
+
+	// DuplicateKeepFirst keeps the item already present and discards
+	// the new one.
+	DuplicateKeepFirst
+
+	// DuplicateKeepLast replaces the value of the item already present
+	// with the new one, keeping its position.
+	DuplicateKeepLast
+)
+
 // OrderedMap is an implementation of an ordered map.
 //
 // K and V are respectively the types of keys and values.
 type OrderedMap[K comparable, V any] struct {
-	m map[K]*list.Element[Item[K, V]]
-	l *list.List[Item[K, V]]
+	// Each item is stored inline in its list element, which is the only
+	// allocation per item, and the map indexes elements by key. The key is
+	// therefore stored twice: keys referencing memory, such as strings, share
+	// it, but value keys, such as arrays, are copied in full. Storing keys
+	// only in elements would require hashing arbitrary comparable keys, which
+	// Go maps do not expose.
+	m    map[K]*list.Element[Item[K, V]]
+	l    *list.List[Item[K, V]]
+	opts options
+	idx  *positionIndex[K, V]
+
+	// onEvict is invoked with the items evicted from a bounded map
+	onEvict func(key K, value V)
+
+	// loader loads the values of missing keys in Get and GetOrLoad, once at
+	// a time for each key as tracked by loads
+	loader func(ctx context.Context, key K) (V, error)
+	loads  *loadGroup[K, V]
+
+	// hook is notified of all modifications of the map
+	hook *writeHook[K, V]
+
+	// version is incremented by each structural modification
+	version uint64
+
+	// frozen is set if the map has been made read-only by Freeze
+	frozen bool
+
+	// stats counts operations if the map has been configured with WithStats
+	stats *Stats
+
+	// applying is set while Apply applies edits, which suspends eviction and
+	// access ordering and defers the edits to be emitted to applied
+	applying bool
+	applied  []Edit[K, V]
+
+	// weigh returns the weight of items of a map bounded with WithMaxBytes,
+	// whose total is weight
+	weigh  func(key K, value V) int64
+	weight int64
+
+	// pool recycles the elements of removed items if the map has been
+	// configured with WithElementPool
+	pool *sync.Pool
+
+	// allocator allocates elements if the map has been configured with
+	// WithAllocator
+	allocator Allocator[K, V]
+
+	// normalizeKey normalizes all keys if the map has been configured with
+	// WithKeyNormalizer
+	normalizeKey func(key K) K
+}
+
+// Option configures an ordered map created with New.
+type Option func(*options)
+
+// options holds the configuration of an ordered map.
+type options struct {
+	positionIndex bool
+	maxEntries    int
+	accessOrder   bool
+
+	// onEvict, loader and writeHook are functions typed according to the key
+	// and value types of the map, which are checked by New
+	onEvict   any
+	loader    any
+	writeHook any
+	hookBatch int
+
+	// keyCodec and valueCodec are codecs typed according to the key and
+	// value types of the map, used by its binary encoding
+	keyCodec   any
+	valueCodec any
+
+	journalSize int
+	stats       bool
+
+	// maxBytes is the maximum weight of a map, whose items are weighed by
+	// weigh, typed according to the key and value types of the map
+	maxBytes int64
+	weigh    any
+
+	elementPool bool
+
+	// allocator is an Allocator typed according to the key and value types
+	// of the map
+	allocator any
+
+	// keyNormalizer is a function typed according to the key type of the map
+	keyNormalizer any
+
+	// capacity is the number of items for which space is allocated by New
+	capacity int
+}
+
+// New returns a new ordered map instance configured with the options provided.
+func New[K comparable, V any](opts ...Option) *OrderedMap[K, V] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	m := newWithOptions[K, V](o, o.capacity)
+	// copies of the map do not notify the hook, record their changes in the
+	// journal nor count their operations, so these are not set by
+	// newWithOptions
+	if o.stats {
+		m.stats = &Stats{}
+	}
+	if o.writeHook != nil || o.journalSize > 0 {
+		m.hook = &writeHook[K, V]{batch: o.hookBatch}
+		if o.writeHook != nil {
+			m.hook.f = typedOption[K, V, func([]Edit[K, V])]("write hook", o.writeHook)
+		}
+		if o.journalSize > 0 {
+			m.hook.journal = newJournal[K, V](o.journalSize)
+		}
+	}
+	return m
+}
+
+// WithCapacity allocates space for approximately n items when creating the
+// map, so that it does not need to grow while the first n items are inserted,
+// for example when bulk loading a large number of items. It panics if n is
+// negative.
+func WithCapacity(n int) Option {
+	if n < 0 {
+		panic("orderedmap: capacity must not be negative")
+	}
+	return func(o *options) {
+		o.capacity = n
+	}
+}
+
+// newWithOptions returns a new ordered map instance configured with o
+// and with space for approximately size items.
+func newWithOptions[K comparable, V any](o options, size int) *OrderedMap[K, V] {
+	m := &OrderedMap[K, V]{
+		m:    make(map[K]*list.Element[Item[K, V]], size),
+		l:    list.New[Item[K, V]](),
+		opts: o,
+	}
+	if o.positionIndex {
+		m.idx = newPositionIndex[K, V]()
+	}
+	if o.allocator != nil {
+		m.allocator = typedOption[K, V, Allocator[K, V]]("allocator", o.allocator)
+	} else if o.elementPool {
+		m.pool = newElementPool[K, V]()
+	}
+	if o.onEvict != nil {
+		m.onEvict = typedOption[K, V, func(K, V)]("eviction callback", o.onEvict)
+	}
+	if o.loader != nil {
+		m.loader = typedOption[K, V, func(context.Context, K) (V, error)]("loader", o.loader)
+		m.loads = newLoadGroup[K, V]()
+	}
+	if o.weigh != nil {
+		m.weigh = typedOption[K, V, func(K, V) int64]("weigh function", o.weigh)
+	}
+	if o.keyNormalizer != nil {
+		m.normalizeKey = typedOption[K, V, func(K) K]("key normalizer", o.keyNormalizer)
+	}
+	if o.keyCodec != nil {
+		typedOption[K, V, Codec[K]]("key codec", o.keyCodec)
+	}
+	if o.valueCodec != nil {
+		typedOption[K, V, Codec[V]]("value codec", o.valueCodec)
+	}
+	return m
+}
+
+// typedOption returns the function stored in an option, panicking if its type
+// does not match the key and value types of the map.
+func typedOption[K comparable, V any, F any](name string, v any) F {
+	f, ok := v.(F)
+	if !ok {
+		panic(fmt.Sprintf("orderedmap: %s of type %T cannot be used with keys of type %T and values of type %T", name, v, *new(K), *new(V)))
+	}
+	return f
+}
+
+// lazyInit lazily initializes a zero OrderedMap value.
+func (m *OrderedMap[K, V]) lazyInit() {
+	if m.m == nil {
+		m.m = make(map[K]*list.Element[Item[K, V]])
+		m.l = list.New[Item[K, V]]()
+	}
+}
+
+// beforeWrite must be called by all methods modifying the map before
+// accessing any of its elements.
+func (m *OrderedMap[K, V]) beforeWrite() {
+	if m.frozen {
+		panic(ErrFrozen)
+	}
+}
+
+// The following methods are the only ones modifying the map and the list
+// directly, so that any auxiliary data structure can be kept consistent.
+
+// insert inserts an item immediately before mark, or at the back of the list
+// if mark is nil, and returns the new element. If the map then exceeds its
+// maximum number of entries or weight, front elements, which may include the
+// new one, are evicted.
+func (m *OrderedMap[K, V]) insert(item Item[K, V], mark *list.Element[Item[K, V]]) *list.Element[Item[K, V]] {
+	el := m.alloc(item)
+	m.link(el, mark)
+	m.evictExcess()
+	return el
+}
+
+// link inserts an element which is not part of any list immediately before
+// mark, or at the back of the list if mark is nil. Unlike insert, it does not
+// evict elements if the map exceeds its maximum number of entries or weight.
+func (m *OrderedMap[K, V]) link(el, mark *list.Element[Item[K, V]]) {
+	m.version++
+	m.l.InsertElementBefore(el, mark)
+	m.m[el.Value.Key] = el
+	if m.idx != nil {
+		m.idx.insert(el, mark)
+	}
+	if m.stats != nil {
+		m.stats.Inserts++
+	}
+	if m.hook != nil {
+		m.emitInsert(el)
+	}
+	m.weighIn(el)
+}
+
+// move moves an element immediately before mark, or to the back of the list
+// if mark is nil.
+func (m *OrderedMap[K, V]) move(el, mark *list.Element[Item[K, V]]) {
+	if el == mark {
+		return
+	}
+	m.version++
+	if m.stats != nil {
+		m.stats.Moves++
+	}
+	if mark == nil {
+		m.l.MoveToBack(el)
+	} else {
+		m.l.MoveBefore(el, mark)
+	}
+	if m.idx != nil {
+		m.idx.move(el, mark)
+	}
+	if m.hook != nil {
+		if mark == nil {
+			m.emit(Edit[K, V]{Op: EditMoveToBack, Key: el.Value.Key})
+		} else {
+			m.emit(Edit[K, V]{Op: EditMoveBefore, Key: el.Value.Key, Mark: mark.Value.Key})
+		}
+	}
+}
+
+// remove removes an element and returns its item.
+func (m *OrderedMap[K, V]) remove(el *list.Element[Item[K, V]]) Item[K, V] {
+	item := m.unlink(el, EditDelete)
+	m.free(el)
+	return item
+}
+
+// unlink removes an element, which can then be inserted again with link,
+// and returns its item. The removal is described by an edit with operation
+// op.
+func (m *OrderedMap[K, V]) unlink(el *list.Element[Item[K, V]], op EditOp) Item[K, V] {
+	m.version++
+	if m.stats != nil {
+		m.stats.Deletes++
+	}
+	m.weighOut(el)
+	delete(m.m, el.Value.Key)
+	if m.idx != nil {
+		m.idx.remove(el)
+	}
+	if m.hook != nil {
+		m.emit(Edit[K, V]{Op: op, Key: el.Value.Key})
+	}
+	return m.l.Remove(el)
+}
+
+// update updates the value of an element. If the map then exceeds its
+// maximum weight, front elements, which may include el, are evicted.
+func (m *OrderedMap[K, V]) update(el *list.Element[Item[K, V]], value V) {
+	m.weighOut(el)
+	el.Value.Value = value
+	m.weighIn(el)
+	if m.hook != nil {
+		m.emit(Edit[K, V]{Op: EditUpdate, Key: el.Value.Key, Value: value})
+	}
+	m.evictExcess()
+}
+
+// rekey replaces the key of an element. If the map then exceeds its maximum
+// weight, front elements, which may include el, are evicted.
+func (m *OrderedMap[K, V]) rekey(el *list.Element[Item[K, V]], newKey K) {
+	m.version++
+	if m.hook != nil {
+		m.emit(Edit[K, V]{Op: EditDelete, Key: el.Value.Key})
+	}
+	m.weighOut(el)
+	delete(m.m, el.Value.Key)
+	el.Value.Key = newKey
+	m.m[newKey] = el
+	m.weighIn(el)
+	if m.hook != nil {
+		m.emitInsert(el)
+	}
+	m.evictExcess()
+}
+
+// sort sorts the list according to less.
+func (m *OrderedMap[K, V]) sort(less func(a, b Item[K, V]) bool) {
+	m.version++
+	m.l.Sort(less)
+	if m.idx != nil {
+		m.idx.rebuild(m.l)
+	}
+	if m.hook != nil {
+		for e := m.l.Front(); e != nil; e = e.Next() {
+			m.emit(Edit[K, V]{Op: EditMoveToBack, Key: e.Value.Key})
+		}
+	}
 }
 
-// New returns a new ordered map instance.
-func New[K comparable, V any]() *OrderedMap[K, V] {
-	return &OrderedMap[K, V]{
-		m: make(map[K]*list.Element[Item[K, V]]),
-		l: list.New[Item[K, V]](),
+// clear removes all elements.
+func (m *OrderedMap[K, V]) clear(retain bool) {
+	m.version++
+	if m.stats != nil {
+		m.stats.Deletes += uint64(len(m.m))
+	}
+	if m.hook != nil {
+		for e := m.l.Front(); e != nil; e = e.Next() {
+			m.emit(Edit[K, V]{Op: EditDelete, Key: e.Value.Key})
+		}
+	}
+	if m.allocator != nil || (retain && m.pool != nil) {
+		for e := m.l.Front(); e != nil; {
+			next := e.Next()
+			m.free(e)
+			e = next
+		}
+	}
+	if retain {
+		for key := range m.m {
+			delete(m.m, key)
+		}
+	} else {
+		m.m = make(map[K]*list.Element[Item[K, V]])
+	}
+	m.l.Init()
+	m.weight = 0
+	if m.idx != nil {
+		m.idx.clear()
 	}
 }
 
 // Get returns the value associated to a key in the map.
 //
 // If the key is not present in the map, it returns the zero value of V
-// and ok is set to false.
+// and ok is set to false. If the map has been configured with WithLoader,
+// the value of a missing key is loaded as GetOrLoad does, and ok is set to
+// false only if the loader fails.
 func (m *OrderedMap[K, V]) Get(key K) (value V, ok bool) {
-	if el, ok := m.m[key]; ok {
+	if m.loads != nil {
+		value, err := m.GetOrLoad(context.Background(), key)
+		return value, err == nil
+	}
+	return m.get(m.normalize(key))
+}
+
+// get returns the value associated to a normalized key in the map, without
+// loading it.
+func (m *OrderedMap[K, V]) get(key K) (value V, ok bool) {
+	if m.accessOrdered() {
+		m.beforeWrite()
+	}
+	el, ok := m.m[key]
+	m.recordGet(ok)
+	if ok {
+		m.access(el)
 		return el.Value.Value, true
 	}
 	return value, false
 }
 
+// Touch moves an existing key to the front of the map and returns its value,
+// with a single lookup.
+//
+// If the key is not present in the map, it returns the zero value of V
+// and ok is set to false.
+func (m *OrderedMap[K, V]) Touch(key K) (value V, ok bool) {
+	key = m.normalize(key)
+	m.beforeWrite()
+	el, ok := m.m[key]
+	m.recordGet(ok)
+	if !ok {
+		return value, false
+	}
+	m.move(el, m.l.Front())
+	return el.Value.Value, true
+}
+
+// Has reports whether a key is present in the map.
+func (m *OrderedMap[K, V]) Has(key K) bool {
+	key = m.normalize(key)
+	_, ok := m.m[key]
+	return ok
+}
+
 // Update updates the value associated to an existing key and returns the old value.
 //
 // If the key is not present, then ErrKeyMissing is returned.
 func (m *OrderedMap[K, V]) Update(key K, value V) (oldValue V, err error) {
+	key = m.normalize(key)
+	m.beforeWrite()
 	el, ok := m.m[key]
 	if !ok {
 		return oldValue, ErrKeyMissing
 	}
 	oldValue = el.Value.Value
-	el.Value.Value = value
+	m.access(el)
+	m.update(el, value)
 	return oldValue, nil
 }
 
+// Set sets the value associated to a key.
+//
+// If the key is already present, its value is updated in place, without
+// changing its position unless the map is in access order, and replaced is
+// set to true. Otherwise, the key and value are inserted at the back of the map.
+func (m *OrderedMap[K, V]) Set(key K, value V) (replaced bool) {
+	key = m.normalize(key)
+	m.beforeWrite()
+	if m.setExisting(key, value) {
+		return true
+	}
+	m.insert(Item[K, V]{key, value}, nil)
+	return false
+}
+
+// SetFront sets the value associated to a key, like Set, but inserts the key
+// and value at the front of the map if the key is not already present.
+func (m *OrderedMap[K, V]) SetFront(key K, value V) (replaced bool) {
+	key = m.normalize(key)
+	m.beforeWrite()
+	if m.setExisting(key, value) {
+		return true
+	}
+	m.insert(Item[K, V]{key, value}, m.l.Front())
+	return false
+}
+
+// SetBack sets the value associated to a key. It is equivalent to Set, and
+// complements SetFront, SetAfter and SetBefore.
+func (m *OrderedMap[K, V]) SetBack(key K, value V) (replaced bool) {
+	return m.Set(key, value)
+}
+
+// SetAfter sets the value associated to a key, like Set, but inserts the key
+// and value immediately after a mark key if the key is not already present.
+//
+// If the key is already present, the mark key is ignored. Otherwise, it
+// returns ErrMarkKeyMissing if the mark key is missing.
+func (m *OrderedMap[K, V]) SetAfter(key K, value V, mark K) (replaced bool, err error) {
+	key, mark = m.normalize(key), m.normalize(mark)
+	m.beforeWrite()
+	if m.setExisting(key, value) {
+		return true, nil
+	}
+	markEl, ok := m.m[mark]
+	if !ok {
+		return false, ErrMarkKeyMissing
+	}
+	m.insert(Item[K, V]{key, value}, markEl.Next())
+	return false, nil
+}
+
+// SetBefore sets the value associated to a key, like Set, but inserts the key
+// and value immediately before a mark key if the key is not already present.
+//
+// If the key is already present, the mark key is ignored. Otherwise, it
+// returns ErrMarkKeyMissing if the mark key is missing.
+func (m *OrderedMap[K, V]) SetBefore(key K, value V, mark K) (replaced bool, err error) {
+	key, mark = m.normalize(key), m.normalize(mark)
+	m.beforeWrite()
+	if m.setExisting(key, value) {
+		return true, nil
+	}
+	markEl, ok := m.m[mark]
+	if !ok {
+		return false, ErrMarkKeyMissing
+	}
+	m.insert(Item[K, V]{key, value}, markEl)
+	return false, nil
+}
+
+// setExisting updates the value associated to a key in place, if present,
+// and reports whether it is.
+func (m *OrderedMap[K, V]) setExisting(key K, value V) bool {
+	el, ok := m.m[key]
+	if ok {
+		m.access(el)
+		m.update(el, value)
+	}
+	return ok
+}
+
+// GetOrCompute returns the value associated to a key in the map.
+//
+// If the key is not present, f is invoked to compute its value, which is
+// inserted at the back of the map and returned. f must not modify the map.
+func (m *OrderedMap[K, V]) GetOrCompute(key K, f func() V) V {
+	key = m.normalize(key)
+	if m.accessOrdered() {
+		m.beforeWrite()
+	}
+	el, ok := m.m[key]
+	m.recordGet(ok)
+	if ok {
+		m.access(el)
+		return el.Value.Value
+	}
+	value := f()
+	m.beforeWrite()
+	m.insert(Item[K, V]{key, value}, nil)
+	return value
+}
+
+// Upsert inserts or merges the value associated to a key.
+//
+// If the key is already present, its value is replaced by the value returned
+// by merge, invoked with the current and the new value, without changing its
+// position unless the map is in access order. Otherwise,
+// the key and value are inserted at the back of the map and merge is not
+// invoked.
+func (m *OrderedMap[K, V]) Upsert(key K, value V, merge func(old, new V) V) {
+	key = m.normalize(key)
+	m.beforeWrite()
+	if el, ok := m.m[key]; ok {
+		m.access(el)
+		m.update(el, merge(el.Value.Value, value))
+		return
+	}
+	m.insert(Item[K, V]{key, value}, nil)
+}
+
+// ReplaceKey replaces the key of an existing item, keeping its value
+// and position.
+//
+// It returns ErrKeyMissing if oldKey is not present and ErrKeyAlreadyPresent
+// if newKey is already present. Replacing a key with itself is a no-op.
+func (m *OrderedMap[K, V]) ReplaceKey(oldKey, newKey K) error {
+	oldKey, newKey = m.normalize(oldKey), m.normalize(newKey)
+	m.beforeWrite()
+	el, ok := m.m[oldKey]
+	if !ok {
+		return ErrKeyMissing
+	}
+	if oldKey == newKey {
+		return nil
+	}
+	if _, ok := m.m[newKey]; ok {
+		return ErrKeyAlreadyPresent
+	}
+	m.rekey(el, newKey)
+	return nil
+}
+
+// add inserts a new key and value at the back of the map, handling an
+// existing key according to policy.
+func (m *OrderedMap[K, V]) add(key K, value V, policy DuplicatePolicy) error {
+	key = m.normalize(key)
+	m.beforeWrite()
+	el, ok := m.m[key]
+	if !ok {
+		m.insert(Item[K, V]{key, value}, nil)
+		return nil
+	}
+	switch policy {
+	case DuplicateKeepFirst:
+	case DuplicateKeepLast:
+		m.update(el, value)
+	default:
+		return fmt.Errorf("duplicate key %v: %w", key, ErrKeyAlreadyPresent)
+	}
+	return nil
+}
+
 // Front returns the item at the front of the map.
 //
 // If the map is empty, it returns the zero value of Item[K, V]
@@ -101,11 +700,13 @@ func (m *OrderedMap[K, V]) Back() (item Item[K, V], ok bool) {
 //
 // It returns ErrKeyAlreadyPresent if the key to be inserted is already present.
 func (m *OrderedMap[K, V]) PushFront(key K, value V) error {
+	key = m.normalize(key)
+	m.beforeWrite()
 	if _, ok := m.m[key]; ok {
 		return ErrKeyAlreadyPresent
 	}
 	newVal := Item[K, V]{key, value}
-	m.m[key] = m.l.PushFront(newVal)
+	m.insert(newVal, m.l.Front())
 	return nil
 }
 
@@ -113,11 +714,88 @@ func (m *OrderedMap[K, V]) PushFront(key K, value V) error {
 //
 // It returns ErrKeyAlreadyPresent if the key to be inserted is already present.
 func (m *OrderedMap[K, V]) PushBack(key K, value V) error {
+	key = m.normalize(key)
+	m.beforeWrite()
 	if _, ok := m.m[key]; ok {
 		return ErrKeyAlreadyPresent
 	}
 	newVal := Item[K, V]{key, value}
-	m.m[key] = m.l.PushBack(newVal)
+	m.insert(newVal, nil)
+	return nil
+}
+
+// PushFrontItems inserts new items at the front of the map, preserving their
+// order, so that items[0] becomes the front of the map.
+//
+// Insertion is all-or-nothing: if the key of any item is already present in
+// the map or appears more than once in items, the map is left unmodified and
+// an error wrapping ErrKeyAlreadyPresent is returned.
+func (m *OrderedMap[K, V]) PushFrontItems(items ...Item[K, V]) error {
+	m.beforeWrite()
+	items = m.normalizeItems(items)
+	if err := m.checkNewItems(items); err != nil {
+		return err
+	}
+	mark := m.l.Front()
+	for _, item := range items {
+		m.insert(item, mark)
+	}
+	return nil
+}
+
+// PushBackItems inserts new items at the back of the map, preserving their
+// order.
+//
+// Insertion is all-or-nothing: if the key of any item is already present in
+// the map or appears more than once in items, the map is left unmodified and
+// an error wrapping ErrKeyAlreadyPresent is returned.
+func (m *OrderedMap[K, V]) PushBackItems(items ...Item[K, V]) error {
+	m.beforeWrite()
+	items = m.normalizeItems(items)
+	if err := m.checkNewItems(items); err != nil {
+		return err
+	}
+	for _, item := range items {
+		m.insert(item, nil)
+	}
+	return nil
+}
+
+// ExtendBack inserts the items of other at the back of the map, in the order
+// in which they appear in other.
+//
+// Keys of other already present in the map are handled according to
+// onDuplicate. If onDuplicate is DuplicateError and any key is already
+// present, the map is left unmodified and an error wrapping
+// ErrKeyAlreadyPresent is returned.
+func (m *OrderedMap[K, V]) ExtendBack(other *OrderedMap[K, V], onDuplicate DuplicatePolicy) error {
+	m.beforeWrite()
+	if onDuplicate == DuplicateError {
+		for e := other.l.Front(); e != nil; e = e.Next() {
+			if _, ok := m.m[m.normalize(e.Value.Key)]; ok {
+				return fmt.Errorf("duplicate key %v: %w", e.Value.Key, ErrKeyAlreadyPresent)
+			}
+		}
+	}
+	for e := other.l.Front(); e != nil; e = e.Next() {
+		if err := m.add(e.Value.Key, e.Value.Value, onDuplicate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkNewItems returns an error if any of the keys of items is already
+// present in the map or is repeated.
+func (m *OrderedMap[K, V]) checkNewItems(items []Item[K, V]) error {
+	seen := make(map[K]struct{}, len(items))
+	for _, item := range items {
+		_, dup := seen[item.Key]
+		if _, ok := m.m[item.Key]; ok || dup {
+			return fmt.Errorf("duplicate key %v: %w", item.Key, ErrKeyAlreadyPresent)
+		}
+		seen[item.Key] = struct{}{}
+	}
 	return nil
 }
 
@@ -126,6 +804,8 @@ func (m *OrderedMap[K, V]) PushBack(key K, value V) error {
 // It returns ErrKeyAlreadyPresent if the key to be inserted is already present
 // and ErrMarkKeyMissing if the mark key is missing.
 func (m *OrderedMap[K, V]) InsertAfter(key K, value V, mark K) error {
+	key, mark = m.normalize(key), m.normalize(mark)
+	m.beforeWrite()
 	if _, ok := m.m[key]; ok {
 		return ErrKeyAlreadyPresent
 	}
@@ -134,8 +814,7 @@ func (m *OrderedMap[K, V]) InsertAfter(key K, value V, mark K) error {
 		return ErrMarkKeyMissing
 	}
 	newVal := Item[K, V]{key, value}
-	newEl := m.l.InsertAfter(newVal, markEl)
-	m.m[key] = newEl
+	m.insert(newVal, markEl.Next())
 	return nil
 }
 
@@ -144,6 +823,8 @@ func (m *OrderedMap[K, V]) InsertAfter(key K, value V, mark K) error {
 // It returns ErrKeyAlreadyPresent if the key to be inserted is already present
 // and ErrMarkKeyMissing if the mark key is missing.
 func (m *OrderedMap[K, V]) InsertBefore(key K, value V, mark K) error {
+	key, mark = m.normalize(key), m.normalize(mark)
+	m.beforeWrite()
 	if _, ok := m.m[key]; ok {
 		return ErrKeyAlreadyPresent
 	}
@@ -152,8 +833,7 @@ func (m *OrderedMap[K, V]) InsertBefore(key K, value V, mark K) error {
 		return ErrMarkKeyMissing
 	}
 	newVal := Item[K, V]{key, value}
-	newEl := m.l.InsertBefore(newVal, markEl)
-	m.m[key] = newEl
+	m.insert(newVal, markEl)
 	return nil
 }
 
@@ -161,11 +841,13 @@ func (m *OrderedMap[K, V]) InsertBefore(key K, value V, mark K) error {
 //
 // It returns ErrKeyMissing if the key to be moved is not in the map.
 func (m *OrderedMap[K, V]) MoveToFront(key K) error {
+	key = m.normalize(key)
+	m.beforeWrite()
 	e, ok := m.m[key]
 	if !ok {
 		return ErrKeyMissing
 	}
-	m.l.MoveToFront(e)
+	m.move(e, m.l.Front())
 	return nil
 }
 
@@ -173,11 +855,13 @@ func (m *OrderedMap[K, V]) MoveToFront(key K) error {
 //
 // It returns ErrKeyMissing if the key to be moved is not in the map.
 func (m *OrderedMap[K, V]) MoveToBack(key K) error {
+	key = m.normalize(key)
+	m.beforeWrite()
 	e, ok := m.m[key]
 	if !ok {
 		return ErrKeyMissing
 	}
-	m.l.MoveToBack(e)
+	m.move(e, nil)
 	return nil
 }
 
@@ -186,6 +870,8 @@ func (m *OrderedMap[K, V]) MoveToBack(key K) error {
 // It returns ErrKeyMissing if the key to be moved is missing
 // and ErrMarkKeyMissing if the mark key is missing.
 func (m *OrderedMap[K, V]) MoveAfter(key K, mark K) error {
+	key, mark = m.normalize(key), m.normalize(mark)
+	m.beforeWrite()
 	if key == mark {
 		return nil
 	}
@@ -195,17 +881,19 @@ func (m *OrderedMap[K, V]) MoveAfter(key K, mark K) error {
 	}
 	markEl, ok := m.m[mark]
 	if !ok {
-		return ErrKeyMissing
+		return ErrMarkKeyMissing
 	}
-	m.l.MoveAfter(el, markEl)
+	m.move(el, markEl.Next())
 	return nil
 }
 
-// MoveAfter moves an existing key immediately before a mark key.
+// MoveBefore moves an existing key immediately before a mark key.
 //
 // It returns ErrKeyMissing if the key to be moved is missing
 // and ErrMarkKeyMissing if the mark key is missing.
 func (m *OrderedMap[K, V]) MoveBefore(key K, mark K) error {
+	key, mark = m.normalize(key), m.normalize(mark)
+	m.beforeWrite()
 	if key == mark {
 		return nil
 	}
@@ -214,38 +902,247 @@ func (m *OrderedMap[K, V]) MoveBefore(key K, mark K) error {
 		return ErrKeyMissing
 	}
 	markEl, ok := m.m[mark]
+	if !ok {
+		return ErrMarkKeyMissing
+	}
+	m.move(el, markEl)
+	return nil
+}
+
+// MoveBy moves an existing key by delta positions, towards the back of the map
+// if delta is positive or towards the front if delta is negative.
+//
+// If the move would go past either end of the map, the key is moved to that
+// end. It returns ErrKeyMissing if the key to be moved is missing.
+func (m *OrderedMap[K, V]) MoveBy(key K, delta int) error {
+	key = m.normalize(key)
+	m.beforeWrite()
+	el, ok := m.m[key]
 	if !ok {
 		return ErrKeyMissing
 	}
-	m.l.MoveBefore(el, markEl)
+	mark := el
+	switch {
+	case delta > 0:
+		for ; delta > 0 && mark.Next() != nil; delta-- {
+			mark = mark.Next()
+		}
+		m.move(el, mark.Next())
+	case delta < 0:
+		for ; delta < 0 && mark.Prev() != nil; delta++ {
+			mark = mark.Prev()
+		}
+		m.move(el, mark)
+	}
+	return nil
+}
+
+// MoveRangeAfter moves the contiguous range of items between the keys first
+// and last, both included, immediately after a mark key, preserving their
+// order.
+//
+// It returns ErrKeyMissing if first or last is missing, ErrMarkKeyMissing if
+// the mark key is missing and ErrInvalidRange if last precedes first or the
+// mark key is within the range, unless it is last, in which case the map is
+// not modified. It runs in O(n) time, where n is the number of items in the
+// range, plus the time needed to find that last follows first, which is
+// bounded by the number of items following first.
+func (m *OrderedMap[K, V]) MoveRangeAfter(first, last, mark K) error {
+	m.beforeWrite()
+	els, markEl, err := m.rangeElements(first, last, mark)
+	if err != nil {
+		return err
+	}
+	if markEl == els[len(els)-1] {
+		return nil
+	}
+	if err := checkMarkOutside(els, markEl); err != nil {
+		return err
+	}
+	m.moveRange(els, markEl.Next())
+	return nil
+}
+
+// MoveRangeBefore moves the contiguous range of items between the keys first
+// and last, both included, immediately before a mark key, preserving their
+// order.
+//
+// It returns ErrKeyMissing if first or last is missing, ErrMarkKeyMissing if
+// the mark key is missing and ErrInvalidRange if last precedes first or the
+// mark key is within the range, unless it is first, in which case the map is
+// not modified. Its complexity is the one of MoveRangeAfter.
+func (m *OrderedMap[K, V]) MoveRangeBefore(first, last, mark K) error {
+	m.beforeWrite()
+	els, markEl, err := m.rangeElements(first, last, mark)
+	if err != nil {
+		return err
+	}
+	if markEl == els[0] {
+		return nil
+	}
+	if err := checkMarkOutside(els, markEl); err != nil {
+		return err
+	}
+	m.moveRange(els, markEl)
+	return nil
+}
+
+// rangeElements returns the elements of the range between the keys first and
+// last, both included, and the element of the mark key.
+func (m *OrderedMap[K, V]) rangeElements(first, last, mark K) (els []*list.Element[Item[K, V]], markEl *list.Element[Item[K, V]], err error) {
+	first, last, mark = m.normalize(first), m.normalize(last), m.normalize(mark)
+	firstEl, ok := m.m[first]
+	if !ok {
+		return nil, nil, ErrKeyMissing
+	}
+	lastEl, ok := m.m[last]
+	if !ok {
+		return nil, nil, ErrKeyMissing
+	}
+	markEl, ok = m.m[mark]
+	if !ok {
+		return nil, nil, ErrMarkKeyMissing
+	}
+	for e := firstEl; ; e = e.Next() {
+		if e == nil {
+			return nil, nil, ErrInvalidRange
+		}
+		els = append(els, e)
+		if e == lastEl {
+			return els, markEl, nil
+		}
+	}
+}
+
+// checkMarkOutside returns ErrInvalidRange if mark is one of els.
+func checkMarkOutside[K comparable, V any](els []*list.Element[Item[K, V]], mark *list.Element[Item[K, V]]) error {
+	for _, e := range els {
+		if e == mark {
+			return ErrInvalidRange
+		}
+	}
 	return nil
 }
 
+// moveRange moves contiguous elements immediately before mark, or to the back
+// of the list if mark is nil. mark must not be one of the elements, except the
+// first one, in which case they are not moved.
+func (m *OrderedMap[K, V]) moveRange(els []*list.Element[Item[K, V]], mark *list.Element[Item[K, V]]) {
+	if mark == els[0] || mark == els[len(els)-1].Next() {
+		// the range is already in place
+		return
+	}
+	for _, e := range els {
+		m.move(e, mark)
+	}
+}
+
 // Delete deletes an item from a map and returns the value deleted.
 //
 // If the item to be deleted was already missing from the map, ok is set to false.
 func (m *OrderedMap[K, V]) Delete(key K) (value V, ok bool) {
+	key = m.normalize(key)
+	m.beforeWrite()
 	el, ok := m.m[key]
 	if !ok {
 		return value, false
 	}
-	val := m.l.Remove(el)
-	delete(m.m, key)
+	val := m.remove(el)
 	return val.Value, true
 }
 
+// DeleteFunc deletes in place all items such that f(key, value) == true
+// and returns the number of items deleted.
+func (m *OrderedMap[K, V]) DeleteFunc(f func(key K, value V) bool) int {
+	m.beforeWrite()
+	n := 0
+	for e := m.l.Front(); e != nil; {
+		next := e.Next()
+		if f(e.Value.Key, e.Value.Value) {
+			m.remove(e)
+			n++
+		}
+		e = next
+	}
+	return n
+}
+
+// DedupValues deletes in place all items whose value is equal, according to
+// eq, to the value of a preceding item, so that only the first item with each
+// value is retained, and returns the number of items deleted.
+//
+// Each value is compared with the values retained so far, so it runs in
+// O(n*k) time, where k is the number of distinct values.
+func (m *OrderedMap[K, V]) DedupValues(eq func(a, b V) bool) int {
+	m.beforeWrite()
+	var retained []V
+	n := 0
+	for e := m.l.Front(); e != nil; {
+		next := e.Next()
+		dup := false
+		for _, v := range retained {
+			if eq(v, e.Value.Value) {
+				dup = true
+				break
+			}
+		}
+		if dup {
+			m.remove(e)
+			n++
+		} else {
+			retained = append(retained, e.Value.Value)
+		}
+		e = next
+	}
+	return n
+}
+
+// DeleteKeys deletes in place all items whose key is one of keys
+// and returns the number of items deleted. Missing keys are ignored.
+func (m *OrderedMap[K, V]) DeleteKeys(keys ...K) int {
+	m.beforeWrite()
+	n := 0
+	for _, key := range keys {
+		if el, ok := m.m[m.normalize(key)]; ok {
+			m.remove(el)
+			n++
+		}
+	}
+	return n
+}
+
+// RetainKeys deletes in place all items whose key is not one of keys
+// and returns the number of items deleted. Missing keys are ignored.
+func (m *OrderedMap[K, V]) RetainKeys(keys ...K) int {
+	m.beforeWrite()
+	retain := make(map[K]struct{}, len(keys))
+	for _, key := range keys {
+		retain[m.normalize(key)] = struct{}{}
+	}
+	n := 0
+	for e := m.l.Front(); e != nil; {
+		next := e.Next()
+		if _, ok := retain[e.Value.Key]; !ok {
+			m.remove(e)
+			n++
+		}
+		e = next
+	}
+	return n
+}
+
 // PopFront pops the element at the front of the map and returns its value.
 //
 // If the map is empty, it returns the zero value of Item[K, V]
 // and ok is set to false.
 func (m *OrderedMap[K, V]) PopFront() (item Item[K, V], ok bool) {
+	m.beforeWrite()
 	el := m.l.Front()
 	if el == nil {
 		return item, false
 	}
 
-	delete(m.m, el.Value.Key)
-	item = m.l.Remove(el)
+	item = m.remove(el)
 
 	return item, true
 }
@@ -255,17 +1152,33 @@ func (m *OrderedMap[K, V]) PopFront() (item Item[K, V], ok bool) {
 // If the map is empty, it returns the zero value of Item[K, V]
 // and ok is set to false.
 func (m *OrderedMap[K, V]) PopBack() (item Item[K, V], ok bool) {
+	m.beforeWrite()
 	el := m.l.Back()
 	if el == nil {
 		return item, false
 	}
 
-	delete(m.m, el.Value.Key)
-	item = m.l.Remove(el)
+	item = m.remove(el)
 
 	return item, true
 }
 
+// Drain returns an iterator, compatible with iter.Seq2, yielding the items
+// of the map from front to back while removing them.
+//
+// Each item is removed from the map before being yielded. If the iteration
+// stops early, the items not yet yielded remain in the map in their order.
+func (m *OrderedMap[K, V]) Drain() func(yield func(key K, value V) bool) {
+	return func(yield func(key K, value V) bool) {
+		for {
+			item, ok := m.PopFront()
+			if !ok || !yield(item.Key, item.Value) {
+				return
+			}
+		}
+	}
+}
+
 // Len returns the number of items stored in the ordered map.
 func (m *OrderedMap[K, V]) Len() int {
 	return len(m.m)
@@ -273,13 +1186,71 @@ func (m *OrderedMap[K, V]) Len() int {
 
 // Clear empties the ordered map.
 func (m *OrderedMap[K, V]) Clear() {
-	m.m = make(map[K]*list.Element[Item[K, V]])
-	m.l.Init()
+	m.beforeWrite()
+	m.clear(false)
+}
+
+// ClearRetain empties the ordered map like Clear, but retains the space
+// allocated for its items, so that it can be refilled without growing again,
+// for example when reusing maps through a sync.Pool. If the map has been
+// configured with WithElementPool, the elements of its items are recycled.
+//
+// Since the space is retained, maps that shrink after being cleared should be
+// compacted with Compact.
+func (m *OrderedMap[K, V]) ClearRetain() {
+	m.beforeWrite()
+	if m.m == nil {
+		return
+	}
+	m.clear(true)
+}
+
+// Compact rebuilds the internal structures of the map to fit its current
+// number of items, releasing the memory retained by a map that has shrunk,
+// since Go maps never shrink. It runs in O(n) time.
+//
+// Compact does not modify the items of the map nor their order, so it can be
+// called while iterating the map.
+func (m *OrderedMap[K, V]) Compact() {
+	m.beforeWrite()
+	if m.m == nil {
+		return
+	}
+	index := make(map[K]*list.Element[Item[K, V]], len(m.m))
+	for key, el := range m.m {
+		index[key] = el
+	}
+	m.m = index
 }
 
-// Reverse returns a copy of the ordered map with reversed ordering.
+// Clone returns a copy of the ordered map, with the same items in the same
+// order and the same configuration.
+//
+// Keys and values are copied by assignment, so that this is a shallow copy.
+func (m *OrderedMap[K, V]) Clone() *OrderedMap[K, V] {
+	out := newWithOptions[K, V](m.opts, m.Len())
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		out.insert(e.Value, nil)
+	}
+	return out
+}
+
+// CloneFunc returns a copy of the ordered map, with the same items in the same
+// order and the same configuration, where each value is copied by calling
+// cloneValue. It can be used to make a deep copy of maps whose values are
+// pointers, slices or maps.
+func (m *OrderedMap[K, V]) CloneFunc(cloneValue func(value V) V) *OrderedMap[K, V] {
+	out := newWithOptions[K, V](m.opts, m.Len())
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		out.insert(Item[K, V]{e.Value.Key, cloneValue(e.Value.Value)}, nil)
+	}
+	return out
+}
+
+// Reverse returns a copy of the ordered map with reversed ordering and the
+// same configuration.
 func (m *OrderedMap[K, V]) Reverse() *OrderedMap[K, V] {
-	out := New[K, V]()
+	out := newWithOptions[K, V](m.opts, m.Len())
 	for item, ok := m.Front(); ok; item, ok = m.Next(item.Key) {
 		if err := out.PushFront(item.Key, item.Value); err != nil {
 			// while generally we should not panic from within a library, this
@@ -295,9 +1266,9 @@ func (m *OrderedMap[K, V]) Reverse() *OrderedMap[K, V] {
 // Filter returns a filtered copy of the ordered map.
 //
 // The returned map only includes the (key, value) items such that
-// f(key, value) == true.
+// f(key, value) == true. It has the same configuration as the map.
 func (m *OrderedMap[K, V]) Filter(f func(key K, value V) bool) *OrderedMap[K, V] {
-	out := New[K, V]()
+	out := newWithOptions[K, V](m.opts, 0)
 	for item, ok := m.Front(); ok; item, ok = m.Next(item.Key) {
 		if f != nil && !f(item.Key, item.Value) {
 			continue
@@ -313,23 +1284,260 @@ func (m *OrderedMap[K, V]) Filter(f func(key K, value V) bool) *OrderedMap[K, V]
 	return out
 }
 
+// Partition splits the ordered map into two copies: match, including the
+// (key, value) items such that f(key, value) == true, and rest, including
+// all other items. Both preserve the relative order of their items and have
+// the same configuration as the map, as copies returned by Clone.
+func (m *OrderedMap[K, V]) Partition(f func(key K, value V) bool) (match, rest *OrderedMap[K, V]) {
+	match, rest = newWithOptions[K, V](m.opts, 0), newWithOptions[K, V](m.opts, 0)
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		if f(e.Value.Key, e.Value.Value) {
+			match.insert(e.Value, nil)
+		} else {
+			rest.insert(e.Value, nil)
+		}
+	}
+	return match, rest
+}
+
+// TakeWhile returns a copy of the longest prefix of the ordered map whose
+// (key, value) items are such that f(key, value) == true, that is of all
+// items preceding the first one for which f returns false. The copy has the
+// same configuration as the map, as copies returned by Clone.
+func (m *OrderedMap[K, V]) TakeWhile(f func(key K, value V) bool) *OrderedMap[K, V] {
+	out := newWithOptions[K, V](m.opts, 0)
+	for e := m.l.Front(); e != nil && f(e.Value.Key, e.Value.Value); e = e.Next() {
+		out.insert(e.Value, nil)
+	}
+	return out
+}
+
+// DropWhile returns a copy of the ordered map without its longest prefix of
+// (key, value) items such that f(key, value) == true, that is of all items
+// starting from the first one for which f returns false. The copy has the
+// same configuration as the map, as copies returned by Clone.
+func (m *OrderedMap[K, V]) DropWhile(f func(key K, value V) bool) *OrderedMap[K, V] {
+	out := newWithOptions[K, V](m.opts, 0)
+	e := m.l.Front()
+	for e != nil && f(e.Value.Key, e.Value.Value) {
+		e = e.Next()
+	}
+	for ; e != nil; e = e.Next() {
+		out.insert(e.Value, nil)
+	}
+	return out
+}
+
+// SplitAt splits the ordered map into two copies: left, including the items
+// preceding key, and right, including the item of key and all following
+// items. Both have the same configuration as the map, as copies returned by
+// Clone.
+//
+// It returns ErrKeyMissing if the key is missing.
+func (m *OrderedMap[K, V]) SplitAt(key K) (left, right *OrderedMap[K, V], err error) {
+	el, ok := m.m[m.normalize(key)]
+	if !ok {
+		return nil, nil, ErrKeyMissing
+	}
+	left, right = m.splitAt(el)
+	return left, right, nil
+}
+
+// splitAt splits the ordered map into copies of the items preceding el and of
+// el and all following items. If el is nil, all items are copied to left.
+func (m *OrderedMap[K, V]) splitAt(el *list.Element[Item[K, V]]) (left, right *OrderedMap[K, V]) {
+	left, right = newWithOptions[K, V](m.opts, 0), newWithOptions[K, V](m.opts, 0)
+	out := left
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		if e == el {
+			out = right
+		}
+		out.insert(e.Value, nil)
+	}
+	return left, right
+}
+
+// SubMap returns a copy of the items of the ordered map between the keys from
+// and to, both included, with the same configuration as the map, as copies
+// returned by Clone.
+//
+// It returns ErrKeyMissing if either key is missing and ErrInvalidRange if to
+// precedes from.
+func (m *OrderedMap[K, V]) SubMap(from, to K) (*OrderedMap[K, V], error) {
+	from, to = m.normalize(from), m.normalize(to)
+	fromEl, ok := m.m[from]
+	if !ok {
+		return nil, ErrKeyMissing
+	}
+	toEl, ok := m.m[to]
+	if !ok {
+		return nil, ErrKeyMissing
+	}
+	out := newWithOptions[K, V](m.opts, 0)
+	for e := fromEl; ; e = e.Next() {
+		if e == nil {
+			return nil, ErrInvalidRange
+		}
+		out.insert(e.Value, nil)
+		if e == toEl {
+			return out, nil
+		}
+	}
+}
+
 // Range calls f sequentially for each key and value present in the ordered map
 // starting from the front element. If f returns false, Range stops the iteration.
 func (m *OrderedMap[K, V]) Range(f func(key K, value V) bool) {
+	v := m.version
 	for e := m.l.Front(); e != nil; e = e.Next() {
 		if !f(e.Value.Key, e.Value.Value) {
 			return
 		}
+		m.checkVersion(v)
 	}
 }
 
+// RangeWithIndex calls f sequentially for each key and value present in the
+// ordered map starting from the front element, together with the position i
+// of the item, starting from 0. If f returns false, RangeWithIndex stops
+// the iteration.
+func (m *OrderedMap[K, V]) RangeWithIndex(f func(i int, key K, value V) bool) {
+	v := m.version
+	i := 0
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		if !f(i, e.Value.Key, e.Value.Value) {
+			return
+		}
+		m.checkVersion(v)
+		i++
+	}
+}
+
+// RangeErr calls f sequentially for each key and value present in the ordered
+// map starting from the front element. If f returns an error, RangeErr stops
+// the iteration and returns that error. If f structurally modifies the map,
+// RangeErr stops the iteration and returns ErrConcurrentModification.
+func (m *OrderedMap[K, V]) RangeErr(f func(key K, value V) error) error {
+	v := m.version
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		if err := f(e.Value.Key, e.Value.Value); err != nil {
+			return err
+		}
+		if m.version != v {
+			return ErrConcurrentModification
+		}
+	}
+	return nil
+}
+
 // Range calls f sequentially for each key and value present in the ordered map
 // starting from the back element. If f returns false, RangeReverse stops the iteration.
 func (m *OrderedMap[K, V]) RangeReverse(f func(key K, value V) bool) {
+	v := m.version
 	for e := m.l.Back(); e != nil; e = e.Prev() {
 		if !f(e.Value.Key, e.Value.Value) {
 			return
 		}
+		m.checkVersion(v)
+	}
+}
+
+// Find returns the first item, starting from the front element, such that
+// f(key, value) == true.
+//
+// If no item matches, it returns the zero value of Item[K, V]
+// and ok is set to false.
+func (m *OrderedMap[K, V]) Find(f func(key K, value V) bool) (item Item[K, V], ok bool) {
+	v := m.version
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		if f(e.Value.Key, e.Value.Value) {
+			return e.Value, true
+		}
+		m.checkVersion(v)
+	}
+	return item, false
+}
+
+// FindLast returns the last item, starting from the back element, such that
+// f(key, value) == true.
+//
+// If no item matches, it returns the zero value of Item[K, V]
+// and ok is set to false.
+func (m *OrderedMap[K, V]) FindLast(f func(key K, value V) bool) (item Item[K, V], ok bool) {
+	v := m.version
+	for e := m.l.Back(); e != nil; e = e.Prev() {
+		if f(e.Value.Key, e.Value.Value) {
+			return e.Value, true
+		}
+		m.checkVersion(v)
+	}
+	return item, false
+}
+
+// ContainsValue reports whether any item of the map has a value v'
+// such that eq(v', v) == true.
+func (m *OrderedMap[K, V]) ContainsValue(v V, eq func(a, b V) bool) bool {
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		if eq(e.Value.Value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// KeysOf returns, in order, the keys of all items of the map with a value v'
+// such that eq(v', v) == true.
+func (m *OrderedMap[K, V]) KeysOf(v V, eq func(a, b V) bool) []K {
+	keys := []K{}
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		if eq(e.Value.Value, v) {
+			keys = append(keys, e.Value.Key)
+		}
+	}
+	return keys
+}
+
+// Pairs returns an iterator, compatible with iter.Seq2, yielding each pair of
+// adjacent items of the map, starting from the front element.
+func (m *OrderedMap[K, V]) Pairs() func(yield func(a, b Item[K, V]) bool) {
+	return func(yield func(a, b Item[K, V]) bool) {
+		v := m.version
+		for e := m.l.Front(); e != nil && e.Next() != nil; e = e.Next() {
+			if !yield(e.Value, e.Next().Value) {
+				return
+			}
+			m.checkVersion(v)
+		}
+	}
+}
+
+// Windows returns an iterator, compatible with iter.Seq, yielding all windows
+// of n adjacent items of the map, starting from the front element. Each
+// window is a new slice. If the map has fewer than n items, nothing is
+// yielded.
+//
+// It panics if n is less than 1.
+func (m *OrderedMap[K, V]) Windows(n int) func(yield func(window []Item[K, V]) bool) {
+	if n < 1 {
+		panic("orderedmap: window size must be at least 1")
+	}
+	return func(yield func(window []Item[K, V]) bool) {
+		v := m.version
+		first := m.l.Front()
+		last := first
+		for i := 1; i < n && last != nil; i++ {
+			last = last.Next()
+		}
+		for ; last != nil; first, last = first.Next(), last.Next() {
+			window := make([]Item[K, V], 0, n)
+			for e := first; e != last.Next(); e = e.Next() {
+				window = append(window, e.Value)
+			}
+			if !yield(window) {
+				return
+			}
+			m.checkVersion(v)
+		}
 	}
 }
 
@@ -349,11 +1557,35 @@ func (m *OrderedMap[K, V]) Map() map[K]V {
 // in the map. For better performance, you may want to iterate using
 // Prev() and Next() instead.
 func (m *OrderedMap[K, V]) Keys() []K {
-	out := make([]K, 0, m.l.Len())
+	return m.AppendKeys(make([]K, 0, m.l.Len()))
+}
+
+// AppendKeys appends the ordered keys of the map to dst and returns the
+// extended slice. It does not allocate if dst has enough spare capacity.
+func (m *OrderedMap[K, V]) AppendKeys(dst []K) []K {
 	for e := m.l.Front(); e != nil; e = e.Next() {
-		out = append(out, e.Value.Key)
+		dst = append(dst, e.Value.Key)
 	}
-	return out
+	return dst
+}
+
+// Values returns an ordered slice of values of the content of the map.
+//
+// Note that while this function could be used to iterate over the items
+// stored in the ordered map, it allocates a new slice and copy all values
+// in the map. For better performance, you may want to iterate using
+// Prev() and Next() instead.
+func (m *OrderedMap[K, V]) Values() []V {
+	return m.AppendValues(make([]V, 0, m.l.Len()))
+}
+
+// AppendValues appends the ordered values of the map to dst and returns the
+// extended slice. It does not allocate if dst has enough spare capacity.
+func (m *OrderedMap[K, V]) AppendValues(dst []V) []V {
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		dst = append(dst, e.Value.Value)
+	}
+	return dst
 }
 
 // Item returns the a ordered slice of items of the content of the map.
@@ -363,17 +1595,23 @@ func (m *OrderedMap[K, V]) Keys() []K {
 // in the map. For better performance, you may want to iterate using
 // Prev() and Next() instead.
 func (m *OrderedMap[K, V]) Items() []Item[K, V] {
-	out := make([]Item[K, V], 0, m.l.Len())
+	return m.AppendItems(make([]Item[K, V], 0, m.l.Len()))
+}
+
+// AppendItems appends the ordered items of the map to dst and returns the
+// extended slice. It does not allocate if dst has enough spare capacity.
+func (m *OrderedMap[K, V]) AppendItems(dst []Item[K, V]) []Item[K, V] {
 	for e := m.l.Front(); e != nil; e = e.Next() {
-		out = append(out, e.Value)
+		dst = append(dst, e.Value)
 	}
-	return out
+	return dst
 }
 
 // Next returns the item succeeding a given item in the map.
 //
 // If the specified item is missing or it is at the back of the map, ok is set to false.
 func (m *OrderedMap[K, V]) Next(key K) (next Item[K, V], ok bool) {
+	key = m.normalize(key)
 	e, ok := m.m[key]
 	if !ok {
 		return next, false
@@ -389,6 +1627,7 @@ func (m *OrderedMap[K, V]) Next(key K) (next Item[K, V], ok bool) {
 //
 // If the specified item is missing or it is at the front of the map, ok is set to false.
 func (m *OrderedMap[K, V]) Prev(key K) (prev Item[K, V], ok bool) {
+	key = m.normalize(key)
 	e, ok := m.m[key]
 	if !ok {
 		return prev, false
@@ -2,6 +2,7 @@ package orderedmap
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -103,6 +104,94 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestTouch(t *testing.T) {
+	cases := []struct {
+		name      string
+		items     []Item[int, string]
+		key       int
+		wantValue string
+		ok        bool
+		want      []Item[int, string]
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			key:   1,
+			want:  []Item[int, string]{},
+		},
+		{
+			name:      "front key",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}},
+			key:       1,
+			wantValue: "one",
+			ok:        true,
+			want:      []Item[int, string]{{1, "one"}, {2, "two"}},
+		},
+		{
+			name:      "back key",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			key:       3,
+			wantValue: "three",
+			ok:        true,
+			want:      []Item[int, string]{{3, "three"}, {1, "one"}, {2, "two"}},
+		},
+		{
+			name:  "missing key",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}},
+			key:   3,
+			want:  []Item[int, string]{{1, "one"}, {2, "two"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			gotValue, ok := m.Touch(c.key)
+			if ok != c.ok {
+				t.Fatalf("unexpected ok: want: %t, got %t", c.ok, ok)
+			}
+			if gotValue != c.wantValue {
+				t.Fatalf("unexpected value: want: %v, got %v", c.wantValue, gotValue)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestHas(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		key   int
+		want  bool
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			key:   1,
+		},
+		{
+			name:  "existing key",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}},
+			key:   2,
+			want:  true,
+		},
+		{
+			name:  "missing key",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}},
+			key:   3,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if got := m.Has(c.key); got != c.want {
+				t.Fatalf("unexpected result: want: %t, got %t", c.want, got)
+			}
+			checkAll(t, m, c.items)
+		})
+	}
+}
+
 func TestUpdate(t *testing.T) {
 	cases := []struct {
 		name  string
@@ -157,686 +246,2155 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
-func TestPushBack(t *testing.T) {
+func TestSet(t *testing.T) {
 	cases := []struct {
-		name       string
-		items      []Item[int, string]
-		itemToPush Item[int, string]
-		want       []Item[int, string]
-		err        error
+		name     string
+		items    []Item[int, string]
+		key      int
+		value    string
+		want     []Item[int, string]
+		replaced bool
 	}{
 		{
-			name:       "empty",
-			itemToPush: Item[int, string]{1, "one"},
-			want:       []Item[int, string]{{1, "one"}},
+			name:  "empty",
+			items: []Item[int, string]{},
+			key:   1,
+			value: "one",
+			want:  []Item[int, string]{{1, "one"}},
 		},
 		{
-			name:       "existing key",
-			items:      []Item[int, string]{{1, "one"}, {2, "two"}},
-			itemToPush: Item[int, string]{1, "one"},
-			want:       []Item[int, string]{{1, "one"}, {2, "two"}},
-			err:        ErrKeyAlreadyPresent,
+			name:     "existing key",
+			items:    []Item[int, string]{{1, "one"}, {2, "two"}},
+			key:      1,
+			value:    "newone",
+			want:     []Item[int, string]{{1, "newone"}, {2, "two"}},
+			replaced: true,
 		},
 		{
-			name:       "push new key",
-			items:      []Item[int, string]{{1, "one"}, {2, "two"}},
-			itemToPush: Item[int, string]{3, "three"},
-			want:       []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			name:  "missing key",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}},
+			key:   3,
+			value: "three",
+			want:  []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			m := newFromItems(t, c.items)
-			if err := m.PushBack(c.itemToPush.Key, c.itemToPush.Value); !errors.Is(err, c.err) {
-				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			if replaced := m.Set(c.key, c.value); replaced != c.replaced {
+				t.Fatalf("unexpected replaced: want: %v, got %v", c.replaced, replaced)
 			}
 			checkAll(t, m, c.want)
 		})
 	}
 }
 
-func TestPushFront(t *testing.T) {
+func TestSetFront(t *testing.T) {
 	cases := []struct {
-		name       string
-		items      []Item[int, string]
-		itemToPush Item[int, string]
-		want       []Item[int, string]
-		err        error
+		name     string
+		items    []Item[int, string]
+		key      int
+		value    string
+		want     []Item[int, string]
+		replaced bool
 	}{
 		{
-			name:       "empty",
-			itemToPush: Item[int, string]{1, "one"},
-			want:       []Item[int, string]{{1, "one"}},
+			name:  "empty",
+			items: []Item[int, string]{},
+			key:   1,
+			value: "one",
+			want:  []Item[int, string]{{1, "one"}},
 		},
 		{
-			name:       "existing key",
-			items:      []Item[int, string]{{1, "one"}, {2, "two"}},
-			itemToPush: Item[int, string]{1, "one"},
-			want:       []Item[int, string]{{1, "one"}, {2, "two"}},
-			err:        ErrKeyAlreadyPresent,
+			name:     "existing key",
+			items:    []Item[int, string]{{1, "one"}, {2, "two"}},
+			key:      2,
+			value:    "newtwo",
+			want:     []Item[int, string]{{1, "one"}, {2, "newtwo"}},
+			replaced: true,
 		},
 		{
-			name:       "push new key",
-			items:      []Item[int, string]{{2, "two"}, {3, "three"}},
-			itemToPush: Item[int, string]{1, "one"},
-			want:       []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			name:  "missing key",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}},
+			key:   3,
+			value: "three",
+			want:  []Item[int, string]{{3, "three"}, {1, "one"}, {2, "two"}},
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			m := newFromItems(t, c.items)
-			if err := m.PushFront(c.itemToPush.Key, c.itemToPush.Value); !errors.Is(err, c.err) {
-				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			if replaced := m.SetFront(c.key, c.value); replaced != c.replaced {
+				t.Fatalf("unexpected replaced: want: %v, got %v", c.replaced, replaced)
 			}
 			checkAll(t, m, c.want)
 		})
 	}
 }
 
-func TestInsertAfter(t *testing.T) {
+func TestSetBack(t *testing.T) {
+	m := newFromItems(t, []Item[int, string]{{1, "one"}, {2, "two"}})
+	if replaced := m.SetBack(1, "newone"); !replaced {
+		t.Fatal("expected value to be replaced")
+	}
+	if replaced := m.SetBack(3, "three"); replaced {
+		t.Fatal("unexpected replaced value")
+	}
+	checkAll(t, m, []Item[int, string]{{1, "newone"}, {2, "two"}, {3, "three"}})
+}
+
+func TestSetAfter(t *testing.T) {
 	cases := []struct {
-		name         string
-		items        []Item[int, string]
-		itemToInsert Item[int, string]
-		mark         int
-		want         []Item[int, string]
-		err          error
+		name     string
+		items    []Item[int, string]
+		key      int
+		value    string
+		mark     int
+		want     []Item[int, string]
+		replaced bool
+		err      error
 	}{
 		{
-			name:         "empty",
-			itemToInsert: Item[int, string]{1, "one"},
-			mark:         2,
-			want:         []Item[int, string]{},
-			err:          ErrMarkKeyMissing,
-		},
-		{
-			name:         "empty and mark equals key",
-			itemToInsert: Item[int, string]{2, "two"},
-			mark:         2,
-			want:         []Item[int, string]{},
-			err:          ErrMarkKeyMissing,
+			name:  "empty",
+			key:   1,
+			value: "one",
+			mark:  2,
+			want:  []Item[int, string]{},
+			err:   ErrMarkKeyMissing,
 		},
 		{
-			name:         "one item",
-			items:        []Item[int, string]{{1, "one"}},
-			itemToInsert: Item[int, string]{2, "two"},
-			mark:         1,
-			want:         []Item[int, string]{{1, "one"}, {2, "two"}},
+			name:     "existing key",
+			items:    []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			key:      1,
+			value:    "newone",
+			mark:     2,
+			want:     []Item[int, string]{{1, "newone"}, {2, "two"}, {3, "three"}},
+			replaced: true,
 		},
 		{
-			name:         "insert at back",
-			items:        []Item[int, string]{{1, "one"}, {2, "two"}},
-			itemToInsert: Item[int, string]{3, "three"},
-			mark:         2,
-			want:         []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			name:     "existing key and missing mark",
+			items:    []Item[int, string]{{1, "one"}, {2, "two"}},
+			key:      1,
+			value:    "newone",
+			mark:     4,
+			want:     []Item[int, string]{{1, "newone"}, {2, "two"}},
+			replaced: true,
 		},
 		{
-			name:         "insert in the middle",
-			items:        []Item[int, string]{{1, "one"}, {3, "three"}},
-			itemToInsert: Item[int, string]{2, "two"},
-			mark:         1,
-			want:         []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			name:  "insert in the middle",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			key:   4,
+			value: "four",
+			mark:  1,
+			want:  []Item[int, string]{{1, "one"}, {4, "four"}, {2, "two"}, {3, "three"}},
 		},
 		{
-			name:         "mark missing",
-			items:        []Item[int, string]{{1, "one"}, {2, "two"}},
-			itemToInsert: Item[int, string]{3, "three"},
-			mark:         4,
-			want:         []Item[int, string]{{1, "one"}, {2, "two"}},
-			err:          ErrMarkKeyMissing,
+			name:  "insert at the back",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			key:   4,
+			value: "four",
+			mark:  3,
+			want:  []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}},
 		},
 		{
-			name:         "key already present",
-			items:        []Item[int, string]{{1, "one"}, {2, "two"}},
-			itemToInsert: Item[int, string]{2, "two"},
-			mark:         1,
-			want:         []Item[int, string]{{1, "one"}, {2, "two"}},
-			err:          ErrKeyAlreadyPresent,
+			name:  "missing mark",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}},
+			key:   3,
+			value: "three",
+			mark:  4,
+			want:  []Item[int, string]{{1, "one"}, {2, "two"}},
+			err:   ErrMarkKeyMissing,
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			m := newFromItems(t, c.items)
-			if err := m.InsertAfter(c.itemToInsert.Key, c.itemToInsert.Value, c.mark); !errors.Is(err, c.err) {
+			replaced, err := m.SetAfter(c.key, c.value, c.mark)
+			if !errors.Is(err, c.err) {
 				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
 			}
+			if replaced != c.replaced {
+				t.Fatalf("unexpected replaced: want: %v, got %v", c.replaced, replaced)
+			}
 			checkAll(t, m, c.want)
 		})
 	}
 }
 
-func TestInsertBefore(t *testing.T) {
+func TestSetBefore(t *testing.T) {
 	cases := []struct {
-		name         string
-		items        []Item[int, string]
-		itemToInsert Item[int, string]
-		mark         int
-		want         []Item[int, string]
-		err          error
+		name     string
+		items    []Item[int, string]
+		key      int
+		value    string
+		mark     int
+		want     []Item[int, string]
+		replaced bool
+		err      error
 	}{
 		{
-			name:         "empty",
-			itemToInsert: Item[int, string]{1, "one"},
-			mark:         2,
-			want:         []Item[int, string]{},
-			err:          ErrMarkKeyMissing,
-		},
-		{
-			name:         "empty and mark equals key",
-			itemToInsert: Item[int, string]{2, "two"},
-			mark:         2,
-			want:         []Item[int, string]{},
-			err:          ErrMarkKeyMissing,
-		},
-		{
-			name:         "one item",
-			items:        []Item[int, string]{{2, "two"}},
-			itemToInsert: Item[int, string]{1, "one"},
-			mark:         2,
-			want:         []Item[int, string]{{1, "one"}, {2, "two"}},
+			name:  "empty",
+			key:   1,
+			value: "one",
+			mark:  2,
+			want:  []Item[int, string]{},
+			err:   ErrMarkKeyMissing,
 		},
 		{
-			name:         "insert at front",
-			items:        []Item[int, string]{{2, "two"}, {3, "three"}},
-			itemToInsert: Item[int, string]{1, "one"},
-			mark:         2,
-			want:         []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			name:     "existing key",
+			items:    []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			key:      3,
+			value:    "newthree",
+			mark:     1,
+			want:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "newthree"}},
+			replaced: true,
 		},
 		{
-			name:         "insert in the middle",
-			items:        []Item[int, string]{{1, "one"}, {3, "three"}},
-			itemToInsert: Item[int, string]{2, "two"},
-			mark:         3,
-			want:         []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			name:  "insert at the front",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			key:   4,
+			value: "four",
+			mark:  1,
+			want:  []Item[int, string]{{4, "four"}, {1, "one"}, {2, "two"}, {3, "three"}},
 		},
 		{
-			name:         "mark missing",
-			items:        []Item[int, string]{{1, "one"}, {2, "two"}},
-			itemToInsert: Item[int, string]{3, "three"},
-			mark:         4,
-			want:         []Item[int, string]{{1, "one"}, {2, "two"}},
-			err:          ErrMarkKeyMissing,
+			name:  "insert in the middle",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			key:   4,
+			value: "four",
+			mark:  3,
+			want:  []Item[int, string]{{1, "one"}, {2, "two"}, {4, "four"}, {3, "three"}},
 		},
 		{
-			name:         "key already present",
-			items:        []Item[int, string]{{1, "one"}, {2, "two"}},
-			itemToInsert: Item[int, string]{1, "one"},
-			mark:         2,
-			want:         []Item[int, string]{{1, "one"}, {2, "two"}},
-			err:          ErrKeyAlreadyPresent,
+			name:  "missing mark",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}},
+			key:   3,
+			value: "three",
+			mark:  4,
+			want:  []Item[int, string]{{1, "one"}, {2, "two"}},
+			err:   ErrMarkKeyMissing,
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			m := newFromItems(t, c.items)
-			if err := m.InsertBefore(c.itemToInsert.Key, c.itemToInsert.Value, c.mark); !errors.Is(err, c.err) {
+			replaced, err := m.SetBefore(c.key, c.value, c.mark)
+			if !errors.Is(err, c.err) {
 				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
 			}
+			if replaced != c.replaced {
+				t.Fatalf("unexpected replaced: want: %v, got %v", c.replaced, replaced)
+			}
 			checkAll(t, m, c.want)
 		})
 	}
 }
 
-func TestMoveToFront(t *testing.T) {
+func TestGetOrCompute(t *testing.T) {
 	cases := []struct {
-		name      string
-		items     []Item[int, string]
-		keyToMove int
-		want      []Item[int, string]
-		err       error
+		name     string
+		items    []Item[int, string]
+		key      int
+		want     []Item[int, string]
+		value    string
+		computed bool
 	}{
 		{
-			name:      "empty",
-			keyToMove: 1,
-			want:      []Item[int, string]{},
-			err:       ErrKeyMissing,
-		},
-		{
-			name:      "no-op",
-			items:     []Item[int, string]{{1, "one"}},
-			keyToMove: 1,
-			want:      []Item[int, string]{{1, "one"}},
-		},
-		{
-			name:      "move from front",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 1,
-			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-		},
-		{
-			name:      "move from middle",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 2,
-			want:      []Item[int, string]{{2, "two"}, {1, "one"}, {3, "three"}},
+			name:     "empty",
+			items:    []Item[int, string]{},
+			key:      1,
+			want:     []Item[int, string]{{1, "computed"}},
+			value:    "computed",
+			computed: true,
 		},
 		{
-			name:      "move from back",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 3,
-			want:      []Item[int, string]{{3, "three"}, {1, "one"}, {2, "two"}},
+			name:  "existing key",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}},
+			key:   1,
+			want:  []Item[int, string]{{1, "one"}, {2, "two"}},
+			value: "one",
 		},
 		{
-			name:      "missing key",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 4,
-			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			err:       ErrKeyMissing,
+			name:     "missing key",
+			items:    []Item[int, string]{{1, "one"}, {2, "two"}},
+			key:      3,
+			want:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "computed"}},
+			value:    "computed",
+			computed: true,
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			m := newFromItems(t, c.items)
-			if err := m.MoveToFront(c.keyToMove); !errors.Is(err, c.err) {
-				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			computed := false
+			value := m.GetOrCompute(c.key, func() string {
+				computed = true
+				return "computed"
+			})
+			if value != c.value {
+				t.Fatalf("unexpected value: want: %v, got %v", c.value, value)
+			}
+			if computed != c.computed {
+				t.Fatalf("unexpected computed: want: %v, got %v", c.computed, computed)
 			}
 			checkAll(t, m, c.want)
 		})
 	}
 }
 
-func TestMoveToBack(t *testing.T) {
+func TestUpsert(t *testing.T) {
 	cases := []struct {
-		name      string
-		items     []Item[int, string]
-		keyToMove int
-		want      []Item[int, string]
-		err       error
+		name  string
+		items []Item[string, int]
+		key   string
+		value int
+		want  []Item[string, int]
 	}{
 		{
-			name:      "empty",
-			keyToMove: 1,
-			want:      []Item[int, string]{},
-			err:       ErrKeyMissing,
-		},
-		{
-			name:      "no-op",
-			items:     []Item[int, string]{{1, "one"}},
-			keyToMove: 1,
-			want:      []Item[int, string]{{1, "one"}},
-		},
-		{
-			name:      "move from front",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 1,
-			want:      []Item[int, string]{{2, "two"}, {3, "three"}, {1, "one"}},
-		},
-		{
-			name:      "move from middle",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 2,
-			want:      []Item[int, string]{{1, "one"}, {3, "three"}, {2, "two"}},
+			name:  "empty",
+			items: []Item[string, int]{},
+			key:   "a",
+			value: 1,
+			want:  []Item[string, int]{{"a", 1}},
 		},
 		{
-			name:      "move from back",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 3,
-			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			name:  "existing key",
+			items: []Item[string, int]{{"a", 1}, {"b", 2}},
+			key:   "a",
+			value: 10,
+			want:  []Item[string, int]{{"a", 11}, {"b", 2}},
 		},
 		{
-			name:      "missing key",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 4,
-			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			err:       ErrKeyMissing,
+			name:  "missing key",
+			items: []Item[string, int]{{"a", 1}, {"b", 2}},
+			key:   "c",
+			value: 3,
+			want:  []Item[string, int]{{"a", 1}, {"b", 2}, {"c", 3}},
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			m := newFromItems(t, c.items)
-			if err := m.MoveToBack(c.keyToMove); !errors.Is(err, c.err) {
-				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
-			}
+			m.Upsert(c.key, c.value, func(old, new int) int { return old + new })
 			checkAll(t, m, c.want)
 		})
 	}
 }
 
-func TestMoveAfter(t *testing.T) {
+func TestReplaceKey(t *testing.T) {
 	cases := []struct {
-		name      string
-		items     []Item[int, string]
-		keyToMove int
-		mark      int
-		want      []Item[int, string]
-		err       error
+		name   string
+		items  []Item[int, string]
+		oldKey int
+		newKey int
+		want   []Item[int, string]
+		err    error
 	}{
 		{
-			name:      "empty",
-			keyToMove: 1,
-			mark:      2,
-			want:      []Item[int, string]{},
-			err:       ErrKeyMissing,
-		},
-		{
-			name:      "one item",
-			items:     []Item[int, string]{{1, "one"}},
-			keyToMove: 1,
-			mark:      1,
-			want:      []Item[int, string]{{1, "one"}},
-		},
-		{
-			name:      "mark equals key",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 2,
-			mark:      2,
-			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-		},
-		{
-			name:      "move front to middle",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 1,
-			mark:      2,
-			want:      []Item[int, string]{{2, "two"}, {1, "one"}, {3, "three"}},
-		},
-		{
-			name:      "move front to back",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 1,
-			mark:      3,
-			want:      []Item[int, string]{{2, "two"}, {3, "three"}, {1, "one"}},
-		},
-		{
-			name:      "move middle to back",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 2,
-			mark:      3,
-			want:      []Item[int, string]{{1, "one"}, {3, "three"}, {2, "two"}},
+			name:   "empty",
+			items:  []Item[int, string]{},
+			oldKey: 1,
+			newKey: 2,
+			want:   []Item[int, string]{},
+			err:    ErrKeyMissing,
 		},
 		{
-			name:      "move back to middle",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 3,
-			mark:      1,
-			want:      []Item[int, string]{{1, "one"}, {3, "three"}, {2, "two"}},
+			name:   "replace key",
+			items:  []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			oldKey: 2,
+			newKey: 4,
+			want:   []Item[int, string]{{1, "one"}, {4, "two"}, {3, "three"}},
 		},
 		{
-			name:      "move back to back",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 3,
-			mark:      2,
-			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			name:   "same key",
+			items:  []Item[int, string]{{1, "one"}, {2, "two"}},
+			oldKey: 2,
+			newKey: 2,
+			want:   []Item[int, string]{{1, "one"}, {2, "two"}},
 		},
 		{
-			name:      "missing key",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 4,
-			mark:      2,
-			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			err:       ErrKeyMissing,
+			name:   "missing key",
+			items:  []Item[int, string]{{1, "one"}, {2, "two"}},
+			oldKey: 3,
+			newKey: 4,
+			want:   []Item[int, string]{{1, "one"}, {2, "two"}},
+			err:    ErrKeyMissing,
 		},
 		{
-			name:      "missing mark",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 2,
-			mark:      4,
-			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			err:       ErrKeyMissing,
+			name:   "new key already present",
+			items:  []Item[int, string]{{1, "one"}, {2, "two"}},
+			oldKey: 1,
+			newKey: 2,
+			want:   []Item[int, string]{{1, "one"}, {2, "two"}},
+			err:    ErrKeyAlreadyPresent,
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			m := newFromItems(t, c.items)
-			if err := m.MoveAfter(c.keyToMove, c.mark); !errors.Is(err, c.err) {
-				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			if err := m.ReplaceKey(c.oldKey, c.newKey); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected err: want: %v, got %v", c.err, err)
 			}
 			checkAll(t, m, c.want)
 		})
 	}
 }
 
-func TestMoveBefore(t *testing.T) {
+func TestPushItems(t *testing.T) {
 	cases := []struct {
 		name      string
 		items     []Item[int, string]
-		keyToMove int
-		mark      int
-		want      []Item[int, string]
+		push      []Item[int, string]
+		wantFront []Item[int, string]
+		wantBack  []Item[int, string]
 		err       error
 	}{
 		{
 			name:      "empty",
-			keyToMove: 1,
-			mark:      2,
-			want:      []Item[int, string]{},
-			err:       ErrKeyMissing,
+			items:     []Item[int, string]{},
+			push:      []Item[int, string]{{1, "one"}, {2, "two"}},
+			wantFront: []Item[int, string]{{1, "one"}, {2, "two"}},
+			wantBack:  []Item[int, string]{{1, "one"}, {2, "two"}},
 		},
 		{
-			name:      "one item",
+			name:      "no items",
 			items:     []Item[int, string]{{1, "one"}},
-			keyToMove: 1,
-			mark:      1,
-			want:      []Item[int, string]{{1, "one"}},
-		},
-		{
-			name:      "mark equals key",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 2,
-			mark:      2,
-			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-		},
-		{
-			name:      "move back to middle",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 3,
-			mark:      2,
-			want:      []Item[int, string]{{1, "one"}, {3, "three"}, {2, "two"}},
-		},
-		{
-			name:      "move back to front",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 3,
-			mark:      1,
-			want:      []Item[int, string]{{3, "three"}, {1, "one"}, {2, "two"}},
-		},
-		{
-			name:      "move middle to front",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 2,
-			mark:      1,
-			want:      []Item[int, string]{{2, "two"}, {1, "one"}, {3, "three"}},
-		},
-		{
-			name:      "move front to middle",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 1,
-			mark:      3,
-			want:      []Item[int, string]{{2, "two"}, {1, "one"}, {3, "three"}},
+			wantFront: []Item[int, string]{{1, "one"}},
+			wantBack:  []Item[int, string]{{1, "one"}},
 		},
 		{
-			name:      "move front to front",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 1,
-			mark:      2,
-			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			name:      "non empty",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}},
+			push:      []Item[int, string]{{4, "four"}, {3, "three"}},
+			wantFront: []Item[int, string]{{4, "four"}, {3, "three"}, {1, "one"}, {2, "two"}},
+			wantBack:  []Item[int, string]{{1, "one"}, {2, "two"}, {4, "four"}, {3, "three"}},
 		},
 		{
-			name:      "missing key",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 4,
-			mark:      2,
-			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			err:       ErrKeyMissing,
+			name:      "key already present",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}},
+			push:      []Item[int, string]{{3, "three"}, {2, "TWO"}},
+			wantFront: []Item[int, string]{{1, "one"}, {2, "two"}},
+			wantBack:  []Item[int, string]{{1, "one"}, {2, "two"}},
+			err:       ErrKeyAlreadyPresent,
 		},
 		{
-			name:      "missing mark",
-			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			keyToMove: 2,
-			mark:      4,
-			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			err:       ErrKeyMissing,
+			name:      "repeated key",
+			items:     []Item[int, string]{{1, "one"}},
+			push:      []Item[int, string]{{2, "two"}, {3, "three"}, {2, "TWO"}},
+			wantFront: []Item[int, string]{{1, "one"}},
+			wantBack:  []Item[int, string]{{1, "one"}},
+			err:       ErrKeyAlreadyPresent,
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			m := newFromItems(t, c.items)
-			if err := m.MoveBefore(c.keyToMove, c.mark); !errors.Is(err, c.err) {
+			if err := m.PushFrontItems(c.push...); !errors.Is(err, c.err) {
 				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
 			}
-			checkAll(t, m, c.want)
+			checkAll(t, m, c.wantFront)
+
+			m = newFromItems(t, c.items)
+			if err := m.PushBackItems(c.push...); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.wantBack)
 		})
 	}
 }
 
-func TestReverse(t *testing.T) {
+func TestExtendBack(t *testing.T) {
 	cases := []struct {
-		name  string
-		items []Item[int, string]
-		want  []Item[int, string]
+		name   string
+		items  []Item[int, string]
+		other  []Item[int, string]
+		policy DuplicatePolicy
+		want   []Item[int, string]
+		err    error
 	}{
 		{
 			name:  "empty",
 			items: []Item[int, string]{},
+			other: []Item[int, string]{},
 			want:  []Item[int, string]{},
 		},
 		{
-			name:  "one element",
+			name:  "empty other",
 			items: []Item[int, string]{{1, "one"}},
+			other: []Item[int, string]{},
 			want:  []Item[int, string]{{1, "one"}},
 		},
 		{
-			name:  "multiple elements",
-			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			want:  []Item[int, string]{{3, "three"}, {2, "two"}, {1, "one"}},
+			name:  "disjoint",
+			items: []Item[int, string]{{2, "two"}, {1, "one"}},
+			other: []Item[int, string]{{4, "four"}, {3, "three"}},
+			want:  []Item[int, string]{{2, "two"}, {1, "one"}, {4, "four"}, {3, "three"}},
 		},
-	}
-	for _, c := range cases {
-		t.Run(c.name, func(t *testing.T) {
-			m := newFromItems(t, c.items)
+		{
+			name:   "duplicate error",
+			items:  []Item[int, string]{{2, "two"}, {1, "one"}},
+			other:  []Item[int, string]{{3, "three"}, {1, "ONE"}},
+			policy: DuplicateError,
+			want:   []Item[int, string]{{2, "two"}, {1, "one"}},
+			err:    ErrKeyAlreadyPresent,
+		},
+		{
+			name:   "duplicate keep first",
+			items:  []Item[int, string]{{2, "two"}, {1, "one"}},
+			other:  []Item[int, string]{{1, "ONE"}, {3, "three"}, {2, "TWO"}},
+			policy: DuplicateKeepFirst,
+			want:   []Item[int, string]{{2, "two"}, {1, "one"}, {3, "three"}},
+		},
+		{
+			name:   "duplicate keep last",
+			items:  []Item[int, string]{{2, "two"}, {1, "one"}},
+			other:  []Item[int, string]{{1, "ONE"}, {3, "three"}, {2, "TWO"}},
+			policy: DuplicateKeepLast,
+			want:   []Item[int, string]{{2, "TWO"}, {1, "ONE"}, {3, "three"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m, other := newFromItems(t, c.items), newFromItems(t, c.other)
+			if err := m.ExtendBack(other, c.policy); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+			checkAll(t, other, c.other)
+		})
+	}
+}
+
+func TestExtendBackSelf(t *testing.T) {
+	items := []Item[int, string]{{2, "two"}, {1, "one"}}
+	m := newFromItems(t, items)
+	if err := m.ExtendBack(m, DuplicateKeepLast); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, items)
+}
+
+func TestPushBack(t *testing.T) {
+	cases := []struct {
+		name       string
+		items      []Item[int, string]
+		itemToPush Item[int, string]
+		want       []Item[int, string]
+		err        error
+	}{
+		{
+			name:       "empty",
+			itemToPush: Item[int, string]{1, "one"},
+			want:       []Item[int, string]{{1, "one"}},
+		},
+		{
+			name:       "existing key",
+			items:      []Item[int, string]{{1, "one"}, {2, "two"}},
+			itemToPush: Item[int, string]{1, "one"},
+			want:       []Item[int, string]{{1, "one"}, {2, "two"}},
+			err:        ErrKeyAlreadyPresent,
+		},
+		{
+			name:       "push new key",
+			items:      []Item[int, string]{{1, "one"}, {2, "two"}},
+			itemToPush: Item[int, string]{3, "three"},
+			want:       []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := m.PushBack(c.itemToPush.Key, c.itemToPush.Value); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestPushFront(t *testing.T) {
+	cases := []struct {
+		name       string
+		items      []Item[int, string]
+		itemToPush Item[int, string]
+		want       []Item[int, string]
+		err        error
+	}{
+		{
+			name:       "empty",
+			itemToPush: Item[int, string]{1, "one"},
+			want:       []Item[int, string]{{1, "one"}},
+		},
+		{
+			name:       "existing key",
+			items:      []Item[int, string]{{1, "one"}, {2, "two"}},
+			itemToPush: Item[int, string]{1, "one"},
+			want:       []Item[int, string]{{1, "one"}, {2, "two"}},
+			err:        ErrKeyAlreadyPresent,
+		},
+		{
+			name:       "push new key",
+			items:      []Item[int, string]{{2, "two"}, {3, "three"}},
+			itemToPush: Item[int, string]{1, "one"},
+			want:       []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := m.PushFront(c.itemToPush.Key, c.itemToPush.Value); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestInsertAfter(t *testing.T) {
+	cases := []struct {
+		name         string
+		items        []Item[int, string]
+		itemToInsert Item[int, string]
+		mark         int
+		want         []Item[int, string]
+		err          error
+	}{
+		{
+			name:         "empty",
+			itemToInsert: Item[int, string]{1, "one"},
+			mark:         2,
+			want:         []Item[int, string]{},
+			err:          ErrMarkKeyMissing,
+		},
+		{
+			name:         "empty and mark equals key",
+			itemToInsert: Item[int, string]{2, "two"},
+			mark:         2,
+			want:         []Item[int, string]{},
+			err:          ErrMarkKeyMissing,
+		},
+		{
+			name:         "one item",
+			items:        []Item[int, string]{{1, "one"}},
+			itemToInsert: Item[int, string]{2, "two"},
+			mark:         1,
+			want:         []Item[int, string]{{1, "one"}, {2, "two"}},
+		},
+		{
+			name:         "insert at back",
+			items:        []Item[int, string]{{1, "one"}, {2, "two"}},
+			itemToInsert: Item[int, string]{3, "three"},
+			mark:         2,
+			want:         []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+		},
+		{
+			name:         "insert in the middle",
+			items:        []Item[int, string]{{1, "one"}, {3, "three"}},
+			itemToInsert: Item[int, string]{2, "two"},
+			mark:         1,
+			want:         []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+		},
+		{
+			name:         "mark missing",
+			items:        []Item[int, string]{{1, "one"}, {2, "two"}},
+			itemToInsert: Item[int, string]{3, "three"},
+			mark:         4,
+			want:         []Item[int, string]{{1, "one"}, {2, "two"}},
+			err:          ErrMarkKeyMissing,
+		},
+		{
+			name:         "key already present",
+			items:        []Item[int, string]{{1, "one"}, {2, "two"}},
+			itemToInsert: Item[int, string]{2, "two"},
+			mark:         1,
+			want:         []Item[int, string]{{1, "one"}, {2, "two"}},
+			err:          ErrKeyAlreadyPresent,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := m.InsertAfter(c.itemToInsert.Key, c.itemToInsert.Value, c.mark); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestInsertBefore(t *testing.T) {
+	cases := []struct {
+		name         string
+		items        []Item[int, string]
+		itemToInsert Item[int, string]
+		mark         int
+		want         []Item[int, string]
+		err          error
+	}{
+		{
+			name:         "empty",
+			itemToInsert: Item[int, string]{1, "one"},
+			mark:         2,
+			want:         []Item[int, string]{},
+			err:          ErrMarkKeyMissing,
+		},
+		{
+			name:         "empty and mark equals key",
+			itemToInsert: Item[int, string]{2, "two"},
+			mark:         2,
+			want:         []Item[int, string]{},
+			err:          ErrMarkKeyMissing,
+		},
+		{
+			name:         "one item",
+			items:        []Item[int, string]{{2, "two"}},
+			itemToInsert: Item[int, string]{1, "one"},
+			mark:         2,
+			want:         []Item[int, string]{{1, "one"}, {2, "two"}},
+		},
+		{
+			name:         "insert at front",
+			items:        []Item[int, string]{{2, "two"}, {3, "three"}},
+			itemToInsert: Item[int, string]{1, "one"},
+			mark:         2,
+			want:         []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+		},
+		{
+			name:         "insert in the middle",
+			items:        []Item[int, string]{{1, "one"}, {3, "three"}},
+			itemToInsert: Item[int, string]{2, "two"},
+			mark:         3,
+			want:         []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+		},
+		{
+			name:         "mark missing",
+			items:        []Item[int, string]{{1, "one"}, {2, "two"}},
+			itemToInsert: Item[int, string]{3, "three"},
+			mark:         4,
+			want:         []Item[int, string]{{1, "one"}, {2, "two"}},
+			err:          ErrMarkKeyMissing,
+		},
+		{
+			name:         "key already present",
+			items:        []Item[int, string]{{1, "one"}, {2, "two"}},
+			itemToInsert: Item[int, string]{1, "one"},
+			mark:         2,
+			want:         []Item[int, string]{{1, "one"}, {2, "two"}},
+			err:          ErrKeyAlreadyPresent,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := m.InsertBefore(c.itemToInsert.Key, c.itemToInsert.Value, c.mark); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestMoveToFront(t *testing.T) {
+	cases := []struct {
+		name      string
+		items     []Item[int, string]
+		keyToMove int
+		want      []Item[int, string]
+		err       error
+	}{
+		{
+			name:      "empty",
+			keyToMove: 1,
+			want:      []Item[int, string]{},
+			err:       ErrKeyMissing,
+		},
+		{
+			name:      "no-op",
+			items:     []Item[int, string]{{1, "one"}},
+			keyToMove: 1,
+			want:      []Item[int, string]{{1, "one"}},
+		},
+		{
+			name:      "move from front",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 1,
+			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+		},
+		{
+			name:      "move from middle",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 2,
+			want:      []Item[int, string]{{2, "two"}, {1, "one"}, {3, "three"}},
+		},
+		{
+			name:      "move from back",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 3,
+			want:      []Item[int, string]{{3, "three"}, {1, "one"}, {2, "two"}},
+		},
+		{
+			name:      "missing key",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 4,
+			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			err:       ErrKeyMissing,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := m.MoveToFront(c.keyToMove); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestMoveToBack(t *testing.T) {
+	cases := []struct {
+		name      string
+		items     []Item[int, string]
+		keyToMove int
+		want      []Item[int, string]
+		err       error
+	}{
+		{
+			name:      "empty",
+			keyToMove: 1,
+			want:      []Item[int, string]{},
+			err:       ErrKeyMissing,
+		},
+		{
+			name:      "no-op",
+			items:     []Item[int, string]{{1, "one"}},
+			keyToMove: 1,
+			want:      []Item[int, string]{{1, "one"}},
+		},
+		{
+			name:      "move from front",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 1,
+			want:      []Item[int, string]{{2, "two"}, {3, "three"}, {1, "one"}},
+		},
+		{
+			name:      "move from middle",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 2,
+			want:      []Item[int, string]{{1, "one"}, {3, "three"}, {2, "two"}},
+		},
+		{
+			name:      "move from back",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 3,
+			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+		},
+		{
+			name:      "missing key",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 4,
+			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			err:       ErrKeyMissing,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := m.MoveToBack(c.keyToMove); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestMoveAfter(t *testing.T) {
+	cases := []struct {
+		name      string
+		items     []Item[int, string]
+		keyToMove int
+		mark      int
+		want      []Item[int, string]
+		err       error
+	}{
+		{
+			name:      "empty",
+			keyToMove: 1,
+			mark:      2,
+			want:      []Item[int, string]{},
+			err:       ErrKeyMissing,
+		},
+		{
+			name:      "one item",
+			items:     []Item[int, string]{{1, "one"}},
+			keyToMove: 1,
+			mark:      1,
+			want:      []Item[int, string]{{1, "one"}},
+		},
+		{
+			name:      "mark equals key",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 2,
+			mark:      2,
+			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+		},
+		{
+			name:      "move front to middle",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 1,
+			mark:      2,
+			want:      []Item[int, string]{{2, "two"}, {1, "one"}, {3, "three"}},
+		},
+		{
+			name:      "move front to back",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 1,
+			mark:      3,
+			want:      []Item[int, string]{{2, "two"}, {3, "three"}, {1, "one"}},
+		},
+		{
+			name:      "move middle to back",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 2,
+			mark:      3,
+			want:      []Item[int, string]{{1, "one"}, {3, "three"}, {2, "two"}},
+		},
+		{
+			name:      "move back to middle",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 3,
+			mark:      1,
+			want:      []Item[int, string]{{1, "one"}, {3, "three"}, {2, "two"}},
+		},
+		{
+			name:      "move back to back",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 3,
+			mark:      2,
+			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+		},
+		{
+			name:      "missing key",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 4,
+			mark:      2,
+			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			err:       ErrKeyMissing,
+		},
+		{
+			name:      "missing mark",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 2,
+			mark:      4,
+			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			err:       ErrMarkKeyMissing,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := m.MoveAfter(c.keyToMove, c.mark); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestMoveBefore(t *testing.T) {
+	cases := []struct {
+		name      string
+		items     []Item[int, string]
+		keyToMove int
+		mark      int
+		want      []Item[int, string]
+		err       error
+	}{
+		{
+			name:      "empty",
+			keyToMove: 1,
+			mark:      2,
+			want:      []Item[int, string]{},
+			err:       ErrKeyMissing,
+		},
+		{
+			name:      "one item",
+			items:     []Item[int, string]{{1, "one"}},
+			keyToMove: 1,
+			mark:      1,
+			want:      []Item[int, string]{{1, "one"}},
+		},
+		{
+			name:      "mark equals key",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 2,
+			mark:      2,
+			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+		},
+		{
+			name:      "move back to middle",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 3,
+			mark:      2,
+			want:      []Item[int, string]{{1, "one"}, {3, "three"}, {2, "two"}},
+		},
+		{
+			name:      "move back to front",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 3,
+			mark:      1,
+			want:      []Item[int, string]{{3, "three"}, {1, "one"}, {2, "two"}},
+		},
+		{
+			name:      "move middle to front",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 2,
+			mark:      1,
+			want:      []Item[int, string]{{2, "two"}, {1, "one"}, {3, "three"}},
+		},
+		{
+			name:      "move front to middle",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 1,
+			mark:      3,
+			want:      []Item[int, string]{{2, "two"}, {1, "one"}, {3, "three"}},
+		},
+		{
+			name:      "move front to front",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 1,
+			mark:      2,
+			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+		},
+		{
+			name:      "missing key",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 4,
+			mark:      2,
+			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			err:       ErrKeyMissing,
+		},
+		{
+			name:      "missing mark",
+			items:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keyToMove: 2,
+			mark:      4,
+			want:      []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			err:       ErrMarkKeyMissing,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := m.MoveBefore(c.keyToMove, c.mark); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestMoveMarkKeyMissing(t *testing.T) {
+	moves := map[string]func(m *OrderedMap[int, string], key, mark int) error{
+		"MoveAfter":  (*OrderedMap[int, string]).MoveAfter,
+		"MoveBefore": (*OrderedMap[int, string]).MoveBefore,
+	}
+	for name, move := range moves {
+		t.Run(name, func(t *testing.T) {
+			m := newFromItems(t, []Item[int, string]{{1, "one"}, {2, "two"}})
+			// a missing mark is distinguishable from a missing key, but it
+			// is still reported as a missing key
+			err := move(m, 1, 3)
+			if !errors.Is(err, ErrMarkKeyMissing) || !errors.Is(err, ErrKeyMissing) {
+				t.Fatalf("unexpected error: want: %v, got %v", ErrMarkKeyMissing, err)
+			}
+			err = move(m, 3, 1)
+			if !errors.Is(err, ErrKeyMissing) || errors.Is(err, ErrMarkKeyMissing) {
+				t.Fatalf("unexpected error: want: %v, got %v", ErrKeyMissing, err)
+			}
+			err = move(m, 3, 4)
+			if !errors.Is(err, ErrKeyMissing) || errors.Is(err, ErrMarkKeyMissing) {
+				t.Fatalf("unexpected error: want: %v, got %v", ErrKeyMissing, err)
+			}
+			checkAll(t, m, []Item[int, string]{{1, "one"}, {2, "two"}})
+		})
+	}
+}
+
+func TestMoveRange(t *testing.T) {
+	items := []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}, {5, "five"}}
+	cases := []struct {
+		name  string
+		after bool
+		first int
+		last  int
+		mark  int
+		want  []Item[int, string]
+		err   error
+	}{
+		{
+			name:  "after back",
+			after: true,
+			first: 1,
+			last:  2,
+			mark:  5,
+			want:  []Item[int, string]{{3, "three"}, {4, "four"}, {5, "five"}, {1, "one"}, {2, "two"}},
+		},
+		{
+			name:  "after middle",
+			after: true,
+			first: 4,
+			last:  5,
+			mark:  1,
+			want:  []Item[int, string]{{1, "one"}, {4, "four"}, {5, "five"}, {2, "two"}, {3, "three"}},
+		},
+		{
+			name:  "after preceding item",
+			after: true,
+			first: 2,
+			last:  3,
+			mark:  1,
+			want:  items,
+		},
+		{
+			name:  "after last",
+			after: true,
+			first: 2,
+			last:  3,
+			mark:  3,
+			want:  items,
+		},
+		{
+			name:  "before front",
+			first: 3,
+			last:  5,
+			mark:  1,
+			want:  []Item[int, string]{{3, "three"}, {4, "four"}, {5, "five"}, {1, "one"}, {2, "two"}},
+		},
+		{
+			name:  "before middle",
+			first: 1,
+			last:  2,
+			mark:  4,
+			want:  []Item[int, string]{{3, "three"}, {1, "one"}, {2, "two"}, {4, "four"}, {5, "five"}},
+		},
+		{
+			name:  "before following item",
+			first: 1,
+			last:  2,
+			mark:  3,
+			want:  items,
+		},
+		{
+			name:  "before first",
+			first: 2,
+			last:  4,
+			mark:  2,
+			want:  items,
+		},
+		{
+			name:  "single item",
+			first: 5,
+			last:  5,
+			mark:  1,
+			want:  []Item[int, string]{{5, "five"}, {1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}},
+		},
+		{
+			name:  "whole map",
+			after: true,
+			first: 1,
+			last:  5,
+			mark:  5,
+			want:  items,
+		},
+		{
+			name:  "mark within range",
+			after: true,
+			first: 2,
+			last:  4,
+			mark:  3,
+			want:  items,
+			err:   ErrInvalidRange,
+		},
+		{
+			name:  "last precedes first",
+			first: 3,
+			last:  2,
+			mark:  1,
+			want:  items,
+			err:   ErrInvalidRange,
+		},
+		{
+			name:  "missing first",
+			first: 6,
+			last:  2,
+			mark:  1,
+			want:  items,
+			err:   ErrKeyMissing,
+		},
+		{
+			name:  "missing last",
+			first: 1,
+			last:  6,
+			mark:  1,
+			want:  items,
+			err:   ErrKeyMissing,
+		},
+		{
+			name:  "missing mark",
+			first: 1,
+			last:  2,
+			mark:  6,
+			want:  items,
+			err:   ErrMarkKeyMissing,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := New[int, string](WithPositionIndex())
+			m.PushBackItems(items...)
+			var err error
+			if c.after {
+				err = m.MoveRangeAfter(c.first, c.last, c.mark)
+			} else {
+				err = m.MoveRangeBefore(c.first, c.last, c.mark)
+			}
+			if !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			if c.err == ErrKeyMissing && errors.Is(err, ErrMarkKeyMissing) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestMoveBy(t *testing.T) {
+	items := []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}}
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		key   int
+		delta int
+		want  []Item[int, string]
+		err   error
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			key:   1,
+			delta: 1,
+			want:  []Item[int, string]{},
+			err:   ErrKeyMissing,
+		},
+		{
+			name:  "missing key",
+			items: items,
+			key:   5,
+			delta: 1,
+			want:  items,
+			err:   ErrKeyMissing,
+		},
+		{
+			name:  "zero delta",
+			items: items,
+			key:   2,
+			delta: 0,
+			want:  items,
+		},
+		{
+			name:  "move towards back",
+			items: items,
+			key:   1,
+			delta: 2,
+			want:  []Item[int, string]{{2, "two"}, {3, "three"}, {1, "one"}, {4, "four"}},
+		},
+		{
+			name:  "move towards front",
+			items: items,
+			key:   4,
+			delta: -2,
+			want:  []Item[int, string]{{1, "one"}, {4, "four"}, {2, "two"}, {3, "three"}},
+		},
+		{
+			name:  "move past back",
+			items: items,
+			key:   2,
+			delta: 10,
+			want:  []Item[int, string]{{1, "one"}, {3, "three"}, {4, "four"}, {2, "two"}},
+		},
+		{
+			name:  "move past front",
+			items: items,
+			key:   3,
+			delta: -10,
+			want:  []Item[int, string]{{3, "three"}, {1, "one"}, {2, "two"}, {4, "four"}},
+		},
+		{
+			name:  "move back item towards back",
+			items: items,
+			key:   4,
+			delta: 1,
+			want:  items,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := m.MoveBy(c.key, c.delta); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected err: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestClone(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+		},
+		{
+			name:  "multiple items",
+			items: []Item[int, string]{{3, "three"}, {1, "one"}, {2, "two"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			for _, m := range []*OrderedMap[int, string]{
+				newFromItems(t, c.items),
+				newIndexedFromItems(t, c.items),
+			} {
+				clone := m.Clone()
+				checkAll(t, clone, c.items)
+				if (clone.idx == nil) != (m.idx == nil) {
+					t.Fatal("configuration not preserved")
+				}
+
+				// modifying the clone must not modify the original
+				clone.PushFront(4, "four")
+				clone.Delete(1)
+				checkAll(t, m, c.items)
+			}
+		})
+	}
+}
+
+func TestCopiesConfiguration(t *testing.T) {
+	m := New[string, int](WithKeyNormalizer(strings.ToLower), WithPositionIndex())
+	m.Set("a", 1)
+	m.Set("b", 2)
+	m.Set("c", 3)
+	match, rest := m.Partition(func(key string, value int) bool { return value != 2 })
+	left, right, err := m.SplitAt("b")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	indexLeft, indexRight, err := m.SplitAtIndex(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	sub, err := m.SubMap("a", "b")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	copies := map[string]*OrderedMap[string, int]{
+		"Reverse":            m.Reverse(),
+		"Filter":             m.Filter(nil),
+		"Partition match":    match,
+		"Partition rest":     rest,
+		"TakeWhile":          m.TakeWhile(func(key string, value int) bool { return true }),
+		"DropWhile":          m.DropWhile(func(key string, value int) bool { return value == 1 }),
+		"SplitAt left":       left,
+		"SplitAt right":      right,
+		"SplitAtIndex left":  indexLeft,
+		"SplitAtIndex right": indexRight,
+		"SubMap":             sub,
+	}
+	for name, c := range copies {
+		if c.idx == nil {
+			t.Fatalf("%s: position index not configured", name)
+		}
+		item, _ := c.Front()
+		if !c.Has(strings.ToUpper(item.Key)) {
+			t.Fatalf("%s: key normalizer not configured", name)
+		}
+	}
+}
+
+func TestCloneFunc(t *testing.T) {
+	items := []Item[string, []int]{{"b", []int{2}}, {"a", []int{1, 1}}}
+	m := newFromItems(t, items)
+	clone := m.CloneFunc(func(value []int) []int {
+		return append([]int(nil), value...)
+	})
+	checkAll(t, clone, items)
+
+	// modifying the values of the clone must not modify the original
+	v, _ := clone.Get("a")
+	v[0] = 10
+	checkAll(t, m, []Item[string, []int]{{"b", []int{2}}, {"a", []int{1, 1}}})
+	checkAll(t, clone, []Item[string, []int]{{"b", []int{2}}, {"a", []int{10, 1}}})
+}
+
+func TestReverse(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		want  []Item[int, string]
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			want:  []Item[int, string]{},
+		},
+		{
+			name:  "one element",
+			items: []Item[int, string]{{1, "one"}},
+			want:  []Item[int, string]{{1, "one"}},
+		},
+		{
+			name:  "multiple elements",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			want:  []Item[int, string]{{3, "three"}, {2, "two"}, {1, "one"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
 			got := m.Reverse()
 			checkAll(t, got, c.want)
 
-			doubleReverse := m.Reverse().Reverse()
-			checkAll(t, doubleReverse, c.items)
+			doubleReverse := m.Reverse().Reverse()
+			checkAll(t, doubleReverse, c.items)
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	cases := []struct {
+		name   string
+		in     []Item[int, string]
+		filter func(int, string) bool
+		want   []Item[int, string]
+	}{
+		{
+			name: "no filter",
+			in:   []Item[int, string]{{1, "one"}, {2, "two"}},
+			want: []Item[int, string]{{1, "one"}, {2, "two"}},
+		},
+		{
+			name:   "exclude all",
+			in:     []Item[int, string]{{1, "one"}, {2, "two"}},
+			filter: func(_ int, _ string) bool { return false },
+			want:   []Item[int, string]{},
+		},
+		{
+			name:   "include all",
+			in:     []Item[int, string]{{1, "one"}, {2, "two"}},
+			filter: func(_ int, _ string) bool { return true },
+			want:   []Item[int, string]{{1, "one"}, {2, "two"}},
+		},
+		{
+			name:   "full test",
+			in:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			filter: func(k int, _ string) bool { return k%2 == 0 },
+			want:   []Item[int, string]{{2, "two"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.in)
+			got := m.Filter(c.filter)
+			checkAll(t, got, c.want)
+		})
+	}
+}
+
+func TestPartition(t *testing.T) {
+	isKeyEven := func(key int, value string) bool { return key%2 == 0 }
+	cases := []struct {
+		name  string
+		in    []Item[int, string]
+		match []Item[int, string]
+		rest  []Item[int, string]
+	}{
+		{
+			name:  "empty",
+			in:    []Item[int, string]{},
+			match: []Item[int, string]{},
+			rest:  []Item[int, string]{},
+		},
+		{
+			name:  "all match",
+			in:    []Item[int, string]{{2, "two"}, {4, "four"}},
+			match: []Item[int, string]{{2, "two"}, {4, "four"}},
+			rest:  []Item[int, string]{},
+		},
+		{
+			name:  "none match",
+			in:    []Item[int, string]{{1, "one"}, {3, "three"}},
+			match: []Item[int, string]{},
+			rest:  []Item[int, string]{{1, "one"}, {3, "three"}},
+		},
+		{
+			name:  "full test",
+			in:    []Item[int, string]{{4, "four"}, {1, "one"}, {2, "two"}, {3, "three"}},
+			match: []Item[int, string]{{4, "four"}, {2, "two"}},
+			rest:  []Item[int, string]{{1, "one"}, {3, "three"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.in)
+			match, rest := m.Partition(isKeyEven)
+			checkAll(t, match, c.match)
+			checkAll(t, rest, c.rest)
+			checkAll(t, m, c.in)
+		})
+	}
+}
+
+func TestTakeWhileDropWhile(t *testing.T) {
+	isKeySmall := func(key int, value string) bool { return key < 3 }
+	cases := []struct {
+		name string
+		in   []Item[int, string]
+		take []Item[int, string]
+		drop []Item[int, string]
+	}{
+		{
+			name: "empty",
+			in:   []Item[int, string]{},
+			take: []Item[int, string]{},
+			drop: []Item[int, string]{},
+		},
+		{
+			name: "all match",
+			in:   []Item[int, string]{{1, "one"}, {2, "two"}},
+			take: []Item[int, string]{{1, "one"}, {2, "two"}},
+			drop: []Item[int, string]{},
+		},
+		{
+			name: "first does not match",
+			in:   []Item[int, string]{{3, "three"}, {1, "one"}},
+			take: []Item[int, string]{},
+			drop: []Item[int, string]{{3, "three"}, {1, "one"}},
+		},
+		{
+			name: "prefix matches",
+			in:   []Item[int, string]{{2, "two"}, {1, "one"}, {4, "four"}, {0, "zero"}},
+			take: []Item[int, string]{{2, "two"}, {1, "one"}},
+			drop: []Item[int, string]{{4, "four"}, {0, "zero"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.in)
+			checkAll(t, m.TakeWhile(isKeySmall), c.take)
+			checkAll(t, m.DropWhile(isKeySmall), c.drop)
+			checkAll(t, m, c.in)
+		})
+	}
+}
+
+func TestSplitAt(t *testing.T) {
+	items := []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}}
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		key   int
+		left  []Item[int, string]
+		right []Item[int, string]
+		err   error
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			key:   1,
+			err:   ErrKeyMissing,
+		},
+		{
+			name:  "front",
+			items: items,
+			key:   1,
+			left:  []Item[int, string]{},
+			right: items,
+		},
+		{
+			name:  "middle",
+			items: items,
+			key:   2,
+			left:  []Item[int, string]{{1, "one"}},
+			right: []Item[int, string]{{2, "two"}, {3, "three"}},
+		},
+		{
+			name:  "back",
+			items: items,
+			key:   3,
+			left:  []Item[int, string]{{1, "one"}, {2, "two"}},
+			right: []Item[int, string]{{3, "three"}},
+		},
+		{
+			name:  "missing key",
+			items: items,
+			key:   4,
+			err:   ErrKeyMissing,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			left, right, err := m.SplitAt(c.key)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			if err == nil {
+				checkAll(t, left, c.left)
+				checkAll(t, right, c.right)
+			}
+			checkAll(t, m, c.items)
+		})
+	}
+}
+
+func TestSubMap(t *testing.T) {
+	items := []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}}
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		from  int
+		to    int
+		want  []Item[int, string]
+		err   error
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			from:  1,
+			to:    2,
+			err:   ErrKeyMissing,
+		},
+		{
+			name:  "whole map",
+			items: items,
+			from:  1,
+			to:    4,
+			want:  items,
+		},
+		{
+			name:  "inner range",
+			items: items,
+			from:  2,
+			to:    3,
+			want:  []Item[int, string]{{2, "two"}, {3, "three"}},
+		},
+		{
+			name:  "single item",
+			items: items,
+			from:  3,
+			to:    3,
+			want:  []Item[int, string]{{3, "three"}},
+		},
+		{
+			name:  "missing from key",
+			items: items,
+			from:  5,
+			to:    3,
+			err:   ErrKeyMissing,
+		},
+		{
+			name:  "missing to key",
+			items: items,
+			from:  1,
+			to:    5,
+			err:   ErrKeyMissing,
+		},
+		{
+			name:  "invalid range",
+			items: items,
+			from:  3,
+			to:    2,
+			err:   ErrInvalidRange,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			sub, err := m.SubMap(c.from, c.to)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("unexpected err: want: %v, got %v", c.err, err)
+			}
+			if err == nil {
+				checkAll(t, sub, c.want)
+			}
+			checkAll(t, m, c.items)
 		})
 	}
 }
 
-func TestFilter(t *testing.T) {
+func TestRange(t *testing.T) {
 	cases := []struct {
-		name   string
-		in     []Item[int, string]
-		filter func(int, string) bool
-		want   []Item[int, string]
+		name  string
+		items []Item[int, string]
+		want  []Item[int, string]
+		f     func(int, string) bool
 	}{
 		{
-			name: "no filter",
-			in:   []Item[int, string]{{1, "one"}, {2, "two"}},
-			want: []Item[int, string]{{1, "one"}, {2, "two"}},
+			name:  "empty",
+			items: []Item[int, string]{},
+			want:  []Item[int, string]{},
+		},
+		{
+			name:  "full iteration",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}},
+			want:  []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}},
+		},
+		{
+			name:  "stop mid iteration",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}},
+			want:  []Item[int, string]{{1, "one"}, {2, "two"}},
+			f: func(k int, v string) bool {
+				return (k != 2)
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			got := make([]Item[int, string], 0, len(c.want))
+			f := func(k int, v string) bool {
+				got = append(got, Item[int, string]{k, v})
+				return c.f == nil || c.f(k, v)
+			}
+			m.Range(f)
+
+			if diff := cmp.Diff(c.want, got); diff != "" {
+				t.Fatalf("unexpected output (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRangeWithIndex(t *testing.T) {
+	type indexedItem struct {
+		i     int
+		key   int
+		value string
+	}
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		stop  int
+		want  []indexedItem
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			stop:  -1,
+			want:  []indexedItem{},
+		},
+		{
+			name:  "all items",
+			items: []Item[int, string]{{3, "three"}, {1, "one"}, {2, "two"}},
+			stop:  -1,
+			want:  []indexedItem{{0, 3, "three"}, {1, 1, "one"}, {2, 2, "two"}},
+		},
+		{
+			name:  "stop early",
+			items: []Item[int, string]{{3, "three"}, {1, "one"}, {2, "two"}},
+			stop:  1,
+			want:  []indexedItem{{0, 3, "three"}, {1, 1, "one"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			got := []indexedItem{}
+			m.RangeWithIndex(func(i int, key int, value string) bool {
+				got = append(got, indexedItem{i, key, value})
+				return i != c.stop
+			})
+			if diff := cmp.Diff(c.want, got, cmp.AllowUnexported(indexedItem{})); diff != "" {
+				t.Fatalf("unexpected items (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRangeErr(t *testing.T) {
+	errStop := errors.New("stop")
+	cases := []struct {
+		name    string
+		items   []Item[int, string]
+		failKey int
+		want    []Item[int, string]
+		err     error
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			want:  []Item[int, string]{},
+		},
+		{
+			name:  "no error",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			want:  []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+		},
+		{
+			name:    "error",
+			items:   []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			failKey: 2,
+			want:    []Item[int, string]{{1, "one"}, {2, "two"}},
+			err:     errStop,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			got := []Item[int, string]{}
+			err := m.RangeErr(func(key int, value string) error {
+				got = append(got, Item[int, string]{key, value})
+				if key == c.failKey {
+					return errStop
+				}
+				return nil
+			})
+			if !errors.Is(err, c.err) {
+				t.Fatalf("unexpected err: want: %v, got %v", c.err, err)
+			}
+			if diff := cmp.Diff(c.want, got); diff != "" {
+				t.Fatalf("unexpected items (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRangeReverse(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		want  []Item[int, string]
+		f     func(int, string) bool
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			want:  []Item[int, string]{},
+		},
+		{
+			name:  "full iteration",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}},
+			want:  []Item[int, string]{{4, "four"}, {3, "three"}, {2, "two"}, {1, "one"}},
+		},
+		{
+			name:  "stop mid iteration",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}},
+			want:  []Item[int, string]{{4, "four"}, {3, "three"}},
+			f: func(k int, v string) bool {
+				return (k != 3)
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			got := make([]Item[int, string], 0, len(c.want))
+			f := func(k int, v string) bool {
+				got = append(got, Item[int, string]{k, v})
+				return c.f == nil || c.f(k, v)
+			}
+			m.RangeReverse(f)
+
+			if diff := cmp.Diff(c.want, got); diff != "" {
+				t.Fatalf("unexpected output (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	isKeyEven := func(key int, value string) bool { return key%2 == 0 }
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		first Item[int, string]
+		last  Item[int, string]
+		ok    bool
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+		},
+		{
+			name:  "no match",
+			items: []Item[int, string]{{1, "one"}, {3, "three"}},
+		},
+		{
+			name:  "single match",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			first: Item[int, string]{2, "two"},
+			last:  Item[int, string]{2, "two"},
+			ok:    true,
+		},
+		{
+			name:  "multiple matches",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}, {5, "five"}},
+			first: Item[int, string]{2, "two"},
+			last:  Item[int, string]{4, "four"},
+			ok:    true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			first, ok := m.Find(isKeyEven)
+			if ok != c.ok || first != c.first {
+				t.Fatalf("unexpected first item: want: %v (%v), got %v (%v)", c.first, c.ok, first, ok)
+			}
+			last, ok := m.FindLast(isKeyEven)
+			if ok != c.ok || last != c.last {
+				t.Fatalf("unexpected last item: want: %v (%v), got %v (%v)", c.last, c.ok, last, ok)
+			}
+		})
+	}
+}
+
+func TestContainsValue(t *testing.T) {
+	equalFold := func(a, b string) bool { return strings.EqualFold(a, b) }
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		value string
+		keys  []int
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			value: "one",
+			keys:  []int{},
+		},
+		{
+			name:  "no match",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}},
+			value: "three",
+			keys:  []int{},
+		},
+		{
+			name:  "single match",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}},
+			value: "TWO",
+			keys:  []int{2},
+		},
+		{
+			name:  "multiple matches",
+			items: []Item[int, string]{{3, "odd"}, {2, "even"}, {1, "Odd"}, {4, "even"}},
+			value: "odd",
+			keys:  []int{3, 1},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if got, want := m.ContainsValue(c.value, equalFold), len(c.keys) > 0; got != want {
+				t.Fatalf("unexpected result: want: %t, got %t", want, got)
+			}
+			if diff := cmp.Diff(c.keys, m.KeysOf(c.value, equalFold)); diff != "" {
+				t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPairs(t *testing.T) {
+	type pair struct{ A, B Item[int, string] }
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		stop  int
+		want  []pair
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			want:  []pair{},
 		},
 		{
-			name:   "exclude all",
-			in:     []Item[int, string]{{1, "one"}, {2, "two"}},
-			filter: func(_ int, _ string) bool { return false },
-			want:   []Item[int, string]{},
+			name:  "one item",
+			items: []Item[int, string]{{1, "one"}},
+			want:  []pair{},
 		},
 		{
-			name:   "include all",
-			in:     []Item[int, string]{{1, "one"}, {2, "two"}},
-			filter: func(_ int, _ string) bool { return true },
-			want:   []Item[int, string]{{1, "one"}, {2, "two"}},
+			name:  "multiple items",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			want:  []pair{{Item[int, string]{1, "one"}, Item[int, string]{2, "two"}}, {Item[int, string]{2, "two"}, Item[int, string]{3, "three"}}},
 		},
 		{
-			name:   "full test",
-			in:     []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
-			filter: func(k int, _ string) bool { return k%2 == 0 },
-			want:   []Item[int, string]{{2, "two"}},
+			name:  "stop early",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			stop:  1,
+			want:  []pair{{Item[int, string]{1, "one"}, Item[int, string]{2, "two"}}},
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			m := newFromItems(t, c.in)
-			got := m.Filter(c.filter)
-			checkAll(t, got, c.want)
+			m := newFromItems(t, c.items)
+			got := []pair{}
+			m.Pairs()(func(a, b Item[int, string]) bool {
+				got = append(got, pair{a, b})
+				return len(got) != c.stop
+			})
+			if diff := cmp.Diff(c.want, got); diff != "" {
+				t.Fatalf("unexpected pairs (-want +got):\n%s", diff)
+			}
 		})
 	}
 }
 
-func TestRange(t *testing.T) {
+func TestWindows(t *testing.T) {
+	items := []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}}
 	cases := []struct {
 		name  string
 		items []Item[int, string]
-		want  []Item[int, string]
-		f     func(int, string) bool
+		n     int
+		stop  int
+		want  [][]Item[int, string]
 	}{
 		{
 			name:  "empty",
 			items: []Item[int, string]{},
-			want:  []Item[int, string]{},
+			n:     1,
+			want:  [][]Item[int, string]{},
 		},
 		{
-			name:  "full iteration",
-			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}},
-			want:  []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}},
+			name:  "size one",
+			items: items[:2],
+			n:     1,
+			want:  [][]Item[int, string]{{{1, "one"}}, {{2, "two"}}},
 		},
 		{
-			name:  "stop mid iteration",
-			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}},
-			want:  []Item[int, string]{{1, "one"}, {2, "two"}},
-			f: func(k int, v string) bool {
-				return (k != 2)
-			},
+			name:  "size three",
+			items: items,
+			n:     3,
+			want:  [][]Item[int, string]{{{1, "one"}, {2, "two"}, {3, "three"}}, {{2, "two"}, {3, "three"}, {4, "four"}}},
+		},
+		{
+			name:  "size of map",
+			items: items,
+			n:     4,
+			want:  [][]Item[int, string]{items},
+		},
+		{
+			name:  "larger than map",
+			items: items,
+			n:     5,
+			want:  [][]Item[int, string]{},
+		},
+		{
+			name:  "stop early",
+			items: items,
+			n:     2,
+			stop:  1,
+			want:  [][]Item[int, string]{{{1, "one"}, {2, "two"}}},
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			m := newFromItems(t, c.items)
-			got := make([]Item[int, string], 0, len(c.want))
-			f := func(k int, v string) bool {
-				got = append(got, Item[int, string]{k, v})
-				return c.f == nil || c.f(k, v)
-			}
-			m.Range(f)
-
+			got := [][]Item[int, string]{}
+			m.Windows(c.n)(func(window []Item[int, string]) bool {
+				got = append(got, window)
+				return len(got) != c.stop
+			})
 			if diff := cmp.Diff(c.want, got); diff != "" {
-				t.Fatalf("unexpected output (-want +got):\n%s", diff)
+				t.Fatalf("unexpected windows (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestRangeReverse(t *testing.T) {
+func TestWindowsInvalidSize(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	New[int, string]().Windows(0)
+}
+
+func TestAppend(t *testing.T) {
 	cases := []struct {
 		name  string
 		items []Item[int, string]
+		dst   []Item[int, string]
 		want  []Item[int, string]
-		f     func(int, string) bool
 	}{
 		{
-			name:  "empty",
+			name:  "empty map",
 			items: []Item[int, string]{},
-			want:  []Item[int, string]{},
+			dst:   []Item[int, string]{{0, "zero"}},
+			want:  []Item[int, string]{{0, "zero"}},
 		},
 		{
-			name:  "full iteration",
-			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}},
-			want:  []Item[int, string]{{4, "four"}, {3, "three"}, {2, "two"}, {1, "one"}},
+			name:  "nil destination",
+			items: []Item[int, string]{{2, "two"}, {1, "one"}},
+			want:  []Item[int, string]{{2, "two"}, {1, "one"}},
 		},
 		{
-			name:  "stop mid iteration",
-			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}},
-			want:  []Item[int, string]{{4, "four"}, {3, "three"}},
-			f: func(k int, v string) bool {
-				return (k != 3)
-			},
+			name:  "non-empty destination",
+			items: []Item[int, string]{{2, "two"}, {1, "one"}},
+			dst:   []Item[int, string]{{0, "zero"}},
+			want:  []Item[int, string]{{0, "zero"}, {2, "two"}, {1, "one"}},
 		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			m := newFromItems(t, c.items)
-			got := make([]Item[int, string], 0, len(c.want))
-			f := func(k int, v string) bool {
-				got = append(got, Item[int, string]{k, v})
-				return c.f == nil || c.f(k, v)
+			var dstKeys []int
+			var dstValues []string
+			var wantKeys []int
+			var wantValues []string
+			for _, item := range c.dst {
+				dstKeys = append(dstKeys, item.Key)
+				dstValues = append(dstValues, item.Value)
 			}
-			m.RangeReverse(f)
-
-			if diff := cmp.Diff(c.want, got); diff != "" {
-				t.Fatalf("unexpected output (-want +got):\n%s", diff)
+			for _, item := range c.want {
+				wantKeys = append(wantKeys, item.Key)
+				wantValues = append(wantValues, item.Value)
+			}
+			if diff := cmp.Diff(wantKeys, m.AppendKeys(dstKeys)); diff != "" {
+				t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(wantValues, m.AppendValues(dstValues)); diff != "" {
+				t.Fatalf("unexpected values (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(c.want, m.AppendItems(c.dst)); diff != "" {
+				t.Fatalf("unexpected items (-want +got):\n%s", diff)
 			}
 		})
 	}
 }
 
+func TestAppendAllocs(t *testing.T) {
+	m := newFromItems(t, []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}})
+	keys := make([]int, 0, m.Len())
+	values := make([]string, 0, m.Len())
+	items := make([]Item[int, string], 0, m.Len())
+	allocs := testing.AllocsPerRun(100, func() {
+		keys = m.AppendKeys(keys[:0])
+		values = m.AppendValues(values[:0])
+		items = m.AppendItems(items[:0])
+	})
+	if allocs != 0 {
+		t.Fatalf("unexpected allocations: %v", allocs)
+	}
+}
+
 func TestPopFront(t *testing.T) {
 	cases := []struct {
 		name   string
@@ -923,6 +2481,53 @@ func TestPopBack(t *testing.T) {
 	}
 }
 
+func TestDrain(t *testing.T) {
+	cases := []struct {
+		name    string
+		items   []Item[int, string]
+		stop    int
+		drained []Item[int, string]
+		want    []Item[int, string]
+	}{
+		{
+			name:    "empty",
+			items:   []Item[int, string]{},
+			drained: []Item[int, string]{},
+			want:    []Item[int, string]{},
+		},
+		{
+			name:    "drain all",
+			items:   []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			drained: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			want:    []Item[int, string]{},
+		},
+		{
+			name:    "stop early",
+			items:   []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			stop:    2,
+			drained: []Item[int, string]{{1, "one"}, {2, "two"}},
+			want:    []Item[int, string]{{3, "three"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			drained := []Item[int, string]{}
+			m.Drain()(func(key int, value string) bool {
+				if _, ok := m.Get(key); ok {
+					t.Fatalf("key %v still present while being yielded", key)
+				}
+				drained = append(drained, Item[int, string]{key, value})
+				return len(drained) != c.stop
+			})
+			if diff := cmp.Diff(c.drained, drained); diff != "" {
+				t.Fatalf("unexpected drained items (-want +got):\n%s", diff)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	cases := []struct {
 		name        string
@@ -992,6 +2597,204 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestDeleteFunc(t *testing.T) {
+	isKeyEven := func(key int, value string) bool { return key%2 == 0 }
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		f     func(key int, value string) bool
+		want  []Item[int, string]
+		n     int
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			f:     isKeyEven,
+			want:  []Item[int, string]{},
+		},
+		{
+			name:  "delete some",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}},
+			f:     isKeyEven,
+			want:  []Item[int, string]{{1, "one"}, {3, "three"}},
+			n:     2,
+		},
+		{
+			name:  "delete none",
+			items: []Item[int, string]{{1, "one"}, {3, "three"}},
+			f:     isKeyEven,
+			want:  []Item[int, string]{{1, "one"}, {3, "three"}},
+		},
+		{
+			name:  "delete all",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}},
+			f:     func(key int, value string) bool { return true },
+			want:  []Item[int, string]{},
+			n:     2,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if n := m.DeleteFunc(c.f); n != c.n {
+				t.Fatalf("unexpected number of deleted items: want: %d, got %d", c.n, n)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestDedupValues(t *testing.T) {
+	eq := func(a, b string) bool { return a == b }
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		eq    func(a, b string) bool
+		want  []Item[int, string]
+		n     int
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			eq:    eq,
+			want:  []Item[int, string]{},
+		},
+		{
+			name:  "no duplicates",
+			items: []Item[int, string]{{1, "a"}, {2, "b"}},
+			eq:    eq,
+			want:  []Item[int, string]{{1, "a"}, {2, "b"}},
+		},
+		{
+			name:  "duplicates",
+			items: []Item[int, string]{{1, "a"}, {2, "b"}, {3, "a"}, {4, "c"}, {5, "b"}, {6, "a"}},
+			eq:    eq,
+			want:  []Item[int, string]{{1, "a"}, {2, "b"}, {4, "c"}},
+			n:     3,
+		},
+		{
+			name:  "custom equality",
+			items: []Item[int, string]{{1, "a"}, {2, "B"}, {3, "A"}, {4, "b"}},
+			eq:    strings.EqualFold,
+			want:  []Item[int, string]{{1, "a"}, {2, "B"}},
+			n:     2,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if n := m.DedupValues(c.eq); n != c.n {
+				t.Fatalf("unexpected number of deleted items: want: %d, got %d", c.n, n)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestDeleteKeys(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		keys  []int
+		want  []Item[int, string]
+		n     int
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			keys:  []int{1},
+			want:  []Item[int, string]{},
+		},
+		{
+			name:  "no keys",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}},
+			want:  []Item[int, string]{{1, "one"}, {2, "two"}},
+		},
+		{
+			name:  "delete some",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}},
+			keys:  []int{4, 2},
+			want:  []Item[int, string]{{1, "one"}, {3, "three"}},
+			n:     2,
+		},
+		{
+			name:  "missing and repeated keys",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keys:  []int{5, 2, 2},
+			want:  []Item[int, string]{{1, "one"}, {3, "three"}},
+			n:     1,
+		},
+		{
+			name:  "delete all",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}},
+			keys:  []int{1, 2},
+			want:  []Item[int, string]{},
+			n:     2,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if n := m.DeleteKeys(c.keys...); n != c.n {
+				t.Fatalf("unexpected number of deleted items: want: %d, got %d", c.n, n)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestRetainKeys(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		keys  []int
+		want  []Item[int, string]
+		n     int
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			keys:  []int{1},
+			want:  []Item[int, string]{},
+		},
+		{
+			name:  "no keys",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}},
+			want:  []Item[int, string]{},
+			n:     2,
+		},
+		{
+			name:  "retain some",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}},
+			keys:  []int{3, 1},
+			want:  []Item[int, string]{{1, "one"}, {3, "three"}},
+			n:     2,
+		},
+		{
+			name:  "missing and repeated keys",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}},
+			keys:  []int{5, 2, 2},
+			want:  []Item[int, string]{{2, "two"}},
+			n:     2,
+		},
+		{
+			name:  "retain all",
+			items: []Item[int, string]{{1, "one"}, {2, "two"}},
+			keys:  []int{2, 1},
+			want:  []Item[int, string]{{1, "one"}, {2, "two"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if n := m.RetainKeys(c.keys...); n != c.n {
+				t.Fatalf("unexpected number of deleted items: want: %d, got %d", c.n, n)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
 func TestPrev(t *testing.T) {
 	cases := []struct {
 		name  string
@@ -1114,7 +2917,9 @@ func checkAll[K comparable, V any](t *testing.T, om *OrderedMap[K, V], items []I
 	checkFrontBack(t, om, items)
 	checkMapGet(t, om, items)
 	checkKeys(t, om, items)
+	checkValues(t, om, items)
 	checkPrevNext(t, om, items)
+	checkPositionIndex(t, om, items)
 }
 
 // checkMapGet converts items to map and validate all entries are present and return the correct value
@@ -1128,13 +2933,14 @@ func checkMapGet[K comparable, V any](t *testing.T, om *OrderedMap[K, V], items
 	if diff := cmp.Diff(m, om.Map()); diff != "" {
 		t.Fatalf("unexpected map (-want +got):\n%s", diff)
 	}
-	for k, want := range m {
-		got, ok := om.Get(k)
+	// get items in order, so that maps in access order are not reordered
+	for _, item := range items {
+		got, ok := om.Get(item.Key)
 		if !ok {
-			t.Fatalf("key %v not found", k)
+			t.Fatalf("key %v not found", item.Key)
 		}
-		if diff := cmp.Diff(want, got); diff != "" {
-			t.Fatalf("unexpected value for key %v (-want +got):\n%s", k, diff)
+		if diff := cmp.Diff(m[item.Key], got); diff != "" {
+			t.Fatalf("unexpected value for key %v (-want +got):\n%s", item.Key, diff)
 		}
 	}
 }
@@ -1192,6 +2998,18 @@ func checkKeys[K comparable, V any](t *testing.T, om *OrderedMap[K, V], items []
 }
 
 // checkKeys checks the correctness of the Prev() and Next() methods
+func checkValues[K comparable, V any](t *testing.T, om *OrderedMap[K, V], items []Item[K, V]) {
+	t.Helper()
+
+	values := make([]V, 0, len(items))
+	for _, item := range items {
+		values = append(values, item.Value)
+	}
+	if diff := cmp.Diff(values, om.Values()); diff != "" {
+		t.Fatalf("unexpected values (-want +got):\n%s", diff)
+	}
+}
+
 func checkPrevNext[K comparable, V any](t *testing.T, om *OrderedMap[K, V], items []Item[K, V]) {
 	t.Helper()
 
@@ -1224,3 +3042,98 @@ func checkPrevNext[K comparable, V any](t *testing.T, om *OrderedMap[K, V], item
 		}
 	}
 }
+
+func TestWithCapacity(t *testing.T) {
+	m := New[int, int](WithCapacity(10))
+	for i := 0; i < 20; i++ {
+		m.Set(i, i)
+	}
+	items := make([]Item[int, int], 20)
+	for i := range items {
+		items[i] = Item[int, int]{i, i}
+	}
+	checkAll(t, m, items)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	WithCapacity(-1)
+}
+
+func TestCompact(t *testing.T) {
+	var zero OrderedMap[int, int]
+	zero.Compact()
+	if zero.Len() != 0 {
+		t.Fatalf("unexpected length: %d", zero.Len())
+	}
+
+	m := New[int, int](WithPositionIndex())
+	for i := 0; i < 1000; i++ {
+		m.Set(i, i)
+	}
+	items := []Item[int, int]{}
+	for i := 0; i < 1000; i++ {
+		if i%100 == 0 {
+			items = append(items, Item[int, int]{i, i})
+		} else {
+			m.Delete(i)
+		}
+	}
+	// compacting while iterating is allowed
+	m.Range(func(key, value int) bool {
+		m.Compact()
+		return true
+	})
+	checkAll(t, m, items)
+}
+
+func TestClearRetain(t *testing.T) {
+	var zero OrderedMap[int, int]
+	zero.ClearRetain()
+	if zero.Len() != 0 {
+		t.Fatalf("unexpected length: %d", zero.Len())
+	}
+
+	for _, opts := range [][]Option{
+		nil,
+		{WithPositionIndex()},
+		{WithElementPool()},
+	} {
+		m := New[int, int](opts...)
+		for i := 0; i < 100; i++ {
+			m.Set(i, i)
+		}
+		m.ClearRetain()
+		checkAll(t, m, []Item[int, int]{})
+		items := []Item[int, int]{}
+		for i := 0; i < 10; i++ {
+			m.Set(i, -i)
+			items = append(items, Item[int, int]{i, -i})
+		}
+		checkAll(t, m, items)
+	}
+}
+
+func TestClearRetainAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("skipping with the race detector, which makes sync.Pool drop items")
+	}
+	const n = 1000
+	m := New[int, int](WithElementPool())
+	fill := func() {
+		for i := 0; i < n; i++ {
+			m.Set(i, i)
+		}
+	}
+	fill()
+	allocs := testing.AllocsPerRun(10, func() {
+		m.ClearRetain()
+		fill()
+	})
+	// the element pool may be drained by the garbage collector
+	if allocs > n/10 {
+		t.Fatalf("unexpected allocations per refill: %v", allocs)
+	}
+}
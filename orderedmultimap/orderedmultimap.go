@@ -0,0 +1,174 @@
+// Package orderedmultimap implements an ordered multimap using generics.
+//
+// An ordered multimap is an ordered map where each key is associated to an
+// ordered list of values. It preserves both the order in which keys are first
+// inserted and the order in which values are added to each key, which makes
+// it suitable to model, for example, HTTP headers or URL query parameters.
+//
+// This implementation is not safe for concurrent usage.
+package orderedmultimap
+
+import (
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+// MultiMap is an implementation of an ordered multimap.
+//
+// K and V are respectively the types of keys and values.
+type MultiMap[K, V comparable] struct {
+	m *orderedmap.OrderedMap[K, []V]
+	n int
+}
+
+// New returns a new multimap instance.
+func New[K, V comparable]() *MultiMap[K, V] {
+	return &MultiMap[K, V]{m: orderedmap.New[K, []V]()}
+}
+
+// Add appends values to the list of values associated to a key.
+//
+// If the key is not present, it is inserted at the back of the map. If no
+// values are provided, the map is not modified.
+func (m *MultiMap[K, V]) Add(key K, values ...V) {
+	if len(values) == 0 {
+		return
+	}
+	m.m.Upsert(key, append([]V(nil), values...), func(old, new []V) []V {
+		return append(old, new...)
+	})
+	m.n += len(values)
+}
+
+// Set replaces the list of values associated to a key.
+//
+// If the key is already present, its position is preserved. Otherwise, it is
+// inserted at the back of the map. If no values are provided, the key is deleted.
+func (m *MultiMap[K, V]) Set(key K, values ...V) {
+	if len(values) == 0 {
+		m.Delete(key)
+		return
+	}
+	old, _ := m.m.Get(key)
+	m.m.Set(key, append([]V(nil), values...))
+	m.n += len(values) - len(old)
+}
+
+// Get returns the first value associated to a key.
+//
+// If the key is not present in the map, it returns the zero value of V
+// and ok is set to false.
+func (m *MultiMap[K, V]) Get(key K) (value V, ok bool) {
+	values, ok := m.m.Get(key)
+	if !ok {
+		return value, false
+	}
+	return values[0], true
+}
+
+// GetAll returns the ordered list of values associated to a key, or nil if the
+// key is not present.
+//
+// The returned slice must not be modified.
+func (m *MultiMap[K, V]) GetAll(key K) []V {
+	values, _ := m.m.Get(key)
+	return values
+}
+
+// Has reports whether a key is present in the map.
+func (m *MultiMap[K, V]) Has(key K) bool {
+	return m.m.Has(key)
+}
+
+// Delete deletes a key from the map and returns the values deleted.
+//
+// If the key was already missing from the map, ok is set to false.
+func (m *MultiMap[K, V]) Delete(key K) (values []V, ok bool) {
+	values, ok = m.m.Delete(key)
+	m.n -= len(values)
+	return values, ok
+}
+
+// RemoveValue removes all occurrences of a value from the list of values
+// associated to a key and returns the number of values removed. If no values
+// are left, the key is deleted.
+func (m *MultiMap[K, V]) RemoveValue(key K, value V) int {
+	values, ok := m.m.Get(key)
+	if !ok {
+		return 0
+	}
+	kept := make([]V, 0, len(values))
+	for _, v := range values {
+		if v != value {
+			kept = append(kept, v)
+		}
+	}
+	n := len(values) - len(kept)
+	if n == 0 {
+		return 0
+	}
+	if len(kept) == 0 {
+		m.m.Delete(key)
+	} else {
+		m.m.Update(key, kept)
+	}
+	m.n -= n
+	return n
+}
+
+// Len returns the number of keys stored in the map.
+func (m *MultiMap[K, V]) Len() int {
+	return m.m.Len()
+}
+
+// NumValues returns the total number of values stored in the map.
+func (m *MultiMap[K, V]) NumValues() int {
+	return m.n
+}
+
+// Clear empties the map.
+func (m *MultiMap[K, V]) Clear() {
+	m.m.Clear()
+	m.n = 0
+}
+
+// MoveToFront moves an existing key to the front of the map.
+//
+// If the key is not present, then orderedmap.ErrKeyMissing is returned.
+func (m *MultiMap[K, V]) MoveToFront(key K) error {
+	return m.m.MoveToFront(key)
+}
+
+// MoveToBack moves an existing key to the back of the map.
+//
+// If the key is not present, then orderedmap.ErrKeyMissing is returned.
+func (m *MultiMap[K, V]) MoveToBack(key K) error {
+	return m.m.MoveToBack(key)
+}
+
+// Keys returns the ordered list of keys of the map.
+func (m *MultiMap[K, V]) Keys() []K {
+	return m.m.Keys()
+}
+
+// Range calls f sequentially for each key present in the map and its list of
+// values, starting from the front element. If f returns false, Range stops
+// the iteration.
+//
+// The slices of values passed to f must not be modified.
+func (m *MultiMap[K, V]) Range(f func(key K, values []V) bool) {
+	m.m.Range(f)
+}
+
+// RangeAll calls f sequentially for each value present in the map, starting
+// from the values of the front element, in order. If f returns false,
+// RangeAll stops the iteration.
+func (m *MultiMap[K, V]) RangeAll(f func(key K, value V) bool) {
+	m.m.Range(func(key K, values []V) bool {
+		for _, v := range values {
+			if !f(key, v) {
+				return false
+			}
+		}
+		return true
+	})
+}
@@ -0,0 +1,219 @@
+package orderedmultimap
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+func TestAdd(t *testing.T) {
+	m := New[string, string]()
+	m.Add("Accept", "text/html")
+	m.Add("Host", "example.com")
+	m.Add("Accept", "application/json", "*/*")
+	m.Add("Empty")
+	checkAll(t, m, []orderedmap.Item[string, []string]{
+		{Key: "Accept", Value: []string{"text/html", "application/json", "*/*"}},
+		{Key: "Host", Value: []string{"example.com"}},
+	})
+}
+
+func TestSet(t *testing.T) {
+	m := newFromItems(t, []orderedmap.Item[string, []int]{
+		{Key: "a", Value: []int{1, 2}},
+		{Key: "b", Value: []int{3}},
+	})
+	m.Set("a", 4)
+	m.Set("c", 5, 6)
+	checkAll(t, m, []orderedmap.Item[string, []int]{
+		{Key: "a", Value: []int{4}},
+		{Key: "b", Value: []int{3}},
+		{Key: "c", Value: []int{5, 6}},
+	})
+	m.Set("b")
+	checkAll(t, m, []orderedmap.Item[string, []int]{
+		{Key: "a", Value: []int{4}},
+		{Key: "c", Value: []int{5, 6}},
+	})
+}
+
+func TestGet(t *testing.T) {
+	m := newFromItems(t, []orderedmap.Item[string, []int]{{Key: "a", Value: []int{1, 2}}})
+	if value, ok := m.Get("a"); !ok || value != 1 {
+		t.Fatalf("unexpected result: want: 1 (true), got %v (%v)", value, ok)
+	}
+	if value, ok := m.Get("b"); ok || value != 0 {
+		t.Fatalf("unexpected result: want: 0 (false), got %v (%v)", value, ok)
+	}
+	if values := m.GetAll("b"); values != nil {
+		t.Fatalf("unexpected values: %v", values)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	m := newFromItems(t, []orderedmap.Item[string, []int]{
+		{Key: "a", Value: []int{1, 2}},
+		{Key: "b", Value: []int{3}},
+	})
+	values, ok := m.Delete("a")
+	if !ok {
+		t.Fatal("key not deleted")
+	}
+	if diff := cmp.Diff([]int{1, 2}, values); diff != "" {
+		t.Fatalf("unexpected values (-want +got):\n%s", diff)
+	}
+	if _, ok := m.Delete("a"); ok {
+		t.Fatal("deleted missing key")
+	}
+	checkAll(t, m, []orderedmap.Item[string, []int]{{Key: "b", Value: []int{3}}})
+
+	m.Clear()
+	checkAll(t, m, []orderedmap.Item[string, []int]{})
+}
+
+func TestRemoveValue(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []orderedmap.Item[string, []int]
+		key   string
+		value int
+		want  []orderedmap.Item[string, []int]
+		n     int
+	}{
+		{
+			name:  "missing key",
+			items: []orderedmap.Item[string, []int]{{Key: "a", Value: []int{1}}},
+			key:   "b",
+			value: 1,
+			want:  []orderedmap.Item[string, []int]{{Key: "a", Value: []int{1}}},
+		},
+		{
+			name:  "missing value",
+			items: []orderedmap.Item[string, []int]{{Key: "a", Value: []int{1}}},
+			key:   "a",
+			value: 2,
+			want:  []orderedmap.Item[string, []int]{{Key: "a", Value: []int{1}}},
+		},
+		{
+			name: "repeated value",
+			items: []orderedmap.Item[string, []int]{
+				{Key: "a", Value: []int{1, 2, 1, 3}},
+				{Key: "b", Value: []int{1}},
+			},
+			key:   "a",
+			value: 1,
+			want: []orderedmap.Item[string, []int]{
+				{Key: "a", Value: []int{2, 3}},
+				{Key: "b", Value: []int{1}},
+			},
+			n: 2,
+		},
+		{
+			name: "last value",
+			items: []orderedmap.Item[string, []int]{
+				{Key: "a", Value: []int{1}},
+				{Key: "b", Value: []int{1}},
+			},
+			key:   "a",
+			value: 1,
+			want:  []orderedmap.Item[string, []int]{{Key: "b", Value: []int{1}}},
+			n:     1,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if n := m.RemoveValue(c.key, c.value); n != c.n {
+				t.Fatalf("unexpected number of removed values: want: %d, got %d", c.n, n)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestMove(t *testing.T) {
+	m := newFromItems(t, []orderedmap.Item[string, []int]{
+		{Key: "a", Value: []int{1}},
+		{Key: "b", Value: []int{2}},
+		{Key: "c", Value: []int{3}},
+	})
+	if err := m.MoveToFront("c"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.MoveToBack("a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, []orderedmap.Item[string, []int]{
+		{Key: "c", Value: []int{3}},
+		{Key: "b", Value: []int{2}},
+		{Key: "a", Value: []int{1}},
+	})
+}
+
+func TestRangeAll(t *testing.T) {
+	m := newFromItems(t, []orderedmap.Item[string, []int]{
+		{Key: "a", Value: []int{1, 2}},
+		{Key: "b", Value: []int{3, 4}},
+	})
+	got := []orderedmap.Item[string, int]{}
+	m.RangeAll(func(key string, value int) bool {
+		got = append(got, orderedmap.Item[string, int]{Key: key, Value: value})
+		return value < 3
+	})
+	want := []orderedmap.Item[string, int]{
+		{Key: "a", Value: 1},
+		{Key: "a", Value: 2},
+		{Key: "b", Value: 3},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+}
+
+func newFromItems[K, V comparable](t *testing.T, items []orderedmap.Item[K, []V]) *MultiMap[K, V] {
+	t.Helper()
+
+	m := New[K, V]()
+	for _, item := range items {
+		m.Add(item.Key, item.Value...)
+	}
+	return m
+}
+
+func checkAll[K, V comparable](t *testing.T, m *MultiMap[K, V], items []orderedmap.Item[K, []V]) {
+	t.Helper()
+
+	if want, got := len(items), m.Len(); want != got {
+		t.Fatalf("incorrect length: want: %d, got: %d", want, got)
+	}
+	n := 0
+	keys := []K{}
+	for _, item := range items {
+		n += len(item.Value)
+		keys = append(keys, item.Key)
+		if diff := cmp.Diff(item.Value, m.GetAll(item.Key)); diff != "" {
+			t.Fatalf("unexpected values of key %v (-want +got):\n%s", item.Key, diff)
+		}
+		if value, ok := m.Get(item.Key); !ok || value != item.Value[0] {
+			t.Fatalf("unexpected value of key %v: want: %v, got %v (%v)", item.Key, item.Value[0], value, ok)
+		}
+		if !m.Has(item.Key) {
+			t.Fatalf("key %v not found", item.Key)
+		}
+	}
+	if want, got := n, m.NumValues(); want != got {
+		t.Fatalf("incorrect number of values: want: %d, got: %d", want, got)
+	}
+	if diff := cmp.Diff(keys, m.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+	got := []orderedmap.Item[K, []V]{}
+	m.Range(func(key K, values []V) bool {
+		got = append(got, orderedmap.Item[K, []V]{Key: key, Value: values})
+		return true
+	})
+	if diff := cmp.Diff(items, got); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,38 @@
+package orderedmap
+
+import (
+	"runtime"
+	"sync"
+)
+
+// RangeParallel calls f for each key and value present in the ordered map
+// using a pool of workers goroutines. If workers is not positive, the value
+// returned by runtime.GOMAXPROCS(0) is used.
+//
+// Items are dispatched to workers in order starting from the front element,
+// but f may be invoked concurrently and complete in any order. RangeParallel
+// returns after all invocations of f have returned.
+//
+// The map must not be modified until RangeParallel returns, neither by f
+// nor by any other goroutine.
+func (m *OrderedMap[K, V]) RangeParallel(workers int, f func(key K, value V)) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	items := make(chan Item[K, V], workers)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for item := range items {
+				f(item.Key, item.Value)
+			}
+		}()
+	}
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		items <- e.Value
+	}
+	close(items)
+	wg.Wait()
+}
@@ -0,0 +1,63 @@
+package orderedmap
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRangeParallel(t *testing.T) {
+	cases := []struct {
+		name    string
+		n       int
+		workers int
+	}{
+		{"empty", 0, 4},
+		{"one worker", 100, 1},
+		{"multiple workers", 1000, 8},
+		{"default workers", 1000, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := New[int, int]()
+			for i := 0; i < c.n; i++ {
+				m.PushBack(i, 2*i)
+			}
+			var mu sync.Mutex
+			seen := make(map[int]int, c.n)
+			m.RangeParallel(c.workers, func(key, value int) {
+				mu.Lock()
+				defer mu.Unlock()
+				seen[key] = value
+			})
+			if len(seen) != c.n {
+				t.Fatalf("unexpected number of items: want: %d, got %d", c.n, len(seen))
+			}
+			for k, v := range seen {
+				if v != 2*k {
+					t.Fatalf("unexpected value for key %d: want: %d, got %d", k, 2*k, v)
+				}
+			}
+		})
+	}
+}
+
+func TestRangeParallelConcurrency(t *testing.T) {
+	const workers = 4
+	m := New[int, int]()
+	for i := 0; i < workers; i++ {
+		m.PushBack(i, i)
+	}
+	// each invocation blocks until all workers are running concurrently
+	var running int32
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	m.RangeParallel(workers, func(key, value int) {
+		atomic.AddInt32(&running, 1)
+		wg.Done()
+		wg.Wait()
+	})
+	if running != workers {
+		t.Fatalf("unexpected number of invocations: want: %d, got %d", workers, running)
+	}
+}
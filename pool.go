@@ -0,0 +1,31 @@
+package orderedmap
+
+import (
+	"sync"
+
+	"github.com/lorenzosaino/go-orderedmap/list"
+)
+
+// WithElementPool configures the map to recycle the internal elements holding
+// its items through a sync.Pool: elements of deleted or evicted items are
+// reused by later insertions instead of being allocated. This reduces the
+// garbage generated by workloads with high insertion and deletion churn,
+// such as queues and LRU caches.
+//
+// Since elements are reused, an Entry must not be used after its item has
+// been deleted, as it may refer to an item inserted later, and a Cursor
+// positioned at a deleted item must be repositioned before being used again.
+// Copies of the map, such as those returned by Clone, have their own pool.
+func WithElementPool() Option {
+	return func(o *options) {
+		o.elementPool = true
+	}
+}
+
+func newElementPool[K comparable, V any]() *sync.Pool {
+	return &sync.Pool{
+		New: func() any {
+			return new(list.Element[Item[K, V]])
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package orderedmap
+
+import (
+	"testing"
+)
+
+func TestWithElementPool(t *testing.T) {
+	m := New[int, string](WithElementPool(), WithPositionIndex())
+	for i := 0; i < 10; i++ {
+		m.Set(i, "v")
+	}
+	for i := 0; i < 10; i += 2 {
+		m.Delete(i)
+	}
+	for i := 10; i < 15; i++ {
+		if i%2 == 0 {
+			m.PushFront(i, "front")
+		} else {
+			m.Set(i, "back")
+		}
+	}
+	checkAll(t, m, []Item[int, string]{
+		{14, "front"}, {12, "front"}, {10, "front"},
+		{1, "v"}, {3, "v"}, {5, "v"}, {7, "v"}, {9, "v"},
+		{11, "back"}, {13, "back"},
+	})
+
+	clone := m.Clone()
+	clone.Delete(1)
+	m.Delete(3)
+	checkAll(t, clone, []Item[int, string]{
+		{14, "front"}, {12, "front"}, {10, "front"},
+		{3, "v"}, {5, "v"}, {7, "v"}, {9, "v"},
+		{11, "back"}, {13, "back"},
+	})
+	checkAll(t, m, []Item[int, string]{
+		{14, "front"}, {12, "front"}, {10, "front"},
+		{1, "v"}, {5, "v"}, {7, "v"}, {9, "v"},
+		{11, "back"}, {13, "back"},
+	})
+}
+
+func TestWithElementPoolAllocs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	m := New[int, int](WithElementPool())
+	for i := 0; i < 100; i++ {
+		m.Set(i, i)
+	}
+	i := 100
+	allocs := testing.AllocsPerRun(1000, func() {
+		m.Delete(i - 100)
+		m.Set(i, i)
+		i++
+	})
+	// the only allocations left are those of the Go map, if any
+	if allocs >= 1 {
+		t.Fatalf("unexpected allocations per operation: %v", allocs)
+	}
+}
+
+func benchmarkChurn(b *testing.B, opts ...Option) {
+	m := New[int, int](opts...)
+	for i := 0; i < 1000; i++ {
+		m.Set(i, i)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 1000; i < b.N+1000; i++ {
+		m.Delete(i - 1000)
+		m.Set(i, i)
+	}
+}
+
+func BenchmarkChurn(b *testing.B) {
+	benchmarkChurn(b)
+}
+
+func BenchmarkChurnElementPool(b *testing.B) {
+	benchmarkChurn(b, WithElementPool())
+}
+
+func benchmarkLRU(b *testing.B, opts ...Option) {
+	m := New[int, int](append(opts, WithMaxEntries[int, int](1000, EvictLRU, nil))...)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// keys cycle over twice the capacity, so each access evicts a key
+		key := i % 2000
+		if _, ok := m.Get(key); !ok {
+			m.Set(key, i)
+		}
+	}
+}
+
+func BenchmarkLRU(b *testing.B) {
+	benchmarkLRU(b)
+}
+
+func BenchmarkLRUElementPool(b *testing.B) {
+	benchmarkLRU(b, WithElementPool())
+}
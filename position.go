@@ -0,0 +1,200 @@
+package orderedmap
+
+import (
+	"github.com/lorenzosaino/go-orderedmap/internal/seqtree"
+	"github.com/lorenzosaino/go-orderedmap/list"
+)
+
+// WithPositionIndex configures the ordered map to maintain an order-statistic
+// index of its items, so that IndexOf, GetAt, InsertAt and RemoveAt run in
+// O(log n) instead of O(n) time.
+//
+// This comes at the cost of additional memory and of making all operations
+// modifying the order of the map, including insertions and removals, run in
+// O(log n) instead of O(1) time.
+func WithPositionIndex() Option {
+	return func(o *options) {
+		o.positionIndex = true
+	}
+}
+
+// IndexOf returns the position of a key in the map, starting from 0 for the
+// front item.
+//
+// If the key is not present in the map, it returns -1 and ok is set to false.
+// It runs in O(n) time, or O(log n) if the map has been created with
+// WithPositionIndex.
+func (m *OrderedMap[K, V]) IndexOf(key K) (i int, ok bool) {
+	key = m.normalize(key)
+	el, ok := m.m[key]
+	if !ok {
+		return -1, false
+	}
+	if m.idx != nil {
+		return m.idx.indexOf(el), true
+	}
+	for e := m.l.Front(); e != el; e = e.Next() {
+		i++
+	}
+	return i, true
+}
+
+// GetAt returns the item at position i of the map, starting from 0 for the
+// front item.
+//
+// If i is out of range, it returns the zero value of Item[K, V] and ok is set
+// to false. It runs in O(n) time, walking from the nearest end of the map,
+// or O(log n) if the map has been created with WithPositionIndex.
+func (m *OrderedMap[K, V]) GetAt(i int) (item Item[K, V], ok bool) {
+	if el := m.elementAt(i); el != nil {
+		return el.Value, true
+	}
+	return item, false
+}
+
+// InsertAt inserts a new key and value at position i of the map, shifting
+// the item at that position, if any, and all following items back by one.
+// i must be in the range [0, m.Len()], where m.Len() inserts at the back.
+//
+// It returns ErrKeyAlreadyPresent if the key to be inserted is already present
+// and ErrIndexOutOfRange if i is out of range. It runs in O(n) time,
+// or O(log n) if the map has been created with WithPositionIndex.
+func (m *OrderedMap[K, V]) InsertAt(i int, key K, value V) error {
+	key = m.normalize(key)
+	m.beforeWrite()
+	if _, ok := m.m[key]; ok {
+		return ErrKeyAlreadyPresent
+	}
+	if i == m.l.Len() {
+		m.insert(Item[K, V]{key, value}, nil)
+		return nil
+	}
+	mark := m.elementAt(i)
+	if mark == nil {
+		return ErrIndexOutOfRange
+	}
+	m.insert(Item[K, V]{key, value}, mark)
+	return nil
+}
+
+// RemoveAt removes the item at position i of the map and returns it.
+//
+// If i is out of range, it returns the zero value of Item[K, V] and ok is set
+// to false. It runs in O(n) time, or O(log n) if the map has been created
+// with WithPositionIndex.
+func (m *OrderedMap[K, V]) RemoveAt(i int) (item Item[K, V], ok bool) {
+	m.beforeWrite()
+	el := m.elementAt(i)
+	if el == nil {
+		return item, false
+	}
+	return m.remove(el), true
+}
+
+// SplitAtIndex splits the ordered map into two copies: left, including the
+// items at positions [0, i), and right, including the items at positions
+// [i, m.Len()). i must be in the range [0, m.Len()]. Both have the same
+// configuration as the map, as copies returned by Clone.
+//
+// It returns ErrIndexOutOfRange if i is out of range.
+func (m *OrderedMap[K, V]) SplitAtIndex(i int) (left, right *OrderedMap[K, V], err error) {
+	if i < 0 || i > m.Len() {
+		return nil, nil, ErrIndexOutOfRange
+	}
+	left, right = m.splitAt(m.elementAt(i))
+	return left, right, nil
+}
+
+// elementAt returns the element at position i of the list,
+// or nil if i is out of range.
+func (m *OrderedMap[K, V]) elementAt(i int) *list.Element[Item[K, V]] {
+	n := m.l.Len()
+	if i < 0 || i >= n {
+		return nil
+	}
+	if m.idx != nil {
+		return m.idx.at(i)
+	}
+	if i < n/2 {
+		el := m.l.Front()
+		for ; i > 0; i-- {
+			el = el.Next()
+		}
+		return el
+	}
+	el := m.l.Back()
+	for i = n - 1 - i; i > 0; i-- {
+		el = el.Prev()
+	}
+	return el
+}
+
+// positionIndex is an order-statistic index of the elements of a list.
+type positionIndex[K comparable, V any] struct {
+	tree  seqtree.Tree[*list.Element[Item[K, V]]]
+	nodes map[*list.Element[Item[K, V]]]*seqtree.Node[*list.Element[Item[K, V]]]
+}
+
+// newPositionIndex returns an empty position index.
+func newPositionIndex[K comparable, V any]() *positionIndex[K, V] {
+	return &positionIndex[K, V]{
+		nodes: make(map[*list.Element[Item[K, V]]]*seqtree.Node[*list.Element[Item[K, V]]]),
+	}
+}
+
+// indexOf returns the position of an element.
+func (p *positionIndex[K, V]) indexOf(el *list.Element[Item[K, V]]) int {
+	return p.tree.Index(p.nodes[el])
+}
+
+// at returns the element at position i, or nil if i is out of range.
+func (p *positionIndex[K, V]) at(i int) *list.Element[Item[K, V]] {
+	if n := p.tree.At(i); n != nil {
+		return n.Value
+	}
+	return nil
+}
+
+// insert indexes an element inserted immediately before mark,
+// or at the back of the list if mark is nil.
+func (p *positionIndex[K, V]) insert(el, mark *list.Element[Item[K, V]]) {
+	i := p.tree.Len()
+	if mark != nil {
+		i = p.indexOf(mark)
+	}
+	p.nodes[el] = p.tree.InsertAt(i, el)
+}
+
+// move reindexes an element moved immediately before mark,
+// or to the back of the list if mark is nil.
+func (p *positionIndex[K, V]) move(el, mark *list.Element[Item[K, V]]) {
+	n := p.nodes[el]
+	i := p.tree.Len() - 1
+	if mark != nil {
+		i = p.indexOf(mark)
+		if p.tree.Index(n) < i {
+			i--
+		}
+	}
+	p.tree.Move(n, i)
+}
+
+// remove removes an element from the index.
+func (p *positionIndex[K, V]) remove(el *list.Element[Item[K, V]]) {
+	p.tree.Remove(p.nodes[el])
+	delete(p.nodes, el)
+}
+
+// rebuild rebuilds the index from the current order of the list.
+func (p *positionIndex[K, V]) rebuild(l *list.List[Item[K, V]]) {
+	p.clear()
+	for el := l.Front(); el != nil; el = el.Next() {
+		p.nodes[el] = p.tree.InsertAt(p.tree.Len(), el)
+	}
+}
+
+// clear removes all elements from the index.
+func (p *positionIndex[K, V]) clear() {
+	p.tree.Clear()
+	p.nodes = make(map[*list.Element[Item[K, V]]]*seqtree.Node[*list.Element[Item[K, V]]])
+}
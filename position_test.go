@@ -0,0 +1,428 @@
+package orderedmap
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+func TestGetAt(t *testing.T) {
+	items := []Item[int, string]{{5, "five"}, {3, "three"}, {1, "one"}, {4, "four"}, {2, "two"}}
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		i     int
+		want  Item[int, string]
+		ok    bool
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			i:     0,
+		},
+		{
+			name:  "front",
+			items: items,
+			i:     0,
+			want:  Item[int, string]{5, "five"},
+			ok:    true,
+		},
+		{
+			name:  "first half",
+			items: items,
+			i:     1,
+			want:  Item[int, string]{3, "three"},
+			ok:    true,
+		},
+		{
+			name:  "middle",
+			items: items,
+			i:     2,
+			want:  Item[int, string]{1, "one"},
+			ok:    true,
+		},
+		{
+			name:  "second half",
+			items: items,
+			i:     3,
+			want:  Item[int, string]{4, "four"},
+			ok:    true,
+		},
+		{
+			name:  "back",
+			items: items,
+			i:     4,
+			want:  Item[int, string]{2, "two"},
+			ok:    true,
+		},
+		{
+			name:  "negative index",
+			items: items,
+			i:     -1,
+		},
+		{
+			name:  "index out of range",
+			items: items,
+			i:     5,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			item, ok := m.GetAt(c.i)
+			if ok != c.ok {
+				t.Fatalf("unexpected ok: want: %v, got %v", c.ok, ok)
+			}
+			if item != c.want {
+				t.Fatalf("unexpected item: want: %v, got %v", c.want, item)
+			}
+		})
+	}
+}
+
+func TestInsertAt(t *testing.T) {
+	items := []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}}
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		i     int
+		key   int
+		value string
+		want  []Item[int, string]
+		err   error
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			i:     0,
+			key:   4,
+			value: "four",
+			want:  []Item[int, string]{{4, "four"}},
+		},
+		{
+			name:  "front",
+			items: items,
+			i:     0,
+			key:   4,
+			value: "four",
+			want:  []Item[int, string]{{4, "four"}, {1, "one"}, {2, "two"}, {3, "three"}},
+		},
+		{
+			name:  "middle",
+			items: items,
+			i:     2,
+			key:   4,
+			value: "four",
+			want:  []Item[int, string]{{1, "one"}, {2, "two"}, {4, "four"}, {3, "three"}},
+		},
+		{
+			name:  "back",
+			items: items,
+			i:     3,
+			key:   4,
+			value: "four",
+			want:  []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}},
+		},
+		{
+			name:  "index out of range",
+			items: items,
+			i:     4,
+			key:   4,
+			value: "four",
+			want:  items,
+			err:   ErrIndexOutOfRange,
+		},
+		{
+			name:  "negative index",
+			items: items,
+			i:     -1,
+			key:   4,
+			value: "four",
+			want:  items,
+			err:   ErrIndexOutOfRange,
+		},
+		{
+			name:  "key already present",
+			items: items,
+			i:     0,
+			key:   3,
+			value: "three",
+			want:  items,
+			err:   ErrKeyAlreadyPresent,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := m.InsertAt(c.i, c.key, c.value); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected err: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestRemoveAt(t *testing.T) {
+	items := []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}}
+	cases := []struct {
+		name    string
+		items   []Item[int, string]
+		i       int
+		removed Item[int, string]
+		ok      bool
+		want    []Item[int, string]
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			i:     0,
+			want:  []Item[int, string]{},
+		},
+		{
+			name:    "front",
+			items:   items,
+			i:       0,
+			removed: Item[int, string]{1, "one"},
+			ok:      true,
+			want:    []Item[int, string]{{2, "two"}, {3, "three"}},
+		},
+		{
+			name:    "middle",
+			items:   items,
+			i:       1,
+			removed: Item[int, string]{2, "two"},
+			ok:      true,
+			want:    []Item[int, string]{{1, "one"}, {3, "three"}},
+		},
+		{
+			name:    "back",
+			items:   items,
+			i:       2,
+			removed: Item[int, string]{3, "three"},
+			ok:      true,
+			want:    []Item[int, string]{{1, "one"}, {2, "two"}},
+		},
+		{
+			name:  "index out of range",
+			items: items,
+			i:     3,
+			want:  items,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			removed, ok := m.RemoveAt(c.i)
+			if ok != c.ok {
+				t.Fatalf("unexpected ok: want: %v, got %v", c.ok, ok)
+			}
+			if removed != c.removed {
+				t.Fatalf("unexpected removed item: want: %v, got %v", c.removed, removed)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestIndexOf(t *testing.T) {
+	items := []Item[int, string]{{5, "five"}, {3, "three"}, {1, "one"}}
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		key   int
+		i     int
+		ok    bool
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			key:   1,
+			i:     -1,
+		},
+		{
+			name:  "front",
+			items: items,
+			key:   5,
+			i:     0,
+			ok:    true,
+		},
+		{
+			name:  "back",
+			items: items,
+			key:   1,
+			i:     2,
+			ok:    true,
+		},
+		{
+			name:  "missing key",
+			items: items,
+			key:   2,
+			i:     -1,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			for _, m := range []*OrderedMap[int, string]{
+				newFromItems(t, c.items),
+				newIndexedFromItems(t, c.items),
+			} {
+				i, ok := m.IndexOf(c.key)
+				if ok != c.ok {
+					t.Fatalf("unexpected ok: want: %v, got %v", c.ok, ok)
+				}
+				if i != c.i {
+					t.Fatalf("unexpected index: want: %v, got %v", c.i, i)
+				}
+			}
+		})
+	}
+}
+
+// TestPositionIndex applies random operations to a map with a position index
+// and to a map without and verifies that they stay consistent.
+func TestPositionIndex(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	want := New[int, int]()
+	got := New[int, int](WithPositionIndex())
+	randomKey := func() int { return rnd.Intn(64) }
+	randomIndex := func() int { return rnd.Intn(want.Len() + 1) }
+	less := func(a, b Item[int, int]) bool { return a.Value < b.Value }
+	cmp := func(a, b Item[int, int]) int { return a.Key - b.Key }
+	ops := []func(m *OrderedMap[int, int], key, mark, i int){
+		func(m *OrderedMap[int, int], key, mark, i int) { m.PushFront(key, i) },
+		func(m *OrderedMap[int, int], key, mark, i int) { m.PushBack(key, i) },
+		func(m *OrderedMap[int, int], key, mark, i int) { m.InsertAfter(key, i, mark) },
+		func(m *OrderedMap[int, int], key, mark, i int) { m.InsertBefore(key, i, mark) },
+		func(m *OrderedMap[int, int], key, mark, i int) { m.InsertAt(i, key, i) },
+		func(m *OrderedMap[int, int], key, mark, i int) { m.InsertSorted(key, i, cmp) },
+		func(m *OrderedMap[int, int], key, mark, i int) { m.Set(key, i) },
+		func(m *OrderedMap[int, int], key, mark, i int) { m.MoveToFront(key) },
+		func(m *OrderedMap[int, int], key, mark, i int) { m.MoveToBack(key) },
+		func(m *OrderedMap[int, int], key, mark, i int) { m.MoveAfter(key, mark) },
+		func(m *OrderedMap[int, int], key, mark, i int) { m.MoveBefore(key, mark) },
+		func(m *OrderedMap[int, int], key, mark, i int) { m.MoveBy(key, i-mark) },
+		func(m *OrderedMap[int, int], key, mark, i int) { m.ReplaceKey(key, mark) },
+		func(m *OrderedMap[int, int], key, mark, i int) { m.Delete(key) },
+		func(m *OrderedMap[int, int], key, mark, i int) { m.PopFront() },
+		func(m *OrderedMap[int, int], key, mark, i int) { m.PopBack() },
+		func(m *OrderedMap[int, int], key, mark, i int) { m.RemoveAt(i) },
+		func(m *OrderedMap[int, int], key, mark, i int) {
+			m.DeleteFunc(func(k, v int) bool { return k%7 == mark%7 })
+		},
+		func(m *OrderedMap[int, int], key, mark, i int) { m.Sort(less) },
+		func(m *OrderedMap[int, int], key, mark, i int) {
+			if key == 0 {
+				m.Clear()
+			}
+		},
+	}
+	for n := 0; n < 5000; n++ {
+		op := ops[rnd.Intn(len(ops))]
+		key, mark, i := randomKey(), randomKey(), randomIndex()
+		op(want, key, mark, i)
+		op(got, key, mark, i)
+		if n%100 == 0 {
+			checkAll(t, got, want.Items())
+		}
+	}
+	checkAll(t, got, want.Items())
+}
+
+func newIndexedFromItems[K comparable, V any](t *testing.T, items []Item[K, V]) *OrderedMap[K, V] {
+	m := New[K, V](WithPositionIndex())
+	for _, item := range items {
+		if err := m.PushBack(item.Key, item.Value); err != nil {
+			t.Fatalf("error inserting key %v: %v", item.Key, err)
+		}
+	}
+	return m
+}
+
+// checkPositionIndex validates the position index, if any
+func checkPositionIndex[K comparable, V any](t *testing.T, om *OrderedMap[K, V], items []Item[K, V]) {
+	t.Helper()
+	if om.idx == nil {
+		return
+	}
+	if want, got := len(items), om.idx.tree.Len(); want != got {
+		t.Fatalf("incorrect index length: want: %d, got: %d", want, got)
+	}
+	if want, got := len(items), len(om.idx.nodes); want != got {
+		t.Fatalf("incorrect index length: want: %d, got: %d", want, got)
+	}
+	for i, item := range items {
+		if got, ok := om.GetAt(i); !ok || got.Key != item.Key {
+			t.Fatalf("incorrect item at %d: want: %v, got: %v", i, item, got)
+		}
+		if got, ok := om.IndexOf(item.Key); !ok || got != i {
+			t.Fatalf("incorrect index of key %v: want: %d, got: %d", item.Key, i, got)
+		}
+	}
+}
+
+func TestSplitAtIndex(t *testing.T) {
+	items := []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}}
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		i     int
+		left  []Item[int, string]
+		right []Item[int, string]
+		err   error
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			i:     0,
+			left:  []Item[int, string]{},
+			right: []Item[int, string]{},
+		},
+		{
+			name:  "front",
+			items: items,
+			i:     0,
+			left:  []Item[int, string]{},
+			right: items,
+		},
+		{
+			name:  "middle",
+			items: items,
+			i:     2,
+			left:  []Item[int, string]{{1, "one"}, {2, "two"}},
+			right: []Item[int, string]{{3, "three"}},
+		},
+		{
+			name:  "back",
+			items: items,
+			i:     3,
+			left:  items,
+			right: []Item[int, string]{},
+		},
+		{
+			name:  "negative index",
+			items: items,
+			i:     -1,
+			err:   ErrIndexOutOfRange,
+		},
+		{
+			name:  "index out of range",
+			items: items,
+			i:     4,
+			err:   ErrIndexOutOfRange,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			left, right, err := m.SplitAtIndex(c.i)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			if err == nil {
+				checkAll(t, left, c.left)
+				checkAll(t, right, c.right)
+			}
+			checkAll(t, m, c.items)
+		})
+	}
+}
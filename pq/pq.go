@@ -0,0 +1,215 @@
+// Package pq implements a keyed priority queue using generics.
+//
+// A priority queue returns its items in order of priority, and additionally
+// indexes them by key, so that the value and priority of any item can be
+// looked up, updated or removed in O(1) or O(log n) time, for example to
+// cancel a scheduled job by its ID.
+//
+// Items are stored in an orderedmap.OrderedMap, which provides lookup by key
+// and keeps them in insertion order, and in a binary heap ordering them by
+// priority. Items with the same priority are returned in insertion order.
+//
+// This implementation is not safe for concurrent usage.
+package pq
+
+import (
+	"container/heap"
+
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+// Item is an item of a priority queue.
+type Item[K comparable, V any, P any] struct {
+	Key      K
+	Value    V
+	Priority P
+}
+
+// Queue is a keyed priority queue, returning first the item with the lowest
+// priority.
+//
+// K, V and P are respectively the types of keys, values and priorities.
+//
+// The zero value is not usable: queues must be created with New or NewFunc.
+type Queue[K comparable, V any, P any] struct {
+	m *orderedmap.OrderedMap[K, *entry[K, V, P]]
+	h entryHeap[K, V, P]
+	// seq is the sequence number of the next item pushed
+	seq uint64
+}
+
+// entry is an item stored in the queue together with its position in the
+// heap and its sequence number, which orders items with the same priority.
+type entry[K comparable, V any, P any] struct {
+	item  Item[K, V, P]
+	index int
+	seq   uint64
+}
+
+// New returns a new empty priority queue whose priorities are compared with
+// the < operator.
+func New[K comparable, V any, P orderedmap.Ordered]() *Queue[K, V, P] {
+	return NewFunc[K, V](func(a, b P) int {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	})
+}
+
+// NewFunc returns a new empty priority queue whose priorities are compared
+// with cmp, which must return a negative number if a has lower priority than
+// b, a positive number if a has higher priority than b and zero if they have
+// the same priority.
+func NewFunc[K comparable, V any, P any](cmp func(a, b P) int) *Queue[K, V, P] {
+	return &Queue[K, V, P]{
+		m: orderedmap.New[K, *entry[K, V, P]](),
+		h: entryHeap[K, V, P]{cmp: cmp},
+	}
+}
+
+// Len returns the number of items in the queue.
+func (q *Queue[K, V, P]) Len() int {
+	return len(q.h.entries)
+}
+
+// Push inserts a new item in the queue.
+//
+// It returns orderedmap.ErrKeyAlreadyPresent if the key is already present.
+func (q *Queue[K, V, P]) Push(key K, value V, priority P) error {
+	e := &entry[K, V, P]{item: Item[K, V, P]{key, value, priority}, seq: q.seq}
+	if err := q.m.PushBack(key, e); err != nil {
+		return err
+	}
+	q.seq++
+	heap.Push(&q.h, e)
+	return nil
+}
+
+// Get returns the item of a key.
+//
+// If the key is not present, ok is set to false.
+func (q *Queue[K, V, P]) Get(key K) (item Item[K, V, P], ok bool) {
+	e, ok := q.m.Get(key)
+	if !ok {
+		return item, false
+	}
+	return e.item, true
+}
+
+// Has reports whether a key is present in the queue.
+func (q *Queue[K, V, P]) Has(key K) bool {
+	return q.m.Has(key)
+}
+
+// UpdatePriority changes the priority of the item of a key. Items whose
+// priority is updated are ordered after the items with the same priority
+// already in the queue.
+//
+// It returns orderedmap.ErrKeyMissing if the key is not present.
+func (q *Queue[K, V, P]) UpdatePriority(key K, priority P) error {
+	e, ok := q.m.Get(key)
+	if !ok {
+		return orderedmap.ErrKeyMissing
+	}
+	e.item.Priority = priority
+	e.seq = q.seq
+	q.seq++
+	heap.Fix(&q.h, e.index)
+	return nil
+}
+
+// UpdateValue changes the value of the item of a key, without changing its
+// priority.
+//
+// It returns orderedmap.ErrKeyMissing if the key is not present.
+func (q *Queue[K, V, P]) UpdateValue(key K, value V) error {
+	e, ok := q.m.Get(key)
+	if !ok {
+		return orderedmap.ErrKeyMissing
+	}
+	e.item.Value = value
+	return nil
+}
+
+// Peek returns the item with the lowest priority without removing it.
+//
+// If the queue is empty, ok is set to false.
+func (q *Queue[K, V, P]) Peek() (item Item[K, V, P], ok bool) {
+	if len(q.h.entries) == 0 {
+		return item, false
+	}
+	return q.h.entries[0].item, true
+}
+
+// PopMin removes and returns the item with the lowest priority.
+//
+// If the queue is empty, ok is set to false.
+func (q *Queue[K, V, P]) PopMin() (item Item[K, V, P], ok bool) {
+	if len(q.h.entries) == 0 {
+		return item, false
+	}
+	e := heap.Pop(&q.h).(*entry[K, V, P])
+	q.m.Delete(e.item.Key)
+	return e.item, true
+}
+
+// Remove removes the item of a key and returns it.
+//
+// If the key is not present, ok is set to false.
+func (q *Queue[K, V, P]) Remove(key K) (item Item[K, V, P], ok bool) {
+	e, ok := q.m.Delete(key)
+	if !ok {
+		return item, false
+	}
+	heap.Remove(&q.h, e.index)
+	return e.item, true
+}
+
+// Keys returns the keys of all items in the order in which they have been
+// pushed.
+func (q *Queue[K, V, P]) Keys() []K {
+	return q.m.Keys()
+}
+
+// entryHeap implements heap.Interface ordering entries by priority and
+// sequence number.
+type entryHeap[K comparable, V any, P any] struct {
+	entries []*entry[K, V, P]
+	cmp     func(a, b P) int
+}
+
+func (h *entryHeap[K, V, P]) Len() int {
+	return len(h.entries)
+}
+
+func (h *entryHeap[K, V, P]) Less(i, j int) bool {
+	a, b := h.entries[i], h.entries[j]
+	if c := h.cmp(a.item.Priority, b.item.Priority); c != 0 {
+		return c < 0
+	}
+	return a.seq < b.seq
+}
+
+func (h *entryHeap[K, V, P]) Swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+	h.entries[i].index = i
+	h.entries[j].index = j
+}
+
+func (h *entryHeap[K, V, P]) Push(x any) {
+	e := x.(*entry[K, V, P])
+	e.index = len(h.entries)
+	h.entries = append(h.entries, e)
+}
+
+func (h *entryHeap[K, V, P]) Pop() any {
+	n := len(h.entries) - 1
+	e := h.entries[n]
+	h.entries[n] = nil
+	h.entries = h.entries[:n]
+	return e
+}
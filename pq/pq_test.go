@@ -0,0 +1,171 @@
+package pq
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+// popAll pops all items of q in order.
+func popAll[K comparable, V any, P any](q *Queue[K, V, P]) []Item[K, V, P] {
+	items := []Item[K, V, P]{}
+	for {
+		item, ok := q.PopMin()
+		if !ok {
+			return items
+		}
+		items = append(items, item)
+	}
+}
+
+func TestQueue(t *testing.T) {
+	q := New[string, string, int]()
+	if _, ok := q.Peek(); ok {
+		t.Fatal("unexpected item in empty queue")
+	}
+	for _, item := range []Item[string, string, int]{
+		{Key: "c", Value: "job c", Priority: 3},
+		{Key: "a", Value: "job a", Priority: 1},
+		{Key: "b1", Value: "job b1", Priority: 2},
+		{Key: "b2", Value: "job b2", Priority: 2},
+	} {
+		if err := q.Push(item.Key, item.Value, item.Priority); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := q.Push("a", "other", 0); !errors.Is(err, orderedmap.ErrKeyAlreadyPresent) {
+		t.Fatalf("unexpected error: want: %v, got %v", orderedmap.ErrKeyAlreadyPresent, err)
+	}
+	if q.Len() != 4 {
+		t.Fatalf("unexpected length: %d", q.Len())
+	}
+	if diff := cmp.Diff([]string{"c", "a", "b1", "b2"}, q.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+	if item, ok := q.Peek(); !ok || item.Key != "a" {
+		t.Fatalf("unexpected peeked item: %v, %v", item, ok)
+	}
+	if item, ok := q.Get("b1"); !ok || item.Value != "job b1" || item.Priority != 2 {
+		t.Fatalf("unexpected item: %v, %v", item, ok)
+	}
+	if !q.Has("c") || q.Has("d") {
+		t.Fatal("unexpected keys present")
+	}
+	want := []Item[string, string, int]{
+		{Key: "a", Value: "job a", Priority: 1},
+		{Key: "b1", Value: "job b1", Priority: 2},
+		{Key: "b2", Value: "job b2", Priority: 2},
+		{Key: "c", Value: "job c", Priority: 3},
+	}
+	if diff := cmp.Diff(want, popAll(q)); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+	if q.Len() != 0 || len(q.Keys()) != 0 {
+		t.Fatalf("unexpected items after popping all: %v", q.Keys())
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	q := New[int, string, float64]()
+	q.Push(1, "one", 1)
+	q.Push(2, "two", 2)
+	q.Push(3, "three", 3)
+	if err := q.UpdatePriority(3, 0.5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := q.UpdatePriority(1, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := q.UpdateValue(2, "TWO"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := q.UpdatePriority(4, 0); !errors.Is(err, orderedmap.ErrKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", orderedmap.ErrKeyMissing, err)
+	}
+	if err := q.UpdateValue(4, "four"); !errors.Is(err, orderedmap.ErrKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", orderedmap.ErrKeyMissing, err)
+	}
+	// updated items follow those with the same priority
+	want := []Item[int, string, float64]{
+		{Key: 3, Value: "three", Priority: 0.5},
+		{Key: 2, Value: "TWO", Priority: 2},
+		{Key: 1, Value: "one", Priority: 2},
+	}
+	if diff := cmp.Diff(want, popAll(q)); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	q := New[int, string, int]()
+	for i := 0; i < 5; i++ {
+		q.Push(i, "job", 10-i)
+	}
+	if item, ok := q.Remove(2); !ok || item.Key != 2 || item.Priority != 8 {
+		t.Fatalf("unexpected removed item: %v, %v", item, ok)
+	}
+	if _, ok := q.Remove(2); ok {
+		t.Fatal("unexpected removal of missing key")
+	}
+	var keys []int
+	for _, item := range popAll(q) {
+		keys = append(keys, item.Key)
+	}
+	if diff := cmp.Diff([]int{4, 3, 1, 0}, keys); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+}
+
+func TestNewFunc(t *testing.T) {
+	// a max-priority queue
+	q := NewFunc[string, struct{}](func(a, b int) int { return b - a })
+	q.Push("low", struct{}{}, 1)
+	q.Push("high", struct{}{}, 10)
+	q.Push("mid", struct{}{}, 5)
+	var keys []string
+	for _, item := range popAll(q) {
+		keys = append(keys, item.Key)
+	}
+	if diff := cmp.Diff([]string{"high", "mid", "low"}, keys); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	q := New[int, int, int]()
+	want := map[int]int{}
+	for i := 0; i < 1000; i++ {
+		key := r.Intn(100)
+		switch r.Intn(3) {
+		case 0:
+			if q.Push(key, key, r.Intn(50)) == nil {
+				want[key] = 0
+			}
+		case 1:
+			if q.UpdatePriority(key, r.Intn(50)) == nil {
+				want[key] = 0
+			}
+		default:
+			q.Remove(key)
+			delete(want, key)
+		}
+	}
+	if q.Len() != len(want) {
+		t.Fatalf("unexpected length: want: %d, got %d", len(want), q.Len())
+	}
+	items := popAll(q)
+	if !sort.SliceIsSorted(items, func(i, j int) bool { return items[i].Priority < items[j].Priority }) {
+		t.Fatalf("items not sorted by priority: %v", items)
+	}
+	for _, item := range items {
+		delete(want, item.Key)
+	}
+	if len(want) != 0 {
+		t.Fatalf("missing keys: %v", want)
+	}
+}
@@ -0,0 +1,71 @@
+package orderedmap
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// FromQuery parses a URL-encoded query string into an ordered map.
+//
+// It behaves like url.ParseQuery except that parameters are kept in the order
+// in which they first appear in query. Values of parameters appearing more
+// than once are appended to the same key in order. As with url.ParseQuery,
+// it returns the first decoding error encountered, if any, along with all the
+// parameters that could be decoded.
+func FromQuery(query string) (*OrderedMap[string, []string], error) {
+	m := New[string, []string]()
+	var err error
+	for query != "" {
+		var key string
+		key, query, _ = strings.Cut(query, "&")
+		if strings.Contains(key, ";") {
+			err = errors.New("invalid semicolon separator in query")
+			continue
+		}
+		if key == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(key, "=")
+		key, err1 := url.QueryUnescape(key)
+		if err1 != nil {
+			if err == nil {
+				err = err1
+			}
+			continue
+		}
+		value, err1 = url.QueryUnescape(value)
+		if err1 != nil {
+			if err == nil {
+				err = err1
+			}
+			continue
+		}
+		if el, ok := m.m[key]; ok {
+			el.Value.Value = append(el.Value.Value, value)
+			continue
+		}
+		m.PushBack(key, []string{value})
+	}
+	return m, err
+}
+
+// EncodeQuery encodes m into URL-encoded form ("bar=baz&foo=quux").
+//
+// Unlike url.Values.Encode, parameters are not sorted by key but written in
+// the order of m. Multiple values of the same key are written in order.
+func EncodeQuery(m *OrderedMap[string, []string]) string {
+	var sb strings.Builder
+	for el := m.l.Front(); el != nil; el = el.Next() {
+		key := url.QueryEscape(el.Value.Key)
+		for _, v := range el.Value.Value {
+			if sb.Len() > 0 {
+				sb.WriteByte('&')
+			}
+			sb.WriteString(key)
+			sb.WriteByte('=')
+			sb.WriteString(url.QueryEscape(v))
+		}
+	}
+	return sb.String()
+}
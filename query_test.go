@@ -0,0 +1,104 @@
+package orderedmap
+
+import (
+	"testing"
+)
+
+func TestFromQuery(t *testing.T) {
+	cases := []struct {
+		name  string
+		query string
+		want  []Item[string, []string]
+		fails bool
+	}{
+		{
+			name:  "empty",
+			query: "",
+			want:  []Item[string, []string]{},
+		},
+		{
+			name:  "multiple parameters",
+			query: "z=1&a=2&m=3",
+			want:  []Item[string, []string]{{"z", []string{"1"}}, {"a", []string{"2"}}, {"m", []string{"3"}}},
+		},
+		{
+			name:  "repeated parameters",
+			query: "b=1&a=2&b=3",
+			want:  []Item[string, []string]{{"b", []string{"1", "3"}}, {"a", []string{"2"}}},
+		},
+		{
+			name:  "escaped parameters",
+			query: "q=a+b%26c&x%3Dy=&flag",
+			want:  []Item[string, []string]{{"q", []string{"a b&c"}}, {"x=y", []string{""}}, {"flag", []string{""}}},
+		},
+		{
+			name:  "empty segments",
+			query: "&a=1&&b=2&",
+			want:  []Item[string, []string]{{"a", []string{"1"}}, {"b", []string{"2"}}},
+		},
+		{
+			name:  "invalid escape",
+			query: "a=1&b=%zz&c=3",
+			want:  []Item[string, []string]{{"a", []string{"1"}}, {"c", []string{"3"}}},
+			fails: true,
+		},
+		{
+			name:  "semicolon separator",
+			query: "a=1;b=2&c=3",
+			want:  []Item[string, []string]{{"c", []string{"3"}}},
+			fails: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m, err := FromQuery(c.query)
+			if c.fails != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestEncodeQuery(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[string, []string]
+		want  string
+	}{
+		{
+			name:  "empty",
+			items: []Item[string, []string]{},
+			want:  "",
+		},
+		{
+			name:  "multiple parameters",
+			items: []Item[string, []string]{{"z", []string{"1"}}, {"a", []string{"2", "3"}}, {"m", nil}, {"b", []string{""}}},
+			want:  "z=1&a=2&a=3&b=",
+		},
+		{
+			name:  "escaped parameters",
+			items: []Item[string, []string]{{"x=y", []string{"a b&c"}}},
+			want:  "x%3Dy=a+b%26c",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if got := EncodeQuery(m); got != c.want {
+				t.Fatalf("unexpected query: want: %q, got %q", c.want, got)
+			}
+		})
+	}
+}
+
+func TestQueryRoundTrip(t *testing.T) {
+	query := "sig=abc&b=2&b=3&a=1&empty="
+	m, err := FromQuery(query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := EncodeQuery(m); got != query {
+		t.Fatalf("unexpected query: want: %q, got %q", query, got)
+	}
+}
@@ -0,0 +1,65 @@
+package orderedmap
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// RCU holds an ordered map that can be read concurrently without locking
+// while being updated, using the read-copy-update technique.
+//
+// Readers obtain an immutable version of the map with Snapshot. Writers,
+// serialized by Update, modify a copy of the current version and atomically
+// publish it as the new version, while readers keep using the version they
+// obtained. Snapshot is wait-free, while each Update copies the map in O(n)
+// time, which makes RCU suitable for maps read often and updated rarely,
+// such as routing tables or configuration.
+//
+// The zero value is not usable: an RCU must be created with NewRCU.
+type RCU[K comparable, V any] struct {
+	mu sync.Mutex
+	v  atomic.Value // *OrderedMap[K, V]
+}
+
+// NewRCU returns a new RCU whose initial version is a copy of m, as returned
+// by Clone.
+//
+// Versions are frozen with Freeze, so that they can be read concurrently even
+// if the map is in access order or has a loader: Get then neither moves the
+// keys accessed nor inserts the values loaded.
+func NewRCU[K comparable, V any](m *OrderedMap[K, V]) *RCU[K, V] {
+	r := &RCU[K, V]{}
+	r.publish(m.Clone())
+	return r
+}
+
+// Snapshot returns the current version of the map.
+//
+// The map returned is frozen. It is safe for concurrent reads and is not
+// affected by subsequent updates.
+func (r *RCU[K, V]) Snapshot() *OrderedMap[K, V] {
+	return r.v.Load().(*OrderedMap[K, V])
+}
+
+// Update invokes f with a copy of the current version of the map and, if f
+// returns nil, publishes it as the new version. If f returns an error, the
+// current version is left unchanged and the error is returned.
+//
+// Updates are serialized, so that f is never invoked concurrently. The map
+// passed to f must not be retained after f returns.
+func (r *RCU[K, V]) Update(f func(m *OrderedMap[K, V]) error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m := r.Snapshot().Clone()
+	if err := f(m); err != nil {
+		return err
+	}
+	r.publish(m)
+	return nil
+}
+
+// publish freezes m and makes it the current version.
+func (r *RCU[K, V]) publish(m *OrderedMap[K, V]) {
+	m.Freeze()
+	r.v.Store(m)
+}
@@ -0,0 +1,105 @@
+package orderedmap
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestRCU(t *testing.T) {
+	m := newFromItems(t, []Item[int, string]{{1, "one"}})
+	r := NewRCU(m)
+
+	// the initial version is a copy
+	m.PushBack(2, "two")
+	before := r.Snapshot()
+	checkAll(t, before, []Item[int, string]{{1, "one"}})
+
+	if err := r.Update(func(m *OrderedMap[int, string]) error {
+		return m.PushFront(0, "zero")
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, r.Snapshot(), []Item[int, string]{{0, "zero"}, {1, "one"}})
+	// snapshots are not affected by updates
+	checkAll(t, before, []Item[int, string]{{1, "one"}})
+
+	errUpdate := errors.New("update failed")
+	if err := r.Update(func(m *OrderedMap[int, string]) error {
+		m.Clear()
+		return errUpdate
+	}); !errors.Is(err, errUpdate) {
+		t.Fatalf("unexpected error: want: %v, got %v", errUpdate, err)
+	}
+	checkAll(t, r.Snapshot(), []Item[int, string]{{0, "zero"}, {1, "one"}})
+}
+
+func TestRCUConcurrent(t *testing.T) {
+	r := NewRCU(New[int, int]())
+	const n = 200
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < n; j++ {
+				s := r.Snapshot()
+				// each version holds a contiguous sequence of keys
+				for k := 0; k < s.Len(); k++ {
+					if v, ok := s.Get(k); !ok || v != k {
+						t.Errorf("unexpected value of key %d: %v (%v)", k, v, ok)
+						return
+					}
+				}
+			}
+		}()
+	}
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < n/2; j++ {
+				r.Update(func(m *OrderedMap[int, int]) error {
+					return m.PushBack(m.Len(), m.Len())
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	if got := r.Snapshot().Len(); got != n {
+		t.Fatalf("unexpected length: want: %d, got %d", n, got)
+	}
+}
+
+// TestRCUAccessOrder verifies that versions of maps in access order and with
+// a loader can be read concurrently, which the race detector checks.
+func TestRCUAccessOrder(t *testing.T) {
+	m := New[int, int](WithAccessOrder(), WithLoader(func(ctx context.Context, key int) (int, error) {
+		return key, nil
+	}))
+	items := []Item[int, int]{}
+	for i := 0; i < 10; i++ {
+		m.Set(i, i)
+		items = append(items, Item[int, int]{i, i})
+	}
+	r := NewRCU(m)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s := r.Snapshot()
+			for j := 0; j < 100; j++ {
+				// missing keys are loaded but not inserted
+				if v, ok := s.Get(j % 20); !ok || v != j%20 {
+					t.Errorf("unexpected value of key %d: %v (%v)", j%20, v, ok)
+					return
+				}
+				s.Range(func(key, value int) bool { return true })
+			}
+		}()
+	}
+	wg.Wait()
+	checkAll(t, r.Snapshot(), items)
+}
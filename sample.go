@@ -0,0 +1,35 @@
+package orderedmap
+
+import "math/rand"
+
+// Sample returns a uniformly random sample of n distinct items of the map,
+// in the order in which they appear in the map, using r as the source of
+// randomness, or the default source of the math/rand package if r is nil.
+//
+// If n is greater than or equal to the length of the map, all items are
+// returned. Items are selected in a single pass over the map, without
+// copying its items first, in O(m.Len()) time.
+func (m *OrderedMap[K, V]) Sample(r *rand.Rand, n int) []Item[K, V] {
+	if n <= 0 || m.m == nil {
+		return nil
+	}
+	intn := rand.Intn
+	if r != nil {
+		intn = r.Intn
+	}
+	remaining := m.l.Len()
+	if n > remaining {
+		n = remaining
+	}
+	// each item is selected with probability equal to the number of items
+	// still to be selected over the number of items not visited yet, so that
+	// all samples of n items are equally likely
+	out := make([]Item[K, V], 0, n)
+	for e := m.l.Front(); len(out) < n; e = e.Next() {
+		if intn(remaining) < n-len(out) {
+			out = append(out, e.Value)
+		}
+		remaining--
+	}
+	return out
+}
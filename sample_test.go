@@ -0,0 +1,59 @@
+package orderedmap
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestSample(t *testing.T) {
+	items := []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}, {5, "five"}}
+	m := newFromItems(t, items)
+	r := rand.New(rand.NewSource(1))
+	for n := -1; n <= len(items)+1; n++ {
+		sample := m.Sample(r, n)
+		want := n
+		if want < 0 {
+			want = 0
+		} else if want > len(items) {
+			want = len(items)
+		}
+		if len(sample) != want {
+			t.Fatalf("unexpected sample length: want: %d, got %d", want, len(sample))
+		}
+		// items are sampled in order, without repetitions
+		for i := 1; i < len(sample); i++ {
+			if sample[i-1].Key >= sample[i].Key {
+				t.Fatalf("unexpected sample order: %v", sample)
+			}
+		}
+	}
+	checkAll(t, m, items)
+
+	var empty OrderedMap[int, string]
+	if sample := empty.Sample(nil, 1); len(sample) != 0 {
+		t.Fatalf("unexpected sample: %v", sample)
+	}
+}
+
+func TestSampleUniform(t *testing.T) {
+	const (
+		n      = 2
+		trials = 10000
+	)
+	items := []Item[int, string]{{0, "zero"}, {1, "one"}, {2, "two"}, {3, "three"}, {4, "four"}}
+	m := newFromItems(t, items)
+	r := rand.New(rand.NewSource(1))
+	counts := make([]int, len(items))
+	for i := 0; i < trials; i++ {
+		for _, item := range m.Sample(r, n) {
+			counts[item.Key]++
+		}
+	}
+	// each item is expected to be sampled trials*n/len(items) times
+	want := trials * n / len(items)
+	for key, count := range counts {
+		if count < want*9/10 || count > want*11/10 {
+			t.Fatalf("unexpected number of samples of key %d: want about %d, got %d", key, want, count)
+		}
+	}
+}
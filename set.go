@@ -0,0 +1,41 @@
+package orderedmap
+
+// Union returns a new ordered map with the items of a followed by the items of
+// b whose keys are not in a, each group in its original order. Keys present in
+// both maps are associated to their value in a.
+func Union[K comparable, V any](a, b *OrderedMap[K, V]) *OrderedMap[K, V] {
+	out := New[K, V]()
+	for e := a.l.Front(); e != nil; e = e.Next() {
+		out.insert(e.Value, nil)
+	}
+	for e := b.l.Front(); e != nil; e = e.Next() {
+		if _, ok := out.m[e.Value.Key]; !ok {
+			out.insert(e.Value, nil)
+		}
+	}
+	return out
+}
+
+// Intersect returns a new ordered map with the items of a whose keys are also
+// in b, in the order in which they appear in a, associated to their value in a.
+func Intersect[K comparable, V any](a, b *OrderedMap[K, V]) *OrderedMap[K, V] {
+	out := New[K, V]()
+	for e := a.l.Front(); e != nil; e = e.Next() {
+		if _, ok := b.m[e.Value.Key]; ok {
+			out.insert(e.Value, nil)
+		}
+	}
+	return out
+}
+
+// Difference returns a new ordered map with the items of a whose keys are not
+// in b, in the order in which they appear in a.
+func Difference[K comparable, V any](a, b *OrderedMap[K, V]) *OrderedMap[K, V] {
+	out := New[K, V]()
+	for e := a.l.Front(); e != nil; e = e.Next() {
+		if _, ok := b.m[e.Value.Key]; !ok {
+			out.insert(e.Value, nil)
+		}
+	}
+	return out
+}
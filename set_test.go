@@ -0,0 +1,66 @@
+package orderedmap
+
+import "testing"
+
+func TestSetOperations(t *testing.T) {
+	cases := []struct {
+		name       string
+		a          []Item[string, int]
+		b          []Item[string, int]
+		union      []Item[string, int]
+		intersect  []Item[string, int]
+		difference []Item[string, int]
+	}{
+		{
+			name:       "empty",
+			a:          []Item[string, int]{},
+			b:          []Item[string, int]{},
+			union:      []Item[string, int]{},
+			intersect:  []Item[string, int]{},
+			difference: []Item[string, int]{},
+		},
+		{
+			name:       "empty second map",
+			a:          []Item[string, int]{{"b", 2}, {"a", 1}},
+			b:          []Item[string, int]{},
+			union:      []Item[string, int]{{"b", 2}, {"a", 1}},
+			intersect:  []Item[string, int]{},
+			difference: []Item[string, int]{{"b", 2}, {"a", 1}},
+		},
+		{
+			name:       "empty first map",
+			a:          []Item[string, int]{},
+			b:          []Item[string, int]{{"b", 2}, {"a", 1}},
+			union:      []Item[string, int]{{"b", 2}, {"a", 1}},
+			intersect:  []Item[string, int]{},
+			difference: []Item[string, int]{},
+		},
+		{
+			name:       "disjoint",
+			a:          []Item[string, int]{{"b", 2}, {"a", 1}},
+			b:          []Item[string, int]{{"d", 4}, {"c", 3}},
+			union:      []Item[string, int]{{"b", 2}, {"a", 1}, {"d", 4}, {"c", 3}},
+			intersect:  []Item[string, int]{},
+			difference: []Item[string, int]{{"b", 2}, {"a", 1}},
+		},
+		{
+			name:       "overlapping",
+			a:          []Item[string, int]{{"c", 3}, {"a", 1}, {"b", 2}},
+			b:          []Item[string, int]{{"e", 50}, {"b", 20}, {"d", 40}, {"c", 30}},
+			union:      []Item[string, int]{{"c", 3}, {"a", 1}, {"b", 2}, {"e", 50}, {"d", 40}},
+			intersect:  []Item[string, int]{{"c", 3}, {"b", 2}},
+			difference: []Item[string, int]{{"a", 1}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			a, b := newFromItems(t, c.a), newFromItems(t, c.b)
+			checkAll(t, Union(a, b), c.union)
+			checkAll(t, Intersect(a, b), c.intersect)
+			checkAll(t, Difference(a, b), c.difference)
+			// operands are not modified
+			checkAll(t, a, c.a)
+			checkAll(t, b, c.b)
+		})
+	}
+}
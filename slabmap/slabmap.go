@@ -0,0 +1,314 @@
+// Package slabmap implements an ordered map using generics, storing its items
+// in a contiguous slice rather than in a linked list of separately allocated
+// nodes.
+//
+// Items are linked in order by their indexes in the slice, and the map from
+// keys to their items holds indexes rather than pointers. Compared to an
+// orderedmap.OrderedMap, this reduces the number of allocations to a
+// handful of growing slices and the number of pointers the garbage collector
+// has to scan, which dominates the cost of collections for maps of many
+// millions of items, and improves locality when iterating.
+//
+// The slots of deleted items are reused by later insertions. Since deletions
+// can still leave the slice sparse and out of order, Compact rebuilds it
+// densely in the order of the map. It is invoked automatically whenever more
+// than half of the slots are unused.
+//
+// This implementation is not safe for concurrent usage.
+package slabmap
+
+import (
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+// nilIndex is the index linking to no item.
+const nilIndex = -1
+
+// minCompactSize is the minimum number of slots for which the slice is
+// automatically compacted.
+const minCompactSize = 64
+
+type slot[K comparable, V any] struct {
+	item       orderedmap.Item[K, V]
+	prev, next int32
+}
+
+// SlabMap is an implementation of an ordered map.
+//
+// K and V are respectively the types of keys and values.
+type SlabMap[K comparable, V any] struct {
+	index       map[K]int32
+	slots       []slot[K, V]
+	front, back int32
+	// free is the first of the unused slots, linked by next
+	free int32
+}
+
+// New returns a new empty map.
+func New[K comparable, V any]() *SlabMap[K, V] {
+	return NewWithCapacity[K, V](0)
+}
+
+// NewWithCapacity returns a new empty map with space for n items.
+func NewWithCapacity[K comparable, V any](n int) *SlabMap[K, V] {
+	return &SlabMap[K, V]{
+		index: make(map[K]int32, n),
+		slots: make([]slot[K, V], 0, n),
+		front: nilIndex,
+		back:  nilIndex,
+		free:  nilIndex,
+	}
+}
+
+// Len returns the number of items in the map.
+func (m *SlabMap[K, V]) Len() int {
+	return len(m.index)
+}
+
+// Get returns the value associated to a key in the map.
+//
+// If the key is not present in the map, it returns the zero value of V
+// and ok is set to false.
+func (m *SlabMap[K, V]) Get(key K) (value V, ok bool) {
+	if i, ok := m.index[key]; ok {
+		return m.slots[i].item.Value, true
+	}
+	return value, false
+}
+
+// Has reports whether a key is present in the map.
+func (m *SlabMap[K, V]) Has(key K) bool {
+	_, ok := m.index[key]
+	return ok
+}
+
+// Front returns the item at the front of the map.
+//
+// If the map is empty, it returns the zero value of Item[K, V]
+// and ok is set to false.
+func (m *SlabMap[K, V]) Front() (item orderedmap.Item[K, V], ok bool) {
+	if m.front == nilIndex {
+		return item, false
+	}
+	return m.slots[m.front].item, true
+}
+
+// Back returns the item at the back of the map.
+//
+// If the map is empty, it returns the zero value of Item[K, V]
+// and ok is set to false.
+func (m *SlabMap[K, V]) Back() (item orderedmap.Item[K, V], ok bool) {
+	if m.back == nilIndex {
+		return item, false
+	}
+	return m.slots[m.back].item, true
+}
+
+// Set sets the value associated to a key.
+//
+// If the key is already present, its value is updated in place and replaced
+// is set to true. Otherwise, the key and value are inserted at the back of the
+// map.
+func (m *SlabMap[K, V]) Set(key K, value V) (replaced bool) {
+	if i, ok := m.index[key]; ok {
+		m.slots[i].item.Value = value
+		return true
+	}
+	m.link(m.alloc(key, value), m.back, nilIndex)
+	return false
+}
+
+// PushFront inserts a new key and value at the front of the map.
+//
+// It returns ErrKeyAlreadyPresent if the key is already present.
+func (m *SlabMap[K, V]) PushFront(key K, value V) error {
+	if _, ok := m.index[key]; ok {
+		return orderedmap.ErrKeyAlreadyPresent
+	}
+	m.link(m.alloc(key, value), nilIndex, m.front)
+	return nil
+}
+
+// PushBack inserts a new key and value at the back of the map.
+//
+// It returns ErrKeyAlreadyPresent if the key is already present.
+func (m *SlabMap[K, V]) PushBack(key K, value V) error {
+	if _, ok := m.index[key]; ok {
+		return orderedmap.ErrKeyAlreadyPresent
+	}
+	m.link(m.alloc(key, value), m.back, nilIndex)
+	return nil
+}
+
+// MoveToFront moves an existing key to the front of the map.
+//
+// It returns ErrKeyMissing if the key to be moved is not in the map.
+func (m *SlabMap[K, V]) MoveToFront(key K) error {
+	i, ok := m.index[key]
+	if !ok {
+		return orderedmap.ErrKeyMissing
+	}
+	if i != m.front {
+		m.unlink(i)
+		m.link(i, nilIndex, m.front)
+	}
+	return nil
+}
+
+// MoveToBack moves an existing key to the back of the map.
+//
+// It returns ErrKeyMissing if the key to be moved is not in the map.
+func (m *SlabMap[K, V]) MoveToBack(key K) error {
+	i, ok := m.index[key]
+	if !ok {
+		return orderedmap.ErrKeyMissing
+	}
+	if i != m.back {
+		m.unlink(i)
+		m.link(i, m.back, nilIndex)
+	}
+	return nil
+}
+
+// Delete deletes a key from the map and returns its value.
+//
+// If the key is not present, it returns the zero value of V and ok is set to
+// false.
+func (m *SlabMap[K, V]) Delete(key K) (value V, ok bool) {
+	i, ok := m.index[key]
+	if !ok {
+		return value, false
+	}
+	value = m.slots[i].item.Value
+	delete(m.index, key)
+	m.unlink(i)
+	m.slots[i] = slot[K, V]{next: m.free}
+	m.free = i
+	if len(m.slots) >= minCompactSize && len(m.index) < len(m.slots)/2 {
+		m.Compact()
+	}
+	return value, true
+}
+
+// Clear empties the map, retaining the space allocated for its items.
+func (m *SlabMap[K, V]) Clear() {
+	for key := range m.index {
+		delete(m.index, key)
+	}
+	var zero slot[K, V]
+	for i := range m.slots {
+		m.slots[i] = zero
+	}
+	m.slots = m.slots[:0]
+	m.front, m.back, m.free = nilIndex, nilIndex, nilIndex
+}
+
+// Compact rebuilds the slice of items densely, in the order of the map, and
+// shrinks it and the map from keys to items to the number of items, releasing
+// the space of deleted items.
+func (m *SlabMap[K, V]) Compact() {
+	slots := make([]slot[K, V], len(m.index))
+	index := make(map[K]int32, len(m.index))
+	j := int32(0)
+	for i := m.front; i != nilIndex; i = m.slots[i].next {
+		slots[j] = slot[K, V]{item: m.slots[i].item, prev: j - 1, next: j + 1}
+		index[slots[j].item.Key] = j
+		j++
+	}
+	m.front, m.back, m.free = nilIndex, nilIndex, nilIndex
+	if j > 0 {
+		slots[j-1].next = nilIndex
+		m.front, m.back = 0, j-1
+	}
+	m.slots = slots
+	m.index = index
+}
+
+// Range calls f sequentially for each key and value present in the map,
+// from front to back. If f returns false, range stops the iteration.
+//
+// The map must not be modified by f.
+func (m *SlabMap[K, V]) Range(f func(key K, value V) bool) {
+	for i := m.front; i != nilIndex; i = m.slots[i].next {
+		if !f(m.slots[i].item.Key, m.slots[i].item.Value) {
+			return
+		}
+	}
+}
+
+// RangeReverse calls f sequentially for each key and value present in the
+// map, from back to front. If f returns false, range stops the iteration.
+//
+// The map must not be modified by f.
+func (m *SlabMap[K, V]) RangeReverse(f func(key K, value V) bool) {
+	for i := m.back; i != nilIndex; i = m.slots[i].prev {
+		if !f(m.slots[i].item.Key, m.slots[i].item.Value) {
+			return
+		}
+	}
+}
+
+// Keys returns the keys of the map, from front to back.
+func (m *SlabMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.index))
+	for i := m.front; i != nilIndex; i = m.slots[i].next {
+		keys = append(keys, m.slots[i].item.Key)
+	}
+	return keys
+}
+
+// Items returns the items of the map, from front to back.
+func (m *SlabMap[K, V]) Items() []orderedmap.Item[K, V] {
+	items := make([]orderedmap.Item[K, V], 0, len(m.index))
+	for i := m.front; i != nilIndex; i = m.slots[i].next {
+		items = append(items, m.slots[i].item)
+	}
+	return items
+}
+
+// alloc stores an item in an unused slot, which is not yet linked, and
+// returns its index.
+func (m *SlabMap[K, V]) alloc(key K, value V) int32 {
+	i := m.free
+	if i == nilIndex {
+		i = int32(len(m.slots))
+		m.slots = append(m.slots, slot[K, V]{})
+	} else {
+		m.free = m.slots[i].next
+	}
+	m.slots[i] = slot[K, V]{item: orderedmap.Item[K, V]{Key: key, Value: value}}
+	m.index[key] = i
+	return i
+}
+
+// link links the slot at index i between prev and next, which must be
+// adjacent.
+func (m *SlabMap[K, V]) link(i, prev, next int32) {
+	s := &m.slots[i]
+	s.prev, s.next = prev, next
+	if prev == nilIndex {
+		m.front = i
+	} else {
+		m.slots[prev].next = i
+	}
+	if next == nilIndex {
+		m.back = i
+	} else {
+		m.slots[next].prev = i
+	}
+}
+
+// unlink unlinks the slot at index i from its neighbors.
+func (m *SlabMap[K, V]) unlink(i int32) {
+	s := &m.slots[i]
+	if s.prev == nilIndex {
+		m.front = s.next
+	} else {
+		m.slots[s.prev].next = s.next
+	}
+	if s.next == nilIndex {
+		m.back = s.prev
+	} else {
+		m.slots[s.next].prev = s.prev
+	}
+}
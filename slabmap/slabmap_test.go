@@ -0,0 +1,193 @@
+package slabmap
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+func TestEmpty(t *testing.T) {
+	m := New[int, string]()
+	checkAll(t, m, []orderedmap.Item[int, string]{})
+	if _, ok := m.Delete(1); ok {
+		t.Fatal("deleted missing key")
+	}
+	if err := m.MoveToFront(1); !errors.Is(err, orderedmap.ErrKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", orderedmap.ErrKeyMissing, err)
+	}
+	if err := m.MoveToBack(1); !errors.Is(err, orderedmap.ErrKeyMissing) {
+		t.Fatalf("unexpected error: want: %v, got %v", orderedmap.ErrKeyMissing, err)
+	}
+	m.Compact()
+	checkAll(t, m, []orderedmap.Item[int, string]{})
+}
+
+func TestOperations(t *testing.T) {
+	m := NewWithCapacity[string, int](4)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	if err := m.PushFront("c", 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.PushBack("d", 4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.PushBack("a", 0); !errors.Is(err, orderedmap.ErrKeyAlreadyPresent) {
+		t.Fatalf("unexpected error: want: %v, got %v", orderedmap.ErrKeyAlreadyPresent, err)
+	}
+	if err := m.PushFront("a", 0); !errors.Is(err, orderedmap.ErrKeyAlreadyPresent) {
+		t.Fatalf("unexpected error: want: %v, got %v", orderedmap.ErrKeyAlreadyPresent, err)
+	}
+	if !m.Set("a", 10) {
+		t.Fatal("key a not replaced")
+	}
+	checkAll(t, m, []orderedmap.Item[string, int]{{Key: "c", Value: 3}, {Key: "a", Value: 10}, {Key: "b", Value: 2}, {Key: "d", Value: 4}})
+
+	if err := m.MoveToFront("d"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.MoveToBack("c"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, []orderedmap.Item[string, int]{{Key: "d", Value: 4}, {Key: "a", Value: 10}, {Key: "b", Value: 2}, {Key: "c", Value: 3}})
+
+	if v, ok := m.Delete("a"); !ok || v != 10 {
+		t.Fatalf("unexpected result: want: 10 (true), got %v (%v)", v, ok)
+	}
+	// the slot of the deleted item is reused
+	m.Set("e", 5)
+	if len(m.slots) != 4 {
+		t.Fatalf("unexpected number of slots: want: 4, got %d", len(m.slots))
+	}
+	checkAll(t, m, []orderedmap.Item[string, int]{{Key: "d", Value: 4}, {Key: "b", Value: 2}, {Key: "c", Value: 3}, {Key: "e", Value: 5}})
+
+	m.Delete("b")
+	m.Compact()
+	if len(m.slots) != 3 {
+		t.Fatalf("unexpected number of slots: want: 3, got %d", len(m.slots))
+	}
+	checkAll(t, m, []orderedmap.Item[string, int]{{Key: "d", Value: 4}, {Key: "c", Value: 3}, {Key: "e", Value: 5}})
+
+	m.Clear()
+	checkAll(t, m, []orderedmap.Item[string, int]{})
+	if cap(m.slots) < 3 {
+		t.Fatalf("capacity not retained: %d", cap(m.slots))
+	}
+	m.Set("f", 6)
+	checkAll(t, m, []orderedmap.Item[string, int]{{Key: "f", Value: 6}})
+}
+
+func TestAutomaticCompaction(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 1000; i++ {
+		m.Set(i, i)
+	}
+	for i := 0; i < 1000; i += 3 {
+		m.Delete(i)
+	}
+	// deleting more than half of the items compacts the slots
+	for i := 1; i < 1000; i += 3 {
+		m.Delete(i)
+	}
+	if len(m.slots) >= 1000 {
+		t.Fatalf("slots not compacted: %d", len(m.slots))
+	}
+	want := []orderedmap.Item[int, int]{}
+	for i := 2; i < 1000; i += 3 {
+		want = append(want, orderedmap.Item[int, int]{Key: i, Value: i})
+	}
+	checkAll(t, m, want)
+}
+
+func TestRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := New[int, int]()
+	ref := orderedmap.New[int, int]()
+	for i := 0; i < 20000; i++ {
+		key := r.Intn(500)
+		switch r.Intn(6) {
+		case 0, 1:
+			value, ok := m.Delete(key)
+			refValue, refOK := ref.Delete(key)
+			if ok != refOK || value != refValue {
+				t.Fatalf("unexpected Delete(%d): want: %v (%v), got %v (%v)", key, refValue, refOK, value, ok)
+			}
+		case 2:
+			err := m.PushFront(key, i)
+			if refErr := ref.PushFront(key, i); !errors.Is(err, refErr) {
+				t.Fatalf("unexpected PushFront(%d) error: want: %v, got %v", key, refErr, err)
+			}
+		case 3:
+			err := m.MoveToFront(key)
+			if refErr := ref.MoveToFront(key); !errors.Is(err, refErr) {
+				t.Fatalf("unexpected MoveToFront(%d) error: want: %v, got %v", key, refErr, err)
+			}
+		case 4:
+			err := m.MoveToBack(key)
+			if refErr := ref.MoveToBack(key); !errors.Is(err, refErr) {
+				t.Fatalf("unexpected MoveToBack(%d) error: want: %v, got %v", key, refErr, err)
+			}
+		default:
+			if replaced, refReplaced := m.Set(key, i), ref.Set(key, i); replaced != refReplaced {
+				t.Fatalf("unexpected Set(%d): want: %v, got %v", key, refReplaced, replaced)
+			}
+		}
+		if i%1000 == 0 {
+			checkAll(t, m, ref.Items())
+		}
+	}
+	checkAll(t, m, ref.Items())
+}
+
+func checkAll[K comparable, V any](t *testing.T, m *SlabMap[K, V], items []orderedmap.Item[K, V]) {
+	t.Helper()
+
+	if want, got := len(items), m.Len(); want != got {
+		t.Fatalf("incorrect length: want: %d, got: %d", want, got)
+	}
+	if diff := cmp.Diff(items, m.Items()); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+	keys := make([]K, 0, len(items))
+	for _, item := range items {
+		keys = append(keys, item.Key)
+	}
+	if diff := cmp.Diff(keys, m.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+	reverse := []orderedmap.Item[K, V]{}
+	m.RangeReverse(func(key K, value V) bool {
+		reverse = append([]orderedmap.Item[K, V]{{Key: key, Value: value}}, reverse...)
+		return true
+	})
+	if diff := cmp.Diff(items, reverse); diff != "" {
+		t.Fatalf("unexpected reverse items (-want +got):\n%s", diff)
+	}
+	for _, item := range items {
+		if value, ok := m.Get(item.Key); !ok || !cmp.Equal(value, item.Value) {
+			t.Fatalf("unexpected value of key %v: want: %v, got %v (%v)", item.Key, item.Value, value, ok)
+		}
+		if !m.Has(item.Key) {
+			t.Fatalf("missing key %v", item.Key)
+		}
+	}
+	front, frontOK := m.Front()
+	back, backOK := m.Back()
+	if frontOK != (len(items) > 0) || backOK != (len(items) > 0) {
+		t.Fatalf("unexpected front and back: %v (%v), %v (%v)", front, frontOK, back, backOK)
+	}
+	if len(items) > 0 && (!cmp.Equal(front, items[0]) || !cmp.Equal(back, items[len(items)-1])) {
+		t.Fatalf("unexpected front and back: %v, %v", front, back)
+	}
+	// all slots are either used or free
+	free := 0
+	for i := m.free; i != nilIndex; i = m.slots[i].next {
+		free++
+	}
+	if free+len(items) != len(m.slots) {
+		t.Fatalf("unexpected number of slots: %d used, %d free, %d total", len(items), free, len(m.slots))
+	}
+}
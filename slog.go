@@ -0,0 +1,21 @@
+//go:build go1.21
+
+package orderedmap
+
+import "log/slog"
+
+// LogValue implements the slog.LogValuer interface.
+//
+// The map is logged as a group with one attribute per item, in order.
+// Keys are formatted like in MarshalText, falling back to the default format
+// of the fmt package for types not supported by it.
+func (m *OrderedMap[K, V]) LogValue() slog.Value {
+	if m == nil || m.m == nil {
+		return slog.GroupValue()
+	}
+	attrs := make([]slog.Attr, 0, m.Len())
+	for el := m.l.Front(); el != nil; el = el.Next() {
+		attrs = append(attrs, slog.Any(formatTextOrDefault(el.Value.Key), el.Value.Value))
+	}
+	return slog.GroupValue(attrs...)
+}
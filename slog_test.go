@@ -0,0 +1,70 @@
+//go:build go1.21
+
+package orderedmap
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+)
+
+var _ slog.LogValuer = (*OrderedMap[string, int])(nil)
+
+// testHandlerOptions removes non-deterministic attributes from log records.
+var testHandlerOptions = &slog.HandlerOptions{
+	ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+			return slog.Attr{}
+		}
+		return a
+	},
+}
+
+func TestLogValue(t *testing.T) {
+	nested := newFromItems(t, []Item[string, any]{{"y", 1}, {"x", 2}})
+	cases := []struct {
+		name string
+		m    *OrderedMap[string, any]
+		want string
+	}{
+		{
+			name: "nil",
+			m:    nil,
+			want: `{"msg":"test"}`,
+		},
+		{
+			name: "empty",
+			m:    New[string, any](),
+			want: `{"msg":"test"}`,
+		},
+		{
+			name: "multiple items",
+			m:    newFromItems(t, []Item[string, any]{{"b", 2}, {"a", "one"}}),
+			want: `{"msg":"test","map":{"b":2,"a":"one"}}`,
+		},
+		{
+			name: "nested map",
+			m:    newFromItems(t, []Item[string, any]{{"z", nested}, {"a", true}}),
+			want: `{"msg":"test","map":{"z":{"y":1,"x":2},"a":true}}`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewJSONHandler(&buf, testHandlerOptions))
+			logger.Info("test", "map", c.m)
+			if got := bytes.TrimSpace(buf.Bytes()); string(got) != c.want {
+				t.Fatalf("unexpected output: want: %s, got %s", c.want, got)
+			}
+		})
+	}
+}
+
+func TestLogValueNonStringKeys(t *testing.T) {
+	m := newFromItems(t, []Item[int, string]{{2, "two"}, {1, "one"}})
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, testHandlerOptions)).Info("test", "map", m)
+	if want, got := "msg=test map.2=two map.1=one", string(bytes.TrimSpace(buf.Bytes())); got != want {
+		t.Fatalf("unexpected output: want: %s, got %s", want, got)
+	}
+}
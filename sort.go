@@ -0,0 +1,56 @@
+package orderedmap
+
+// Ordered is a constraint that permits any ordered type: any type
+// that supports the operators < <= >= >.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// Sort sorts the items of the map in place according to less, which must
+// define a strict weak ordering.
+//
+// The sort is stable and runs in O(n log n) time without allocating
+// a copy of the map.
+func (m *OrderedMap[K, V]) Sort(less func(a, b Item[K, V]) bool) {
+	m.beforeWrite()
+	m.sort(less)
+}
+
+// InsertSorted inserts a new key and value in sorted position according to
+// cmp, which must return a negative number if a sorts before b, a positive
+// number if a sorts after b and zero if they sort equally.
+//
+// The map is assumed to be already sorted according to cmp. The new item is
+// inserted after all items sorting equally to it. The position is searched
+// starting from the back of the map, so that inserting items in nearly sorted
+// order takes nearly constant time.
+//
+// It returns ErrKeyAlreadyPresent if the key to be inserted is already present.
+func (m *OrderedMap[K, V]) InsertSorted(key K, value V, cmp func(a, b Item[K, V]) int) error {
+	key = m.normalize(key)
+	m.beforeWrite()
+	if _, ok := m.m[key]; ok {
+		return ErrKeyAlreadyPresent
+	}
+	item := Item[K, V]{key, value}
+	mark := m.l.Back()
+	for mark != nil && cmp(mark.Value, item) > 0 {
+		mark = mark.Prev()
+	}
+	if mark == nil {
+		m.insert(item, m.l.Front())
+	} else {
+		m.insert(item, mark.Next())
+	}
+	return nil
+}
+
+// SortKeys sorts the items of the map in place in ascending order of keys.
+func SortKeys[K Ordered, V any](m *OrderedMap[K, V]) {
+	m.Sort(func(a, b Item[K, V]) bool {
+		return a.Key < b.Key
+	})
+}
@@ -0,0 +1,137 @@
+package orderedmap
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[int, string]
+		want  []Item[int, string]
+	}{
+		{
+			name:  "empty",
+			items: []Item[int, string]{},
+			want:  []Item[int, string]{},
+		},
+		{
+			name:  "one item",
+			items: []Item[int, string]{{1, "a"}},
+			want:  []Item[int, string]{{1, "a"}},
+		},
+		{
+			name:  "sort by value",
+			items: []Item[int, string]{{1, "c"}, {2, "a"}, {3, "b"}},
+			want:  []Item[int, string]{{2, "a"}, {3, "b"}, {1, "c"}},
+		},
+		{
+			name:  "stable",
+			items: []Item[int, string]{{1, "b"}, {2, "a"}, {3, "b"}, {4, "a"}, {5, "b"}},
+			want:  []Item[int, string]{{2, "a"}, {4, "a"}, {1, "b"}, {3, "b"}, {5, "b"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			m.Sort(func(a, b Item[int, string]) bool {
+				return a.Value < b.Value
+			})
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestSortKeys(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[string, int]
+		want  []Item[string, int]
+	}{
+		{
+			name:  "empty",
+			items: []Item[string, int]{},
+			want:  []Item[string, int]{},
+		},
+		{
+			name:  "multiple items",
+			items: []Item[string, int]{{"c", 1}, {"a", 2}, {"d", 3}, {"b", 4}},
+			want:  []Item[string, int]{{"a", 2}, {"b", 4}, {"c", 1}, {"d", 3}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			SortKeys(m)
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestInsertSorted(t *testing.T) {
+	cmp := func(a, b Item[string, int]) int {
+		return a.Value - b.Value
+	}
+	cases := []struct {
+		name  string
+		items []Item[string, int]
+		key   string
+		value int
+		want  []Item[string, int]
+		err   error
+	}{
+		{
+			name:  "empty",
+			items: []Item[string, int]{},
+			key:   "a",
+			value: 1,
+			want:  []Item[string, int]{{"a", 1}},
+		},
+		{
+			name:  "insert at front",
+			items: []Item[string, int]{{"a", 1}, {"b", 2}},
+			key:   "c",
+			value: 0,
+			want:  []Item[string, int]{{"c", 0}, {"a", 1}, {"b", 2}},
+		},
+		{
+			name:  "insert in the middle",
+			items: []Item[string, int]{{"a", 1}, {"b", 3}},
+			key:   "c",
+			value: 2,
+			want:  []Item[string, int]{{"a", 1}, {"c", 2}, {"b", 3}},
+		},
+		{
+			name:  "insert at back",
+			items: []Item[string, int]{{"a", 1}, {"b", 2}},
+			key:   "c",
+			value: 3,
+			want:  []Item[string, int]{{"a", 1}, {"b", 2}, {"c", 3}},
+		},
+		{
+			name:  "insert after equal items",
+			items: []Item[string, int]{{"a", 1}, {"b", 2}, {"c", 2}, {"d", 3}},
+			key:   "e",
+			value: 2,
+			want:  []Item[string, int]{{"a", 1}, {"b", 2}, {"c", 2}, {"e", 2}, {"d", 3}},
+		},
+		{
+			name:  "key already present",
+			items: []Item[string, int]{{"a", 1}, {"b", 2}},
+			key:   "a",
+			value: 3,
+			want:  []Item[string, int]{{"a", 1}, {"b", 2}},
+			err:   ErrKeyAlreadyPresent,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := m.InsertSorted(c.key, c.value, cmp); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected err: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
@@ -0,0 +1,229 @@
+package sortedmap
+
+import (
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+const (
+	// degree is the minimum number of children of each internal node
+	// other than the root.
+	degree = 16
+
+	// maxItems and minItems are the maximum and minimum number of items
+	// of each node other than the root.
+	maxItems = 2*degree - 1
+	minItems = degree - 1
+)
+
+// node is a node of a B-tree. Leaves have no children, internal nodes have
+// exactly one child more than items. All keys of children[i] sort before
+// items[i], which sorts before all keys of children[i+1].
+type node[K comparable, V any] struct {
+	items    []orderedmap.Item[K, V]
+	children []*node[K, V]
+}
+
+func (n *node[K, V]) leaf() bool {
+	return len(n.children) == 0
+}
+
+// find returns the index of the first item whose key does not sort before key
+// and whether that item has the key searched.
+func (n *node[K, V]) find(key K, cmp func(a, b K) int) (i int, found bool) {
+	lo, hi := 0, len(n.items)
+	for lo < hi {
+		mid := int(uint(lo+hi) >> 1)
+		if cmp(n.items[mid].Key, key) < 0 {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return lo, lo < len(n.items) && cmp(n.items[lo].Key, key) == 0
+}
+
+// split splits the node at item i, which is returned together with a new node
+// containing all items and children following it.
+func (n *node[K, V]) split(i int) (orderedmap.Item[K, V], *node[K, V]) {
+	item := n.items[i]
+	right := &node[K, V]{items: append([]orderedmap.Item[K, V](nil), n.items[i+1:]...)}
+	n.items = truncate(n.items, i)
+	if !n.leaf() {
+		right.children = append([]*node[K, V](nil), n.children[i+1:]...)
+		n.children = truncate(n.children, i+1)
+	}
+	return item, right
+}
+
+// insert inserts or updates a key in the subtree rooted at the node, which
+// must not be full, and reports whether the key was already present.
+func (n *node[K, V]) insert(key K, value V, cmp func(a, b K) int) (replaced bool) {
+	i, found := n.find(key, cmp)
+	if found {
+		n.items[i].Value = value
+		return true
+	}
+	if n.leaf() {
+		n.items = insertAt(n.items, i, orderedmap.Item[K, V]{Key: key, Value: value})
+		return false
+	}
+	if len(n.children[i].items) >= maxItems {
+		item, right := n.children[i].split(maxItems / 2)
+		n.items = insertAt(n.items, i, item)
+		n.children = insertAt(n.children, i+1, right)
+		switch c := cmp(key, item.Key); {
+		case c == 0:
+			n.items[i].Value = value
+			return true
+		case c > 0:
+			i++
+		}
+	}
+	return n.children[i].insert(key, value, cmp)
+}
+
+// remove removes a key from the subtree rooted at the node, or its maximum
+// item if max is true, and returns the item removed. All nodes visited other
+// than the root are guaranteed to have more than minItems items before
+// removing from them, so that removing never leaves them underfull.
+func (n *node[K, V]) remove(key K, max bool, cmp func(a, b K) int) (item orderedmap.Item[K, V], ok bool) {
+	var i int
+	var found bool
+	if max {
+		if n.leaf() {
+			item = n.items[len(n.items)-1]
+			n.items = truncate(n.items, len(n.items)-1)
+			return item, true
+		}
+		i = len(n.items)
+	} else {
+		i, found = n.find(key, cmp)
+		if n.leaf() {
+			if !found {
+				return item, false
+			}
+			item = n.items[i]
+			n.items = removeAt(n.items, i)
+			return item, true
+		}
+	}
+	if len(n.children[i].items) <= minItems {
+		n.grow(i)
+		return n.remove(key, max, cmp)
+	}
+	if found {
+		// replace the item with its predecessor, which can be removed from
+		// the child since it has more than minItems items
+		item = n.items[i]
+		n.items[i], _ = n.children[i].remove(key, true, cmp)
+		return item, true
+	}
+	return n.children[i].remove(key, max, cmp)
+}
+
+// grow adds an item to children[i], either by moving one from a sibling
+// through the node or by merging children[i] with a sibling.
+func (n *node[K, V]) grow(i int) {
+	switch {
+	case i > 0 && len(n.children[i-1].items) > minItems:
+		child, left := n.children[i], n.children[i-1]
+		child.items = insertAt(child.items, 0, n.items[i-1])
+		n.items[i-1] = left.items[len(left.items)-1]
+		left.items = truncate(left.items, len(left.items)-1)
+		if !left.leaf() {
+			child.children = insertAt(child.children, 0, left.children[len(left.children)-1])
+			left.children = truncate(left.children, len(left.children)-1)
+		}
+	case i < len(n.items) && len(n.children[i+1].items) > minItems:
+		child, right := n.children[i], n.children[i+1]
+		child.items = append(child.items, n.items[i])
+		n.items[i] = right.items[0]
+		right.items = removeAt(right.items, 0)
+		if !right.leaf() {
+			child.children = append(child.children, right.children[0])
+			right.children = removeAt(right.children, 0)
+		}
+	default:
+		if i >= len(n.items) {
+			i--
+		}
+		child, right := n.children[i], n.children[i+1]
+		child.items = append(child.items, n.items[i])
+		child.items = append(child.items, right.items...)
+		child.children = append(child.children, right.children...)
+		n.items = removeAt(n.items, i)
+		n.children = removeAt(n.children, i+1)
+	}
+}
+
+// ascend calls f for each item of the subtree rooted at the node in ascending
+// order, starting from the first item whose key does not sort before from if
+// bounded is true. It returns false if f stopped the iteration.
+func (n *node[K, V]) ascend(from K, bounded bool, cmp func(a, b K) int, f func(key K, value V) bool) bool {
+	i := 0
+	if bounded {
+		i, _ = n.find(from, cmp)
+	}
+	for ; i < len(n.items); i++ {
+		if !n.leaf() && !n.children[i].ascend(from, bounded, cmp, f) {
+			return false
+		}
+		// all following items and children sort after from
+		bounded = false
+		if !f(n.items[i].Key, n.items[i].Value) {
+			return false
+		}
+	}
+	return n.leaf() || n.children[i].ascend(from, bounded, cmp, f)
+}
+
+// descend calls f for each item of the subtree rooted at the node in
+// descending order, starting from the last item whose key does not sort after
+// from if bounded is true. It returns false if f stopped the iteration.
+func (n *node[K, V]) descend(from K, bounded bool, cmp func(a, b K) int, f func(key K, value V) bool) bool {
+	i := len(n.items)
+	if bounded {
+		var found bool
+		if i, found = n.find(from, cmp); found {
+			i++
+		} else if !n.leaf() && !n.children[i].descend(from, true, cmp, f) {
+			return false
+		}
+	} else if !n.leaf() && !n.children[i].descend(from, false, cmp, f) {
+		return false
+	}
+	for i--; i >= 0; i-- {
+		if !f(n.items[i].Key, n.items[i].Value) {
+			return false
+		}
+		if !n.leaf() && !n.children[i].descend(from, false, cmp, f) {
+			return false
+		}
+	}
+	return true
+}
+
+// insertAt inserts v in s at index i.
+func insertAt[T any](s []T, i int, v T) []T {
+	var zero T
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
+
+// removeAt removes the element of s at index i.
+func removeAt[T any](s []T, i int) []T {
+	copy(s[i:], s[i+1:])
+	return truncate(s, len(s)-1)
+}
+
+// truncate truncates s to length n, clearing the elements removed so that
+// they can be garbage collected.
+func truncate[T any](s []T, n int) []T {
+	var zero T
+	for i := n; i < len(s); i++ {
+		s[i] = zero
+	}
+	return s[:n]
+}
@@ -0,0 +1,258 @@
+// Package sortedmap implements a sorted map using generics.
+//
+// A sorted map is a map whose items are ordered by key according to a total
+// order, rather than by insertion order as in an orderedmap.OrderedMap. It is
+// implemented as a B-tree and provides O(log n) lookup, insertion and removal,
+// seeking of the nearest key and ascending and descending range scans.
+//
+// This implementation is not safe for concurrent usage.
+package sortedmap
+
+import (
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+// SortedMap is an implementation of a sorted map.
+//
+// K and V are respectively the types of keys and values.
+type SortedMap[K comparable, V any] struct {
+	root *node[K, V]
+	n    int
+	cmp  func(a, b K) int
+}
+
+// New returns a new sorted map whose keys are sorted in ascending order.
+func New[K orderedmap.Ordered, V any]() *SortedMap[K, V] {
+	return NewFunc[K, V](func(a, b K) int {
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	})
+}
+
+// NewFunc returns a new sorted map whose keys are sorted according to cmp,
+// which must return a negative number if a sorts before b, a positive number
+// if a sorts after b and zero if a and b are the same key.
+func NewFunc[K comparable, V any](cmp func(a, b K) int) *SortedMap[K, V] {
+	return &SortedMap[K, V]{cmp: cmp}
+}
+
+// Get returns the value associated to a key in the map.
+//
+// If the key is not present in the map, it returns the zero value of V
+// and ok is set to false.
+func (m *SortedMap[K, V]) Get(key K) (value V, ok bool) {
+	for n := m.root; n != nil; {
+		i, found := n.find(key, m.cmp)
+		if found {
+			return n.items[i].Value, true
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	return value, false
+}
+
+// Has reports whether a key is present in the map.
+func (m *SortedMap[K, V]) Has(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Set sets the value associated to a key.
+//
+// If the key is already present, its value is updated and replaced is set
+// to true. Otherwise, the key and value are inserted.
+func (m *SortedMap[K, V]) Set(key K, value V) (replaced bool) {
+	if m.root == nil {
+		m.root = &node[K, V]{}
+	}
+	if len(m.root.items) >= maxItems {
+		item, right := m.root.split(maxItems / 2)
+		m.root = &node[K, V]{
+			items:    []orderedmap.Item[K, V]{item},
+			children: []*node[K, V]{m.root, right},
+		}
+	}
+	replaced = m.root.insert(key, value, m.cmp)
+	if !replaced {
+		m.n++
+	}
+	return replaced
+}
+
+// Delete deletes an item from a map and returns the value deleted.
+//
+// If the item to be deleted was already missing from the map, ok is set to false.
+func (m *SortedMap[K, V]) Delete(key K) (value V, ok bool) {
+	if m.root == nil {
+		return value, false
+	}
+	item, ok := m.root.remove(key, false, m.cmp)
+	if len(m.root.items) == 0 {
+		if m.root.leaf() {
+			m.root = nil
+		} else {
+			m.root = m.root.children[0]
+		}
+	}
+	if !ok {
+		return value, false
+	}
+	m.n--
+	return item.Value, true
+}
+
+// Len returns the number of items stored in the sorted map.
+func (m *SortedMap[K, V]) Len() int {
+	return m.n
+}
+
+// Clear empties the sorted map.
+func (m *SortedMap[K, V]) Clear() {
+	m.root = nil
+	m.n = 0
+}
+
+// Min returns the item with the smallest key.
+//
+// If the map is empty, it returns the zero value of orderedmap.Item[K, V]
+// and ok is set to false.
+func (m *SortedMap[K, V]) Min() (item orderedmap.Item[K, V], ok bool) {
+	n := m.root
+	if n == nil {
+		return item, false
+	}
+	for !n.leaf() {
+		n = n.children[0]
+	}
+	return n.items[0], true
+}
+
+// Max returns the item with the largest key.
+//
+// If the map is empty, it returns the zero value of orderedmap.Item[K, V]
+// and ok is set to false.
+func (m *SortedMap[K, V]) Max() (item orderedmap.Item[K, V], ok bool) {
+	n := m.root
+	if n == nil {
+		return item, false
+	}
+	for !n.leaf() {
+		n = n.children[len(n.children)-1]
+	}
+	return n.items[len(n.items)-1], true
+}
+
+// SeekGE returns the item with the smallest key that does not sort before key.
+//
+// If there is no such item, it returns the zero value of
+// orderedmap.Item[K, V] and ok is set to false.
+func (m *SortedMap[K, V]) SeekGE(key K) (item orderedmap.Item[K, V], ok bool) {
+	for n := m.root; n != nil; {
+		i, found := n.find(key, m.cmp)
+		if found {
+			return n.items[i], true
+		}
+		if i < len(n.items) {
+			item, ok = n.items[i], true
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	return item, ok
+}
+
+// SeekLE returns the item with the largest key that does not sort after key.
+//
+// If there is no such item, it returns the zero value of
+// orderedmap.Item[K, V] and ok is set to false.
+func (m *SortedMap[K, V]) SeekLE(key K) (item orderedmap.Item[K, V], ok bool) {
+	for n := m.root; n != nil; {
+		i, found := n.find(key, m.cmp)
+		if found {
+			return n.items[i], true
+		}
+		if i > 0 {
+			item, ok = n.items[i-1], true
+		}
+		if n.leaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	return item, ok
+}
+
+// Range calls f sequentially for each key and value present in the sorted map
+// in ascending order. If f returns false, Range stops the iteration.
+//
+// The map must not be modified while iterating it.
+func (m *SortedMap[K, V]) Range(f func(key K, value V) bool) {
+	var zero K
+	if m.root != nil {
+		m.root.ascend(zero, false, m.cmp, f)
+	}
+}
+
+// RangeReverse calls f sequentially for each key and value present in the
+// sorted map in descending order. If f returns false, RangeReverse stops the
+// iteration.
+//
+// The map must not be modified while iterating it.
+func (m *SortedMap[K, V]) RangeReverse(f func(key K, value V) bool) {
+	var zero K
+	if m.root != nil {
+		m.root.descend(zero, false, m.cmp, f)
+	}
+}
+
+// Ascend calls f sequentially in ascending order for each key and value
+// present in the sorted map whose key does not sort before from. If f returns
+// false, Ascend stops the iteration.
+//
+// The map must not be modified while iterating it.
+func (m *SortedMap[K, V]) Ascend(from K, f func(key K, value V) bool) {
+	if m.root != nil {
+		m.root.ascend(from, true, m.cmp, f)
+	}
+}
+
+// Descend calls f sequentially in descending order for each key and value
+// present in the sorted map whose key does not sort after from. If f returns
+// false, Descend stops the iteration.
+//
+// The map must not be modified while iterating it.
+func (m *SortedMap[K, V]) Descend(from K, f func(key K, value V) bool) {
+	if m.root != nil {
+		m.root.descend(from, true, m.cmp, f)
+	}
+}
+
+// Keys returns the sorted list of keys of the map.
+func (m *SortedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.n)
+	m.Range(func(key K, value V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Items returns the sorted list of items of the map.
+func (m *SortedMap[K, V]) Items() []orderedmap.Item[K, V] {
+	items := make([]orderedmap.Item[K, V], 0, m.n)
+	m.Range(func(key K, value V) bool {
+		items = append(items, orderedmap.Item[K, V]{Key: key, Value: value})
+		return true
+	})
+	return items
+}
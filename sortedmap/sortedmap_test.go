@@ -0,0 +1,218 @@
+package sortedmap
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+func TestEmpty(t *testing.T) {
+	m := New[int, string]()
+	checkAll(t, m, []orderedmap.Item[int, string]{})
+	if _, ok := m.Delete(1); ok {
+		t.Fatal("deleted missing key")
+	}
+	if _, ok := m.SeekGE(1); ok {
+		t.Fatal("found item in empty map")
+	}
+	if _, ok := m.SeekLE(1); ok {
+		t.Fatal("found item in empty map")
+	}
+	m.Ascend(0, func(key int, value string) bool {
+		t.Fatal("found item in empty map")
+		return true
+	})
+	m.Descend(0, func(key int, value string) bool {
+		t.Fatal("found item in empty map")
+		return true
+	})
+}
+
+func TestSetDelete(t *testing.T) {
+	m := New[string, int]()
+	for _, key := range []string{"c", "a", "b"} {
+		if m.Set(key, len(key)) {
+			t.Fatalf("key %v replaced", key)
+		}
+	}
+	if !m.Set("a", 10) {
+		t.Fatal("key a not replaced")
+	}
+	checkAll(t, m, []orderedmap.Item[string, int]{{Key: "a", Value: 10}, {Key: "b", Value: 1}, {Key: "c", Value: 1}})
+
+	if value, ok := m.Delete("b"); !ok || value != 1 {
+		t.Fatalf("unexpected result: want: 1 (true), got %v (%v)", value, ok)
+	}
+	if _, ok := m.Delete("b"); ok {
+		t.Fatal("deleted missing key")
+	}
+	checkAll(t, m, []orderedmap.Item[string, int]{{Key: "a", Value: 10}, {Key: "c", Value: 1}})
+
+	m.Clear()
+	checkAll(t, m, []orderedmap.Item[string, int]{})
+}
+
+func TestNewFunc(t *testing.T) {
+	m := NewFunc[string, int](func(a, b string) int {
+		return strings.Compare(b, a)
+	})
+	for i, key := range []string{"b", "c", "a"} {
+		m.Set(key, i)
+	}
+	checkAll(t, m, []orderedmap.Item[string, int]{{Key: "c", Value: 1}, {Key: "b", Value: 0}, {Key: "a", Value: 2}})
+}
+
+func TestSeek(t *testing.T) {
+	m := New[int, int]()
+	for i := 0; i < 1000; i += 2 {
+		m.Set(i, i)
+	}
+	cases := []struct {
+		name  string
+		key   int
+		ge    int
+		geOK  bool
+		le    int
+		leOK  bool
+		asc   []int
+		desc  []int
+		limit int
+	}{
+		{name: "before min", key: -1, ge: 0, geOK: true, asc: []int{0, 2, 4}},
+		{name: "min", key: 0, ge: 0, geOK: true, le: 0, leOK: true, asc: []int{0, 2, 4}, desc: []int{0}},
+		{name: "present", key: 500, ge: 500, geOK: true, le: 500, leOK: true, asc: []int{500, 502, 504}, desc: []int{500, 498, 496}},
+		{name: "missing", key: 501, ge: 502, geOK: true, le: 500, leOK: true, asc: []int{502, 504, 506}, desc: []int{500, 498, 496}},
+		{name: "max", key: 998, ge: 998, geOK: true, le: 998, leOK: true, asc: []int{998}, desc: []int{998, 996, 994}},
+		{name: "after max", key: 999, le: 998, leOK: true, desc: []int{998, 996, 994}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if item, ok := m.SeekGE(c.key); ok != c.geOK || item.Key != c.ge {
+				t.Fatalf("unexpected SeekGE: want: %v (%v), got %v (%v)", c.ge, c.geOK, item.Key, ok)
+			}
+			if item, ok := m.SeekLE(c.key); ok != c.leOK || item.Key != c.le {
+				t.Fatalf("unexpected SeekLE: want: %v (%v), got %v (%v)", c.le, c.leOK, item.Key, ok)
+			}
+			asc := collect(func(f func(key, value int) bool) { m.Ascend(c.key, f) }, 3)
+			if diff := cmp.Diff(c.asc, asc); diff != "" {
+				t.Fatalf("unexpected Ascend (-want +got):\n%s", diff)
+			}
+			desc := collect(func(f func(key, value int) bool) { m.Descend(c.key, f) }, 3)
+			if diff := cmp.Diff(c.desc, desc); diff != "" {
+				t.Fatalf("unexpected Descend (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	m := New[int, int]()
+	ref := map[int]int{}
+	for i := 0; i < 20000; i++ {
+		key := r.Intn(2000)
+		if r.Intn(3) == 0 {
+			value, ok := m.Delete(key)
+			refValue, refOK := ref[key]
+			if ok != refOK || value != refValue {
+				t.Fatalf("unexpected Delete(%d): want: %v (%v), got %v (%v)", key, refValue, refOK, value, ok)
+			}
+			delete(ref, key)
+		} else {
+			_, refOK := ref[key]
+			if replaced := m.Set(key, i); replaced != refOK {
+				t.Fatalf("unexpected Set(%d): want: %v, got %v", key, refOK, replaced)
+			}
+			ref[key] = i
+		}
+		if i%1000 == 0 {
+			checkAll(t, m, sortedItems(ref))
+		}
+	}
+	checkAll(t, m, sortedItems(ref))
+	for key := range ref {
+		m.Delete(key)
+	}
+	checkAll(t, m, []orderedmap.Item[int, int]{})
+}
+
+// collect returns up to n keys yielded by scan.
+func collect(scan func(f func(key, value int) bool), n int) []int {
+	var keys []int
+	scan(func(key, value int) bool {
+		keys = append(keys, key)
+		return len(keys) < n
+	})
+	return keys
+}
+
+func sortedItems(m map[int]int) []orderedmap.Item[int, int] {
+	items := make([]orderedmap.Item[int, int], 0, len(m))
+	for k, v := range m {
+		items = append(items, orderedmap.Item[int, int]{Key: k, Value: v})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Key < items[j].Key })
+	return items
+}
+
+func checkAll[K comparable, V any](t *testing.T, m *SortedMap[K, V], items []orderedmap.Item[K, V]) {
+	t.Helper()
+
+	if want, got := len(items), m.Len(); want != got {
+		t.Fatalf("incorrect length: want: %d, got: %d", want, got)
+	}
+	if diff := cmp.Diff(items, m.Items()); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+	reverse := []orderedmap.Item[K, V]{}
+	m.RangeReverse(func(key K, value V) bool {
+		reverse = append([]orderedmap.Item[K, V]{{Key: key, Value: value}}, reverse...)
+		return true
+	})
+	if diff := cmp.Diff(items, reverse); diff != "" {
+		t.Fatalf("unexpected reverse items (-want +got):\n%s", diff)
+	}
+	for _, item := range items {
+		if value, ok := m.Get(item.Key); !ok || !cmp.Equal(value, item.Value) {
+			t.Fatalf("unexpected value of key %v: want: %v, got %v (%v)", item.Key, item.Value, value, ok)
+		}
+	}
+	min, minOK := m.Min()
+	max, maxOK := m.Max()
+	if minOK != (len(items) > 0) || maxOK != (len(items) > 0) {
+		t.Fatalf("unexpected min and max: %v (%v), %v (%v)", min, minOK, max, maxOK)
+	}
+	if len(items) > 0 && (!cmp.Equal(min, items[0]) || !cmp.Equal(max, items[len(items)-1])) {
+		t.Fatalf("unexpected min and max: %v, %v", min, max)
+	}
+	if m.root != nil {
+		checkNode(t, m.root, true)
+	}
+}
+
+// checkNode verifies the invariants of the B-tree rooted at n and returns its
+// height.
+func checkNode[K comparable, V any](t *testing.T, n *node[K, V], root bool) int {
+	t.Helper()
+
+	if len(n.items) > maxItems || (!root && len(n.items) < minItems) {
+		t.Fatalf("unexpected number of items: %d", len(n.items))
+	}
+	if n.leaf() {
+		return 1
+	}
+	if len(n.children) != len(n.items)+1 {
+		t.Fatalf("unexpected number of children: want: %d, got %d", len(n.items)+1, len(n.children))
+	}
+	height := checkNode(t, n.children[0], false)
+	for _, child := range n.children[1:] {
+		if h := checkNode(t, child, false); h != height {
+			t.Fatalf("unbalanced tree: heights %d and %d", height, h)
+		}
+	}
+	return height + 1
+}
@@ -0,0 +1,91 @@
+package orderedmap
+
+import (
+	"fmt"
+
+	"github.com/lorenzosaino/go-orderedmap/list"
+)
+
+// SpliceFront moves all items of other to the front of the map, preserving
+// their order, and leaves other empty.
+//
+// Items are moved by relinking their internal elements, without copying
+// them, in O(other.Len()) time. Moving is all-or-nothing: if the key of any
+// item of other is already present in the map, both maps are left unmodified
+// and an error wrapping ErrKeyAlreadyPresent is returned.
+//
+// Items are moved as if deleted from other and inserted into the map, so
+// they are notified as such to the write hooks of the maps and counted by
+// their statistics. If the map then exceeds its maximum number of entries or
+// weight, its front items are evicted.
+func (m *OrderedMap[K, V]) SpliceFront(other *OrderedMap[K, V]) error {
+	m.beforeWrite()
+	return m.splice(other, m.l.Front())
+}
+
+// SpliceBack moves all items of other to the back of the map, preserving
+// their order, and leaves other empty, as described by SpliceFront.
+func (m *OrderedMap[K, V]) SpliceBack(other *OrderedMap[K, V]) error {
+	m.beforeWrite()
+	return m.splice(other, nil)
+}
+
+// SpliceAfter moves all items of other immediately after a mark key,
+// preserving their order, and leaves other empty, as described by
+// SpliceFront.
+//
+// It returns ErrMarkKeyMissing if the mark key is missing.
+func (m *OrderedMap[K, V]) SpliceAfter(other *OrderedMap[K, V], mark K) error {
+	m.beforeWrite()
+	markEl, ok := m.m[m.normalize(mark)]
+	if !ok {
+		return ErrMarkKeyMissing
+	}
+	return m.splice(other, markEl.Next())
+}
+
+// SpliceBefore moves all items of other immediately before a mark key,
+// preserving their order, and leaves other empty, as described by
+// SpliceFront.
+//
+// It returns ErrMarkKeyMissing if the mark key is missing.
+func (m *OrderedMap[K, V]) SpliceBefore(other *OrderedMap[K, V], mark K) error {
+	m.beforeWrite()
+	markEl, ok := m.m[m.normalize(mark)]
+	if !ok {
+		return ErrMarkKeyMissing
+	}
+	return m.splice(other, markEl)
+}
+
+// splice moves all elements of other immediately before mark, or at the back
+// of the list if mark is nil.
+func (m *OrderedMap[K, V]) splice(other *OrderedMap[K, V], mark *list.Element[Item[K, V]]) error {
+	if other.Len() == 0 {
+		return nil
+	}
+	other.beforeWrite()
+	// keys are checked before moving any element, also taking into account
+	// that keys of other may be normalized to the same key of the map
+	var seen map[K]struct{}
+	if m.normalizeKey != nil {
+		seen = make(map[K]struct{}, other.Len())
+	}
+	for e := other.l.Front(); e != nil; e = e.Next() {
+		key := m.normalize(e.Value.Key)
+		_, dup := seen[key]
+		if _, ok := m.m[key]; ok || dup {
+			return fmt.Errorf("duplicate key %v: %w", e.Value.Key, ErrKeyAlreadyPresent)
+		}
+		if seen != nil {
+			seen[key] = struct{}{}
+		}
+	}
+	for e := other.l.Front(); e != nil; e = other.l.Front() {
+		other.unlink(e, EditDelete)
+		e.Value.Key = m.normalize(e.Value.Key)
+		m.link(e, mark)
+	}
+	m.evictExcess()
+	return nil
+}
@@ -0,0 +1,185 @@
+package orderedmap
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSplice(t *testing.T) {
+	items := []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}}
+	other := []Item[int, string]{{5, "five"}, {4, "four"}}
+	cases := []struct {
+		name   string
+		items  []Item[int, string]
+		other  []Item[int, string]
+		splice func(m, other *OrderedMap[int, string]) error
+		want   []Item[int, string]
+		err    error
+	}{
+		{
+			name:   "front",
+			items:  items,
+			other:  other,
+			splice: (*OrderedMap[int, string]).SpliceFront,
+			want:   []Item[int, string]{{5, "five"}, {4, "four"}, {1, "one"}, {2, "two"}, {3, "three"}},
+		},
+		{
+			name:   "back",
+			items:  items,
+			other:  other,
+			splice: (*OrderedMap[int, string]).SpliceBack,
+			want:   []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {5, "five"}, {4, "four"}},
+		},
+		{
+			name:   "back of empty map",
+			items:  []Item[int, string]{},
+			other:  other,
+			splice: (*OrderedMap[int, string]).SpliceBack,
+			want:   other,
+		},
+		{
+			name:   "empty other",
+			items:  items,
+			other:  []Item[int, string]{},
+			splice: (*OrderedMap[int, string]).SpliceFront,
+			want:   items,
+		},
+		{
+			name:  "after",
+			items: items,
+			other: other,
+			splice: func(m, other *OrderedMap[int, string]) error {
+				return m.SpliceAfter(other, 1)
+			},
+			want: []Item[int, string]{{1, "one"}, {5, "five"}, {4, "four"}, {2, "two"}, {3, "three"}},
+		},
+		{
+			name:  "after back",
+			items: items,
+			other: other,
+			splice: func(m, other *OrderedMap[int, string]) error {
+				return m.SpliceAfter(other, 3)
+			},
+			want: []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}, {5, "five"}, {4, "four"}},
+		},
+		{
+			name:  "before",
+			items: items,
+			other: other,
+			splice: func(m, other *OrderedMap[int, string]) error {
+				return m.SpliceBefore(other, 3)
+			},
+			want: []Item[int, string]{{1, "one"}, {2, "two"}, {5, "five"}, {4, "four"}, {3, "three"}},
+		},
+		{
+			name:  "missing mark",
+			items: items,
+			other: other,
+			splice: func(m, other *OrderedMap[int, string]) error {
+				return m.SpliceAfter(other, 6)
+			},
+			want: items,
+			err:  ErrMarkKeyMissing,
+		},
+		{
+			name:   "key already present",
+			items:  items,
+			other:  []Item[int, string]{{4, "four"}, {2, "other"}},
+			splice: (*OrderedMap[int, string]).SpliceBack,
+			want:   items,
+			err:    ErrKeyAlreadyPresent,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := New[int, string](WithPositionIndex())
+			m.PushBackItems(c.items...)
+			o := New[int, string](WithPositionIndex())
+			o.PushBackItems(c.other...)
+			if err := c.splice(m, o); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+			if c.err != nil {
+				checkAll(t, o, c.other)
+			} else {
+				checkAll(t, o, []Item[int, string]{})
+			}
+		})
+	}
+}
+
+func TestSpliceSelf(t *testing.T) {
+	m := newFromItems(t, []Item[int, string]{{1, "one"}})
+	if err := m.SpliceBack(m); !errors.Is(err, ErrKeyAlreadyPresent) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrKeyAlreadyPresent, err)
+	}
+	checkAll(t, m, []Item[int, string]{{1, "one"}})
+}
+
+func TestSpliceEdits(t *testing.T) {
+	var mEdits, oEdits []Edit[int, string]
+	m := New[int, string](WithWriteHook(func(edits []Edit[int, string]) {
+		mEdits = append(mEdits, edits...)
+	}, 1), WithStats())
+	o := New[int, string](WithWriteHook(func(edits []Edit[int, string]) {
+		oEdits = append(oEdits, edits...)
+	}, 1), WithStats())
+	m.PushBack(1, "one")
+	o.PushBack(2, "two")
+	o.PushBack(3, "three")
+	mEdits, oEdits = nil, nil
+	if err := m.SpliceFront(o); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, []Item[int, string]{{2, "two"}, {3, "three"}, {1, "one"}})
+
+	// replaying the edits of the map reproduces the splice
+	replica := newFromItems(t, []Item[int, string]{{1, "one"}})
+	if err := replica.Apply(mEdits); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, replica, m.Items())
+	if want := []Edit[int, string]{{Op: EditDelete, Key: 2}, {Op: EditDelete, Key: 3}}; len(oEdits) != len(want) || oEdits[0] != want[0] || oEdits[1] != want[1] {
+		t.Fatalf("unexpected edits: want: %v, got %v", want, oEdits)
+	}
+	if s := m.Stats(); s.Inserts != 3 {
+		t.Fatalf("unexpected inserts: want: 3, got %d", s.Inserts)
+	}
+	if s := o.Stats(); s.Deletes != 2 {
+		t.Fatalf("unexpected deletes: want: 2, got %d", s.Deletes)
+	}
+}
+
+func TestSpliceEvict(t *testing.T) {
+	var evicted []int
+	m := New[int, string](WithMaxEntries(3, EvictOldest, func(key int, value string) {
+		evicted = append(evicted, key)
+	}))
+	m.PushBack(1, "one")
+	m.PushBack(2, "two")
+	o := newFromItems(t, []Item[int, string]{{3, "three"}, {4, "four"}})
+	if err := m.SpliceAfter(o, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, []Item[int, string]{{3, "three"}, {4, "four"}, {2, "two"}})
+	if len(evicted) != 1 || evicted[0] != 1 {
+		t.Fatalf("unexpected evicted keys: %v", evicted)
+	}
+}
+
+func TestSpliceNormalizer(t *testing.T) {
+	m := New[string, int](WithKeyNormalizer(strings.ToLower))
+	m.Set("a", 1)
+	o := newFromItems(t, []Item[string, int]{{"B", 2}, {"b", 3}})
+	if err := m.SpliceBack(o); !errors.Is(err, ErrKeyAlreadyPresent) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrKeyAlreadyPresent, err)
+	}
+	o.Delete("b")
+	o.Set("C", 3)
+	if err := m.SpliceBack(o); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, []Item[string, int]{{"a", 1}, {"b", 2}, {"c", 3}})
+}
@@ -0,0 +1,98 @@
+package orderedmap
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+)
+
+// Scan implements the sql.Scanner interface.
+//
+// It replaces the content of the map with the JSON object stored in src,
+// which must be a []byte, a string or nil. A nil src, corresponding to a
+// NULL column, empties the map.
+//
+// Note that some databases, such as PostgreSQL for JSONB columns, do not
+// preserve the order of the members of stored JSON objects. Columns of type
+// JSON should be used instead if the order needs to be preserved.
+func (m *OrderedMap[K, V]) Scan(src any) error {
+	switch src := src.(type) {
+	case nil:
+		m.lazyInit()
+		m.Clear()
+		return nil
+	case []byte:
+		return m.UnmarshalJSON(src)
+	case string:
+		return m.UnmarshalJSON([]byte(src))
+	}
+	return fmt.Errorf("cannot scan %T into an ordered map", src)
+}
+
+// Value implements the driver.Valuer interface.
+//
+// It returns the map encoded as a JSON object, as returned by MarshalJSON,
+// or nil if m is nil.
+func (m *OrderedMap[K, V]) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+	return m.MarshalJSON()
+}
+
+// ScanRows builds an ordered map from the rows of a query result, preserving
+// the order in which rows are returned.
+//
+// For each row, the column named keyCol is scanned into the key and the column
+// named valCol into the value, using the conversion rules of sql.Rows.Scan.
+// All other columns are ignored. keyCol and valCol must be different columns,
+// or an error is returned. If a key appears in more than one row, it returns
+// an error wrapping ErrKeyAlreadyPresent.
+//
+// ScanRows consumes all remaining rows and closes rows before returning.
+func ScanRows[K comparable, V any](rows *sql.Rows, keyCol, valCol string) (*OrderedMap[K, V], error) {
+	defer rows.Close()
+	if keyCol == valCol {
+		return nil, fmt.Errorf("key and value cannot be scanned from the same column %q", keyCol)
+	}
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	keyIdx, valIdx := -1, -1
+	for i, col := range cols {
+		switch col {
+		case keyCol:
+			keyIdx = i
+		case valCol:
+			valIdx = i
+		}
+	}
+	if keyIdx < 0 {
+		return nil, fmt.Errorf("column %q not found", keyCol)
+	}
+	if valIdx < 0 {
+		return nil, fmt.Errorf("column %q not found", valCol)
+	}
+	dest := make([]any, len(cols))
+	for i := range dest {
+		dest[i] = new(any)
+	}
+	m := New[K, V]()
+	for rows.Next() {
+		var key K
+		var value V
+		dest[keyIdx] = &key
+		dest[valIdx] = &value
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		if err := m.PushBack(key, value); err != nil {
+			return nil, fmt.Errorf("cannot scan key %v: %w", key, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
@@ -0,0 +1,250 @@
+package orderedmap
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+var (
+	_ sql.Scanner   = (*OrderedMap[string, int])(nil)
+	_ driver.Valuer = (*OrderedMap[string, int])(nil)
+)
+
+func TestScan(t *testing.T) {
+	cases := []struct {
+		name  string
+		src   any
+		items []Item[string, int]
+		want  []Item[string, int]
+		err   error
+		fails bool
+	}{
+		{
+			name:  "nil",
+			src:   nil,
+			items: []Item[string, int]{{"z", 26}},
+			want:  []Item[string, int]{},
+		},
+		{
+			name: "bytes",
+			src:  []byte(`{"b": 2, "a": 1}`),
+			want: []Item[string, int]{{"b", 2}, {"a", 1}},
+		},
+		{
+			name: "string",
+			src:  `{"b": 2, "a": 1}`,
+			want: []Item[string, int]{{"b", 2}, {"a", 1}},
+		},
+		{
+			name: "duplicate key",
+			src:  `{"a": 1, "a": 1}`,
+			want: []Item[string, int]{{"a", 1}},
+			err:  ErrKeyAlreadyPresent,
+		},
+		{
+			name:  "unsupported type",
+			src:   42,
+			want:  []Item[string, int]{},
+			fails: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			err := m.Scan(c.src)
+			if c.fails {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+			} else if !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestScanZeroValue(t *testing.T) {
+	var m OrderedMap[string, int]
+	if err := m.Scan(nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, &m, []Item[string, int]{})
+}
+
+func TestValue(t *testing.T) {
+	m := newFromItems(t, []Item[string, int]{{"b", 2}, {"a", 1}})
+	got, err := m.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, ok := got.([]byte)
+	if !ok {
+		t.Fatalf("unexpected type %T", got)
+	}
+	if want := `{"b":2,"a":1}`; string(b) != want {
+		t.Fatalf("unexpected value: want: %s, got %s", want, b)
+	}
+
+	// a driver.Value must be usable as a Scan source
+	out := New[string, int]()
+	if err := out.Scan(got); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, out, m.Items())
+}
+
+func TestValueNil(t *testing.T) {
+	var m *OrderedMap[string, int]
+	got, err := m.Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("unexpected value: %v", got)
+	}
+}
+
+// testDriver is a minimal database/sql driver whose queries return the
+// rows of the table registered with the name used as query text.
+type testDriver struct{}
+
+type testConn struct{}
+
+type testStmt struct{ query string }
+
+type testRows struct {
+	cols []string
+	rows [][]driver.Value
+}
+
+var testTables = map[string]*testRows{
+	"users": {
+		cols: []string{"id", "name", "age"},
+		rows: [][]driver.Value{{int64(3), "carol", int64(41)}, {int64(1), "alice", int64(30)}, {int64(2), "bob", nil}},
+	},
+	"duplicates": {
+		cols: []string{"id", "name"},
+		rows: [][]driver.Value{{int64(1), "alice"}, {int64(1), "bob"}},
+	},
+}
+
+func init() {
+	sql.Register("orderedmap-test", testDriver{})
+}
+
+func (testDriver) Open(string) (driver.Conn, error) { return testConn{}, nil }
+
+func (testConn) Prepare(query string) (driver.Stmt, error) { return testStmt{query}, nil }
+func (testConn) Close() error                              { return nil }
+func (testConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+func (testStmt) Close() error  { return nil }
+func (testStmt) NumInput() int { return 0 }
+func (testStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+func (s testStmt) Query([]driver.Value) (driver.Rows, error) {
+	t, ok := testTables[s.query]
+	if !ok {
+		return nil, errors.New("no such table")
+	}
+	return &testRows{cols: t.cols, rows: t.rows}, nil
+}
+
+func (r *testRows) Columns() []string { return r.cols }
+func (r *testRows) Close() error      { return nil }
+func (r *testRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func query(t *testing.T, table string) *sql.Rows {
+	t.Helper()
+	db, err := sql.Open("orderedmap-test", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	rows, err := db.Query(table)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return rows
+}
+
+func TestScanRows(t *testing.T) {
+	m, err := ScanRows[int, string](query(t, "users"), "id", "name")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, []Item[int, string]{{3, "carol"}, {1, "alice"}, {2, "bob"}})
+}
+
+func TestScanRowsNullableValue(t *testing.T) {
+	m, err := ScanRows[string, sql.NullInt64](query(t, "users"), "name", "age")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, m, []Item[string, sql.NullInt64]{
+		{"carol", sql.NullInt64{Int64: 41, Valid: true}},
+		{"alice", sql.NullInt64{Int64: 30, Valid: true}},
+		{"bob", sql.NullInt64{}},
+	})
+}
+
+func TestScanRowsErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		table  string
+		keyCol string
+		valCol string
+		err    error
+	}{
+		{
+			name:   "missing key column",
+			table:  "users",
+			keyCol: "email",
+			valCol: "name",
+		},
+		{
+			name:   "missing value column",
+			table:  "users",
+			keyCol: "id",
+			valCol: "email",
+		},
+		{
+			name:   "duplicate key",
+			table:  "duplicates",
+			keyCol: "id",
+			valCol: "name",
+			err:    ErrKeyAlreadyPresent,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := ScanRows[int, string](query(t, c.table), c.keyCol, c.valCol)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if c.err != nil && !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+		})
+	}
+}
+
+func TestScanRowsSameColumn(t *testing.T) {
+	_, err := ScanRows[string, string](query(t, "users"), "name", "name")
+	if err == nil || !strings.Contains(err.Error(), "same column") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package orderedmap
+
+// Stats holds counts of the operations performed on an ordered map.
+type Stats struct {
+	// Gets is the number of lookups performed by Get, GetOrCompute,
+	// GetOrLoad and Touch.
+	Gets uint64
+
+	// Hits and Misses are the number of lookups that respectively found
+	// and did not find the key looked up.
+	Hits, Misses uint64
+
+	// Inserts, Deletes and Moves are respectively the number of keys
+	// inserted, deleted, including by Clear and by evictions, and moved,
+	// including by accesses to maps in access order.
+	Inserts, Deletes, Moves uint64
+
+	// Evictions is the number of keys evicted from a bounded map.
+	Evictions uint64
+
+	// Len is the number of items in the map.
+	Len int
+}
+
+// WithStats configures the map to count the operations performed on it,
+// which are reported by Stats. Copies of the map, such as those returned by
+// Clone, do not count their operations.
+func WithStats() Option {
+	return func(o *options) {
+		o.stats = true
+	}
+}
+
+// Stats returns counts of the operations performed on the map. Unless the
+// map has been configured with WithStats, only the length is reported.
+func (m *OrderedMap[K, V]) Stats() Stats {
+	var s Stats
+	if m.stats != nil {
+		s = *m.stats
+	}
+	s.Len = m.Len()
+	return s
+}
+
+// recordGet counts a lookup of a key, which has been found if hit is set.
+func (m *OrderedMap[K, V]) recordGet(hit bool) {
+	if m.stats == nil {
+		return
+	}
+	m.stats.Gets++
+	if hit {
+		m.stats.Hits++
+	} else {
+		m.stats.Misses++
+	}
+}
@@ -0,0 +1,66 @@
+package orderedmap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStats(t *testing.T) {
+	m := New[int, string](WithStats(), WithMaxEntries[int, string](3, EvictOldest, nil))
+	m.Set(1, "one")
+	m.Set(2, "two")
+	m.Get(1)
+	m.Get(3)
+	m.Touch(2)
+	m.Touch(4)
+	m.GetOrCompute(3, func() string { return "three" })
+	m.Set(4, "four")
+	m.Delete(3)
+	if err := m.MoveToBack(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := Stats{
+		Gets:      5,
+		Hits:      2,
+		Misses:    3,
+		Inserts:   4,
+		Deletes:   2,
+		Moves:     2,
+		Evictions: 1,
+		Len:       2,
+	}
+	if diff := cmp.Diff(want, m.Stats()); diff != "" {
+		t.Fatalf("unexpected stats (-want +got):\n%s", diff)
+	}
+
+	m.Clear()
+	want.Deletes += 2
+	want.Len = 0
+	if diff := cmp.Diff(want, m.Stats()); diff != "" {
+		t.Fatalf("unexpected stats (-want +got):\n%s", diff)
+	}
+
+	// copies do not count operations
+	m.Set(1, "one")
+	clone := m.Clone()
+	clone.Get(1)
+	if diff := cmp.Diff(Stats{Len: 1}, clone.Stats()); diff != "" {
+		t.Fatalf("unexpected stats (-want +got):\n%s", diff)
+	}
+}
+
+func TestStatsDisabled(t *testing.T) {
+	m := New[int, string](WithLoader(func(ctx context.Context, key int) (string, error) {
+		return "loaded", nil
+	}))
+	m.Set(1, "one")
+	m.Get(1)
+	if _, err := m.GetOrLoad(context.Background(), 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(Stats{Len: 2}, m.Stats()); diff != "" {
+		t.Fatalf("unexpected stats (-want +got):\n%s", diff)
+	}
+}
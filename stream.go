@@ -0,0 +1,259 @@
+package orderedmap
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// maxBinaryChunk is the largest encoded key or value size for which a buffer
+// is allocated upfront when decoding, so that corrupted lengths cannot cause
+// huge allocations.
+const maxBinaryChunk = 1 << 16
+
+// Codec encodes and decodes keys or values of type T in the binary encoding
+// of ordered maps, produced by WriteTo and MarshalBinary.
+//
+// Both functions must be set.
+type Codec[T any] struct {
+	// Encode appends the encoding of v to b and returns the extended buffer.
+	Encode func(b []byte, v T) ([]byte, error)
+
+	// Decode decodes data into the value pointed to by v. It must copy data
+	// if it needs to retain it after returning.
+	Decode func(data []byte, v *T) error
+}
+
+// WithKeyCodec configures the map to encode keys with c in its binary
+// encoding, instead of the default encoding described by MarshalBinary.
+//
+// The type of c must match the key type of the map, or New panics.
+func WithKeyCodec[K any](c Codec[K]) Option {
+	return func(o *options) {
+		o.keyCodec = c
+	}
+}
+
+// WithValueCodec configures the map to encode values with c in its binary
+// encoding, instead of the default encoding described by MarshalBinary.
+//
+// The type of c must match the value type of the map, or New panics.
+func WithValueCodec[V any](c Codec[V]) Option {
+	return func(o *options) {
+		o.valueCodec = c
+	}
+}
+
+// binaryCodecs returns the codecs of the keys and values of the map.
+func (m *OrderedMap[K, V]) binaryCodecs() (Codec[K], Codec[V]) {
+	return codecOption[K](m.opts.keyCodec), codecOption[V](m.opts.valueCodec)
+}
+
+// codecOption returns the codec stored in an option, or the default codec if
+// none is set.
+func codecOption[T any](v any) Codec[T] {
+	if c, ok := v.(Codec[T]); ok {
+		return c
+	}
+	return BinaryCodec[T]()
+}
+
+// BinaryCodec returns the codec used by default to encode keys and values of
+// type T in the binary encoding of ordered maps, as described by
+// MarshalBinary.
+func BinaryCodec[T any]() Codec[T] {
+	return Codec[T]{
+		Encode: func(b []byte, v T) ([]byte, error) {
+			payload, err := marshalBinaryValue(v)
+			return append(b, payload...), err
+		},
+		Decode: func(data []byte, v *T) error {
+			return unmarshalBinaryValue(data, v)
+		},
+	}
+}
+
+// WriteTo implements the io.WriterTo interface.
+//
+// It writes the binary encoding of the map, as produced by MarshalBinary, to
+// w, one item at a time, so that large maps can be written without buffering
+// their whole encoding in memory. It returns the number of bytes written.
+func (m *OrderedMap[K, V]) WriteTo(w io.Writer) (int64, error) {
+	keyCodec, valueCodec := m.binaryCodecs()
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	b := appendUvarint([]byte{binaryVersion}, uint64(m.Len()))
+	if _, err := bw.Write(b); err != nil {
+		return cw.n, err
+	}
+	if m.m != nil {
+		var err error
+		for el := m.l.Front(); el != nil; el = el.Next() {
+			if b, err = keyCodec.Encode(b[:0], el.Value.Key); err != nil {
+				return cw.n, fmt.Errorf("cannot encode key %v: %w", el.Value.Key, err)
+			}
+			if err = writeBinary(bw, b); err != nil {
+				return cw.n, err
+			}
+			if b, err = valueCodec.Encode(b[:0], el.Value.Value); err != nil {
+				return cw.n, fmt.Errorf("cannot encode value of key %v: %w", el.Value.Key, err)
+			}
+			if err = writeBinary(bw, b); err != nil {
+				return cw.n, err
+			}
+		}
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// writeBinary writes a length-prefixed payload to w.
+func writeBinary(w *bufio.Writer, payload []byte) error {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], uint64(len(payload)))
+	if _, err := w.Write(buf[:n]); err != nil {
+		return err
+	}
+	_, err := w.Write(payload)
+	return err
+}
+
+// ReadFrom implements the io.ReaderFrom interface.
+//
+// It replaces the content of the map with the items read from r, which must
+// have been written by WriteTo or MarshalBinary, and returns the number of
+// bytes read. If the data read is malformed, it returns an error wrapping
+// ErrInvalidBinary.
+//
+// If r does not implement io.ByteReader, it is buffered, so that ReadFrom may
+// read from r past the end of the encoding of the map.
+func (m *OrderedMap[K, V]) ReadFrom(r io.Reader) (int64, error) {
+	m.lazyInit()
+	m.Clear()
+	br, ok := r.(byteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	cr := &countingReader{r: br}
+	version, err := cr.ReadByte()
+	if err != nil {
+		if err == io.EOF {
+			return cr.n, fmt.Errorf("%w: empty data", ErrInvalidBinary)
+		}
+		return cr.n, err
+	}
+	if version != binaryVersion {
+		return cr.n, fmt.Errorf("%w: unsupported version %d", ErrInvalidBinary, version)
+	}
+	n, err := cr.readUvarint()
+	if err != nil {
+		return cr.n, err
+	}
+	keyCodec, valueCodec := m.binaryCodecs()
+	var buf []byte
+	for i := uint64(0); i < n; i++ {
+		var key K
+		if buf, err = readBinary(cr, buf, &key, keyCodec); err != nil {
+			return cr.n, fmt.Errorf("cannot decode key: %w", err)
+		}
+		var value V
+		if buf, err = readBinary(cr, buf, &value, valueCodec); err != nil {
+			return cr.n, fmt.Errorf("cannot decode value of key %v: %w", key, err)
+		}
+		if err := m.PushBack(key, value); err != nil {
+			return cr.n, fmt.Errorf("cannot decode key %v: %w", key, err)
+		}
+	}
+	return cr.n, nil
+}
+
+// readBinary reads a length-prefixed payload from r, using buf as buffer if
+// large enough, decodes it into dst and returns the buffer.
+func readBinary[T any](r *countingReader, buf []byte, dst *T, c Codec[T]) ([]byte, error) {
+	n, err := r.readUvarint()
+	if err != nil {
+		return buf, err
+	}
+	if n > maxBinaryChunk {
+		// grow the buffer as data is read rather than trusting n
+		var b bytes.Buffer
+		if _, err := io.CopyN(&b, r, int64(n)); err != nil {
+			return buf, r.wrap(err)
+		}
+		return buf, c.Decode(b.Bytes(), dst)
+	}
+	if uint64(cap(buf)) < n {
+		buf = make([]byte, n)
+	}
+	buf = buf[:n]
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return buf, r.wrap(err)
+	}
+	return buf, c.Decode(buf, dst)
+}
+
+type byteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// countingReader counts the bytes read from a reader and records the last
+// error returned by it.
+type countingReader struct {
+	r   byteReader
+	n   int64
+	err error
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	r.err = err
+	return n, err
+}
+
+func (r *countingReader) ReadByte() (byte, error) {
+	c, err := r.r.ReadByte()
+	if err == nil {
+		r.n++
+	}
+	r.err = err
+	return c, err
+}
+
+// readUvarint reads an unsigned varint length.
+func (r *countingReader) readUvarint() (uint64, error) {
+	r.err = nil
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		if r.err == nil {
+			return n, fmt.Errorf("%w: malformed length", ErrInvalidBinary)
+		}
+		return n, r.wrap(err)
+	}
+	return n, nil
+}
+
+// wrap returns an error wrapping ErrInvalidBinary if err is caused by the
+// data being truncated, or err otherwise.
+func (r *countingReader) wrap(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return fmt.Errorf("%w: truncated data", ErrInvalidBinary)
+	}
+	return err
+}
+
+// countingWriter counts the bytes written to a writer.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,142 @@
+package orderedmap
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+	"testing/iotest"
+)
+
+func TestWriteToReadFrom(t *testing.T) {
+	items := []Item[string, int]{{"b", 2}, {"a", -1}, {"c", 1 << 40}}
+	m := newFromItems(t, items)
+	var buf bytes.Buffer
+	n, err := m.WriteTo(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != int64(buf.Len()) {
+		t.Fatalf("unexpected count: want: %d, got %d", buf.Len(), n)
+	}
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Equal(data, buf.Bytes()) {
+		t.Fatalf("unexpected encoding: want: %v, got %v", data, buf.Bytes())
+	}
+
+	// data following the encoding is not consumed by byte readers
+	buf.WriteString("trailer")
+	var out OrderedMap[string, int]
+	n, err = out.ReadFrom(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != int64(len(data)) {
+		t.Fatalf("unexpected count: want: %d, got %d", len(data), n)
+	}
+	checkAll(t, &out, items)
+	if buf.String() != "trailer" {
+		t.Fatalf("unexpected remaining data: %q", buf.String())
+	}
+
+	// other readers are buffered
+	out.Clear()
+	n, err = out.ReadFrom(iotest.OneByteReader(bytes.NewReader(data)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != int64(len(data)) {
+		t.Fatalf("unexpected count: want: %d, got %d", len(data), n)
+	}
+	checkAll(t, &out, items)
+}
+
+func TestWriteToLarge(t *testing.T) {
+	m := New[int, []byte]()
+	m.Set(1, bytes.Repeat([]byte{'x'}, maxBinaryChunk+1))
+	m.Set(2, []byte("y"))
+	var buf bytes.Buffer
+	if _, err := m.WriteTo(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := New[int, []byte]()
+	if _, err := out.ReadFrom(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v, _ := out.Get(1); !bytes.Equal(v, m.Items()[0].Value) {
+		t.Fatalf("unexpected value of length %d", len(v))
+	}
+	if v, _ := out.Get(2); string(v) != "y" {
+		t.Fatalf("unexpected value: %q", v)
+	}
+}
+
+func TestStreamErrors(t *testing.T) {
+	m := newFromItems(t, []Item[string, int]{{"a", 1}})
+	data, _ := m.MarshalBinary()
+	errIO := errors.New("i/o error")
+	if _, err := m.WriteTo(errWriter{errIO}); !errors.Is(err, errIO) {
+		t.Fatalf("unexpected error: want: %v, got %v", errIO, err)
+	}
+	if _, err := m.ReadFrom(iotest.ErrReader(errIO)); !errors.Is(err, errIO) {
+		t.Fatalf("unexpected error: want: %v, got %v", errIO, err)
+	}
+	r := io.MultiReader(bytes.NewReader(data[:3]), iotest.ErrReader(errIO))
+	if _, err := m.ReadFrom(r); !errors.Is(err, errIO) {
+		t.Fatalf("unexpected error: want: %v, got %v", errIO, err)
+	}
+	if _, err := m.ReadFrom(bytes.NewReader(data[:3])); !errors.Is(err, ErrInvalidBinary) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrInvalidBinary, err)
+	}
+	if _, err := m.ReadFrom(bytes.NewReader([]byte{binaryVersion, 0x80})); !errors.Is(err, ErrInvalidBinary) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrInvalidBinary, err)
+	}
+}
+
+func TestCodec(t *testing.T) {
+	// values are encoded as decimal strings
+	decimal := Codec[int]{
+		Encode: func(b []byte, v int) ([]byte, error) {
+			return strconv.AppendInt(b, int64(v), 10), nil
+		},
+		Decode: func(data []byte, v *int) error {
+			n, err := strconv.Atoi(string(data))
+			*v = n
+			return err
+		},
+	}
+	m := New[string, int](WithValueCodec(decimal))
+	m.Set("a", 12)
+	data, err := m.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []byte{binaryVersion, 1, 1, 'a', 2, '1', '2'}
+	if !bytes.Equal(data, want) {
+		t.Fatalf("unexpected encoding: want: %v, got %v", want, data)
+	}
+	out := New[string, int](WithValueCodec(decimal))
+	if _, err := out.ReadFrom(bytes.NewReader(data)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, out, []Item[string, int]{{"a", 12}})
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic")
+		}
+	}()
+	New[int, string](WithValueCodec(decimal))
+}
+
+type errWriter struct {
+	err error
+}
+
+func (w errWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
@@ -0,0 +1,72 @@
+package orderedmap
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// TemplateFuncs returns functions for accessing ordered maps from templates
+// of the text/template and html/template packages, which can be registered
+// with their Funcs methods:
+//
+//	tmpl := template.New("page").Funcs(orderedmap.TemplateFuncs())
+//
+// Unlike built-in maps, whose iteration order in templates is sorted by key,
+// ordered maps are iterated in order by ranging over their Items method,
+// whose elements have Key and Value fields:
+//
+//	{{range .M.Items}}{{.Key}}={{.Value}} {{end}}
+//
+// The functions returned complement the methods of ordered maps which cannot
+// be called from templates, and accept any ordered map as first argument:
+//
+//	get m key   the value of key, or the zero value if key is missing
+//	has m key   whether key is present
+//
+// key must be of the key type of the map, or of a type of the same kind,
+// as template constants are untyped: for example {{get .M 1}} can be used
+// with keys of type int, but not int64.
+func TemplateFuncs() map[string]any {
+	return map[string]any{
+		"get": func(m templateMap, key any) (any, error) {
+			return m.templateGet(key)
+		},
+		"has": func(m templateMap, key any) (bool, error) {
+			_, ok, err := m.templateLookup(key)
+			return ok, err
+		},
+	}
+}
+
+// templateMap is the interface of ordered maps used by template functions,
+// which cannot be generic.
+type templateMap interface {
+	templateGet(key any) (any, error)
+	templateLookup(key any) (value any, ok bool, err error)
+}
+
+func (m *OrderedMap[K, V]) templateGet(key any) (any, error) {
+	value, _, err := m.templateLookup(key)
+	return value, err
+}
+
+// templateLookup returns the value of a key of any type, converting it to K
+// if it is of a different type of the same kind. Unlike Get, it does not
+// move the item if the map is in access order.
+func (m *OrderedMap[K, V]) templateLookup(key any) (value any, ok bool, err error) {
+	k, isK := key.(K)
+	if !isK {
+		t := reflect.TypeOf(k)
+		v := reflect.ValueOf(key)
+		if !v.IsValid() || t == nil || v.Kind() != t.Kind() || !v.Type().ConvertibleTo(t) {
+			return nil, false, fmt.Errorf("key of type %T cannot be used with keys of type %T", key, k)
+		}
+		k = v.Convert(t).Interface().(K)
+	}
+	var zero V
+	el, ok := m.m[m.normalize(k)]
+	if !ok {
+		return zero, false, nil
+	}
+	return el.Value.Value, true, nil
+}
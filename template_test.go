@@ -0,0 +1,84 @@
+package orderedmap
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+)
+
+func TestTemplate(t *testing.T) {
+	m := newFromItems(t, []Item[string, int]{{"c", 1}, {"a", 2}, {"b", 3}})
+	ids := newFromItems(t, []Item[int, string]{{2, "two"}, {1, "<one>"}})
+	data := map[string]any{"M": m, "IDs": ids}
+	cases := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "range",
+			text: `{{range .M.Items}}{{.Key}}={{.Value}} {{end}}`,
+			want: "c=1 a=2 b=3 ",
+		},
+		{
+			name: "range with index",
+			text: `{{range $i, $item := .IDs.Items}}{{$i}}:{{$item.Key}} {{end}}`,
+			want: "0:2 1:1 ",
+		},
+		{
+			name: "keys",
+			text: `{{range .M.Keys}}{{.}}{{end}}`,
+			want: "cab",
+		},
+		{
+			name: "get",
+			text: `{{get .M "a"}} {{get .M "d"}} {{get .IDs 2}}`,
+			want: "2 0 two",
+		},
+		{
+			name: "has",
+			text: `{{has .M "a"}} {{has .M "d"}} {{if has .IDs 1}}yes{{end}}`,
+			want: "true false yes",
+		},
+		{
+			name: "len",
+			text: `{{.M.Len}} {{len .IDs.Items}}`,
+			want: "3 2",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			tmpl := template.Must(template.New(c.name).Funcs(TemplateFuncs()).Parse(c.text))
+			var b strings.Builder
+			if err := tmpl.Execute(&b, data); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := b.String(); got != c.want {
+				t.Fatalf("unexpected output: want: %q, got %q", c.want, got)
+			}
+		})
+	}
+}
+
+func TestTemplateHTML(t *testing.T) {
+	m := newFromItems(t, []Item[int, string]{{2, "two"}, {1, "<one>"}})
+	tmpl := htmltemplate.Must(htmltemplate.New("html").Funcs(TemplateFuncs()).Parse(
+		`<ul>{{range .Items}}<li>{{.Value}}</li>{{end}}</ul>{{get . 1}}`))
+	var b strings.Builder
+	if err := tmpl.Execute(&b, m); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "<ul><li>two</li><li>&lt;one&gt;</li></ul>&lt;one&gt;"
+	if got := b.String(); got != want {
+		t.Fatalf("unexpected output: want: %q, got %q", want, got)
+	}
+}
+
+func TestTemplateInvalidKey(t *testing.T) {
+	m := newFromItems(t, []Item[int64, string]{{1, "one"}})
+	tmpl := template.Must(template.New("get").Funcs(TemplateFuncs()).Parse(`{{get . "1"}}`))
+	if err := tmpl.Execute(&strings.Builder{}, m); err == nil {
+		t.Fatal("expected error")
+	}
+}
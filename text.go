@@ -0,0 +1,74 @@
+package orderedmap
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// MarshalText implements the encoding.TextMarshaler interface.
+//
+// Each item is encoded, in order, on its own line as key=value.
+// Keys and values must either implement encoding.TextMarshaler or be strings,
+// booleans, integers or floating point numbers. Keys must not contain '='
+// and neither keys nor values can contain line breaks.
+func (m *OrderedMap[K, V]) MarshalText() ([]byte, error) {
+	var buf bytes.Buffer
+	if m.m == nil {
+		return buf.Bytes(), nil
+	}
+	for el := m.l.Front(); el != nil; el = el.Next() {
+		key, err := formatText(el.Value.Key)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode key %v: %w", el.Value.Key, err)
+		}
+		if strings.ContainsAny(key, "=\r\n") {
+			return nil, fmt.Errorf("cannot encode key %q: contains '=' or line break", key)
+		}
+		value, err := formatText(el.Value.Value)
+		if err != nil {
+			return nil, fmt.Errorf("cannot encode value of key %q: %w", key, err)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, fmt.Errorf("cannot encode value of key %q: contains line break", key)
+		}
+		buf.WriteString(key)
+		buf.WriteByte('=')
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+//
+// It replaces the content of the map with the key=value lines of text,
+// in the order in which they appear. Empty lines are ignored. Each line
+// is split at its first '=', so values may contain '='. If a key appears
+// more than once, it returns an error wrapping ErrKeyAlreadyPresent.
+func (m *OrderedMap[K, V]) UnmarshalText(text []byte) error {
+	m.lazyInit()
+	m.Clear()
+	for i, line := range strings.Split(string(text), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if line == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return fmt.Errorf("line %d: missing '='", i+1)
+		}
+		var key K
+		if err := parseText(k, &key); err != nil {
+			return fmt.Errorf("line %d: cannot decode key %q: %w", i+1, k, err)
+		}
+		var value V
+		if err := parseText(v, &value); err != nil {
+			return fmt.Errorf("line %d: cannot decode value %q: %w", i+1, v, err)
+		}
+		if err := m.PushBack(key, value); err != nil {
+			return fmt.Errorf("line %d: cannot decode key %q: %w", i+1, k, err)
+		}
+	}
+	return nil
+}
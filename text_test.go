@@ -0,0 +1,149 @@
+package orderedmap
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMarshalText(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[string, string]
+		want  string
+		err   bool
+	}{
+		{
+			name:  "empty",
+			items: []Item[string, string]{},
+			want:  "",
+		},
+		{
+			name:  "multiple items",
+			items: []Item[string, string]{{"b", "two"}, {"a", "one=1"}, {"c", ""}},
+			want:  "b=two\na=one=1\nc=\n",
+		},
+		{
+			name:  "key with equal sign",
+			items: []Item[string, string]{{"a=b", "one"}},
+			err:   true,
+		},
+		{
+			name:  "key with line break",
+			items: []Item[string, string]{{"a\nb", "one"}},
+			err:   true,
+		},
+		{
+			name:  "value with line break",
+			items: []Item[string, string]{{"a", "one\r\n"}},
+			err:   true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			got, err := m.MarshalText()
+			if (err != nil) != c.err {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != c.want {
+				t.Fatalf("unexpected text: want: %q, got %q", c.want, got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	cases := []struct {
+		name    string
+		text    string
+		items   []Item[string, int]
+		want    []Item[string, int]
+		err     error
+		anyErr  bool
+		wantErr string
+	}{
+		{
+			name: "empty",
+			text: "",
+			want: []Item[string, int]{},
+		},
+		{
+			name: "multiple items",
+			text: "b=2\r\n\na=1\nc=3",
+			want: []Item[string, int]{{"b", 2}, {"a", 1}, {"c", 3}},
+		},
+		{
+			name:  "replace existing content",
+			text:  "a=1\n",
+			items: []Item[string, int]{{"z", 26}},
+			want:  []Item[string, int]{{"a", 1}},
+		},
+		{
+			name: "duplicate key",
+			text: "a=1\na=2\n",
+			want: []Item[string, int]{{"a", 1}},
+			err:  ErrKeyAlreadyPresent,
+		},
+		{
+			name:    "missing equal sign",
+			text:    "a=1\nb\n",
+			want:    []Item[string, int]{{"a", 1}},
+			anyErr:  true,
+			wantErr: "line 2: missing '='",
+		},
+		{
+			name:   "invalid value",
+			text:   "a=one\n",
+			want:   []Item[string, int]{},
+			anyErr: true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			err := m.UnmarshalText([]byte(c.text))
+			switch {
+			case c.anyErr:
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if c.wantErr != "" && err.Error() != c.wantErr {
+					t.Fatalf("unexpected error: want: %q, got %q", c.wantErr, err)
+				}
+			case !errors.Is(err, c.err):
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestTextRoundTrip(t *testing.T) {
+	items := []Item[time.Duration, float64]{{time.Second, 0.5}, {time.Millisecond, -1e10}}
+	m := newFromItems(t, items)
+	text, err := m.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var out OrderedMap[time.Duration, float64]
+	if err := out.UnmarshalText(text); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, &out, items)
+}
+
+func TestTextMarshalerKeys(t *testing.T) {
+	items := []Item[time.Time, bool]{
+		{time.Date(2023, 4, 8, 10, 0, 0, 0, time.UTC), true},
+		{time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+	m := newFromItems(t, items)
+	text, err := m.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "2023-04-08T10:00:00Z=true\n2022-01-01T00:00:00Z=false\n"; string(text) != want {
+		t.Fatalf("unexpected text: want: %q, got %q", want, text)
+	}
+}
@@ -0,0 +1,62 @@
+package orderedmap
+
+// GroupBy groups the items of an ordered map by the key returned by f.
+//
+// The returned map has a key for each distinct group, in the order in which
+// the first item of each group appears in m, associated to the items of that
+// group in their relative order.
+func GroupBy[K comparable, V any, G comparable](m *OrderedMap[K, V], f func(key K, value V) G) *OrderedMap[G, []Item[K, V]] {
+	out := New[G, []Item[K, V]]()
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		g := f(e.Value.Key, e.Value.Value)
+		if el, ok := out.m[g]; ok {
+			el.Value.Value = append(el.Value.Value, e.Value)
+			continue
+		}
+		out.insert(Item[G, []Item[K, V]]{g, []Item[K, V]{e.Value}}, nil)
+	}
+	return out
+}
+
+// MapValues returns a copy of an ordered map with the same keys in the same
+// order, each associated to the value returned by f for its item.
+func MapValues[K comparable, V1, V2 any](m *OrderedMap[K, V1], f func(key K, value V1) V2) *OrderedMap[K, V2] {
+	out := New[K, V2]()
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		out.insert(Item[K, V2]{e.Value.Key, f(e.Value.Key, e.Value.Value)}, nil)
+	}
+	return out
+}
+
+// MapKeys returns a copy of an ordered map with the same values in the same
+// order, each associated to the key returned by f for its item.
+//
+// Items mapped to a key already returned for a previous item are handled
+// according to policy. If policy is DuplicateError, it returns an error
+// wrapping ErrKeyAlreadyPresent.
+func MapKeys[K1, K2 comparable, V any](m *OrderedMap[K1, V], f func(key K1, value V) K2, policy DuplicatePolicy) (*OrderedMap[K2, V], error) {
+	out := New[K2, V]()
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		if err := out.add(f(e.Value.Key, e.Value.Value), e.Value.Value, policy); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// Invert returns an ordered map associating each value of m to its key,
+// in the order of m.
+//
+// Items whose value has already been found in a previous item are handled
+// according to policy. With DuplicateKeepFirst, each value is associated to
+// the key of its first occurrence. If policy is DuplicateError, it returns an
+// error wrapping ErrKeyAlreadyPresent.
+func Invert[K, V comparable](m *OrderedMap[K, V], policy DuplicatePolicy) (*OrderedMap[V, K], error) {
+	out := New[V, K]()
+	for e := m.l.Front(); e != nil; e = e.Next() {
+		if err := out.add(e.Value.Value, e.Value.Key, policy); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,180 @@
+package orderedmap
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGroupBy(t *testing.T) {
+	byLength := func(key string, value int) int { return len(key) }
+	cases := []struct {
+		name  string
+		items []Item[string, int]
+		want  []Item[int, []Item[string, int]]
+	}{
+		{
+			name:  "empty",
+			items: []Item[string, int]{},
+			want:  []Item[int, []Item[string, int]]{},
+		},
+		{
+			name:  "single group",
+			items: []Item[string, int]{{"a", 1}, {"b", 2}},
+			want: []Item[int, []Item[string, int]]{
+				{1, []Item[string, int]{{"a", 1}, {"b", 2}}},
+			},
+		},
+		{
+			name:  "multiple groups",
+			items: []Item[string, int]{{"bb", 1}, {"a", 2}, {"ccc", 3}, {"dd", 4}, {"e", 5}},
+			want: []Item[int, []Item[string, int]]{
+				{2, []Item[string, int]{{"bb", 1}, {"dd", 4}}},
+				{1, []Item[string, int]{{"a", 2}, {"e", 5}}},
+				{3, []Item[string, int]{{"ccc", 3}}},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			checkAll(t, GroupBy(m, byLength), c.want)
+		})
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[string, int]
+		want  []Item[string, string]
+	}{
+		{
+			name:  "empty",
+			items: []Item[string, int]{},
+			want:  []Item[string, string]{},
+		},
+		{
+			name:  "multiple items",
+			items: []Item[string, int]{{"b", 2}, {"a", 1}, {"c", 3}},
+			want:  []Item[string, string]{{"b", "b2"}, {"a", "a1"}, {"c", "c3"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			got := MapValues(m, func(key string, value int) string {
+				return key + strconv.Itoa(value)
+			})
+			checkAll(t, got, c.want)
+			checkAll(t, m, c.items)
+		})
+	}
+}
+
+func TestMapKeys(t *testing.T) {
+	items := []Item[string, int]{{"b", 1}, {"A", 2}, {"B", 3}, {"c", 4}}
+	cases := []struct {
+		name   string
+		items  []Item[string, int]
+		policy DuplicatePolicy
+		want   []Item[string, int]
+		err    error
+	}{
+		{
+			name:  "empty",
+			items: []Item[string, int]{},
+			want:  []Item[string, int]{},
+		},
+		{
+			name:  "no collisions",
+			items: []Item[string, int]{{"b", 1}, {"a", 2}},
+			want:  []Item[string, int]{{"B", 1}, {"A", 2}},
+		},
+		{
+			name:   "collision error",
+			items:  items,
+			policy: DuplicateError,
+			err:    ErrKeyAlreadyPresent,
+		},
+		{
+			name:   "collision keep first",
+			items:  items,
+			policy: DuplicateKeepFirst,
+			want:   []Item[string, int]{{"B", 1}, {"A", 2}, {"C", 4}},
+		},
+		{
+			name:   "collision keep last",
+			items:  items,
+			policy: DuplicateKeepLast,
+			want:   []Item[string, int]{{"B", 3}, {"A", 2}, {"C", 4}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			got, err := MapKeys(m, func(key string, value int) string {
+				return strings.ToUpper(key)
+			}, c.policy)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("unexpected err: want: %v, got %v", c.err, err)
+			}
+			if err == nil {
+				checkAll(t, got, c.want)
+			}
+		})
+	}
+}
+
+func TestInvert(t *testing.T) {
+	items := []Item[string, int]{{"one", 1}, {"uno", 1}, {"two", 2}}
+	cases := []struct {
+		name   string
+		items  []Item[string, int]
+		policy DuplicatePolicy
+		want   []Item[int, string]
+		err    error
+	}{
+		{
+			name:  "empty",
+			items: []Item[string, int]{},
+			want:  []Item[int, string]{},
+		},
+		{
+			name:  "distinct values",
+			items: []Item[string, int]{{"two", 2}, {"one", 1}},
+			want:  []Item[int, string]{{2, "two"}, {1, "one"}},
+		},
+		{
+			name:   "duplicate error",
+			items:  items,
+			policy: DuplicateError,
+			err:    ErrKeyAlreadyPresent,
+		},
+		{
+			name:   "duplicate keep first",
+			items:  items,
+			policy: DuplicateKeepFirst,
+			want:   []Item[int, string]{{1, "one"}, {2, "two"}},
+		},
+		{
+			name:   "duplicate keep last",
+			items:  items,
+			policy: DuplicateKeepLast,
+			want:   []Item[int, string]{{1, "uno"}, {2, "two"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			got, err := Invert(m, c.policy)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("unexpected err: want: %v, got %v", c.err, err)
+			}
+			if err == nil {
+				checkAll(t, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,398 @@
+// Package ttl implements an ordered map whose items expire.
+//
+// Each item of a Map may be given a time to live, after which it is
+// considered expired. Expired items are never returned and are removed
+// lazily, when accessed, or periodically, by a background goroutine
+// enabled with WithReapInterval.
+//
+// Items are kept in insertion order, as in an orderedmap.OrderedMap.
+// Unlike orderedmap.OrderedMap, Map is safe for concurrent usage.
+package ttl
+
+import (
+	"sync"
+	"time"
+
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+// Map is an ordered map whose items expire.
+//
+// K and V are respectively the types of keys and values.
+type Map[K comparable, V any] struct {
+	mu       sync.Mutex
+	m        *orderedmap.OrderedMap[K, entry[V]]
+	onExpire func(key K, value V)
+	clock    Clock
+
+	// weak holds the positions of the RangeWeak calls in progress
+	weak map[*weakRange[K]]struct{}
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// entry is a value stored in the map together with its expiration time.
+type entry[V any] struct {
+	value V
+
+	// expires is the time at which the entry expires,
+	// or the zero time if it never expires
+	expires time.Time
+}
+
+func (e entry[V]) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
+}
+
+// Clock is a source of time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+
+	// After returns a channel receiving the current time after d has elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is a Clock backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Option configures a Map created with New.
+type Option func(*options)
+
+// options holds the configuration of a Map.
+type options struct {
+	reapInterval time.Duration
+	clock        Clock
+}
+
+// WithClock configures a map to use c, instead of the system clock, both to
+// compute expiration times and to schedule the removal of expired items.
+// It is mostly useful to control time in tests.
+func WithClock(c Clock) Option {
+	return func(o *options) {
+		o.clock = c
+	}
+}
+
+// WithReapInterval configures a map to remove all expired items every
+// interval from a background goroutine, which runs until Stop is called.
+//
+// Without it, expired items are removed only when accessed or when
+// DeleteExpired is called.
+func WithReapInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.reapInterval = interval
+	}
+}
+
+// New returns a new map configured with the options provided.
+func New[K comparable, V any](opts ...Option) *Map[K, V] {
+	o := options{clock: realClock{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	m := &Map[K, V]{
+		m:     orderedmap.New[K, entry[V]](),
+		clock: o.clock,
+		weak:  make(map[*weakRange[K]]struct{}),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	if o.reapInterval > 0 {
+		go m.reap(o.reapInterval)
+	} else {
+		close(m.done)
+	}
+	return m
+}
+
+// reap removes all expired items every interval until the map is stopped.
+func (m *Map[K, V]) reap(interval time.Duration) {
+	defer close(m.done)
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-m.clock.After(interval):
+			m.DeleteExpired()
+		}
+	}
+}
+
+// Stop stops the background goroutine removing expired items, if any, and
+// waits for it to return. It is safe to call Stop more than once.
+func (m *Map[K, V]) Stop() {
+	m.stopOnce.Do(func() { close(m.stop) })
+	<-m.done
+}
+
+// OnExpire registers a function invoked with the key and value of each
+// expired item when it is removed, replacing any function previously
+// registered. It is not invoked for items removed with Delete.
+//
+// f is invoked without holding the lock of the map, so it may access the map.
+func (m *Map[K, V]) OnExpire(f func(key K, value V)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onExpire = f
+}
+
+// Set sets the value associated to a key, which never expires.
+//
+// If the key is already present, its value is updated in place, without
+// changing its position, and replaced is set to true. Otherwise, the key
+// and value are inserted at the back of the map.
+func (m *Map[K, V]) Set(key K, value V) (replaced bool) {
+	return m.SetWithTTL(key, value, 0)
+}
+
+// SetWithTTL sets the value associated to a key, which expires after d.
+// If d is not positive, the value never expires.
+//
+// If the key is already present and not expired, its value and expiration are
+// updated in place, without changing its position, and replaced is set to
+// true. Otherwise, the key and value are inserted at the back of the map.
+func (m *Map[K, V]) SetWithTTL(key K, value V, d time.Duration) (replaced bool) {
+	now := m.clock.Now()
+	e := entry[V]{value: value}
+	if d > 0 {
+		e.expires = now.Add(d)
+	}
+	m.mu.Lock()
+	expired, ok := m.expireLocked(key, now)
+	replaced = m.m.Set(key, e)
+	m.mu.Unlock()
+	m.notify(expired, ok)
+	return replaced
+}
+
+// Get returns the value associated to a key in the map.
+//
+// If the key is not present in the map or is expired, it returns the zero
+// value of V and ok is set to false.
+func (m *Map[K, V]) Get(key K) (value V, ok bool) {
+	now := m.clock.Now()
+	m.mu.Lock()
+	expired, wasExpired := m.expireLocked(key, now)
+	e, ok := m.m.Get(key)
+	m.mu.Unlock()
+	m.notify(expired, wasExpired)
+	return e.value, ok
+}
+
+// TTL returns the remaining time to live of a key. If the key never expires,
+// it returns 0.
+//
+// If the key is not present in the map or is expired, ok is set to false.
+func (m *Map[K, V]) TTL(key K) (ttl time.Duration, ok bool) {
+	now := m.clock.Now()
+	m.mu.Lock()
+	expired, wasExpired := m.expireLocked(key, now)
+	e, ok := m.m.Get(key)
+	m.mu.Unlock()
+	m.notify(expired, wasExpired)
+	if !ok || e.expires.IsZero() {
+		return 0, ok
+	}
+	return e.expires.Sub(now), true
+}
+
+// Delete deletes an item from a map and returns the value deleted.
+//
+// If the item to be deleted was missing from the map or expired, ok is set
+// to false.
+func (m *Map[K, V]) Delete(key K) (value V, ok bool) {
+	now := m.clock.Now()
+	m.mu.Lock()
+	expired, wasExpired := m.expireLocked(key, now)
+	e, ok := m.deleteLocked(key)
+	m.mu.Unlock()
+	m.notify(expired, wasExpired)
+	return e.value, ok
+}
+
+// DeleteExpired removes all expired items from the map and returns the number
+// of items removed.
+func (m *Map[K, V]) DeleteExpired() int {
+	now := m.clock.Now()
+	var expired []orderedmap.Item[K, V]
+	m.mu.Lock()
+	var prev K
+	hasPrev := false
+	m.m.DeleteFunc(func(key K, e entry[V]) bool {
+		if e.expired(now) {
+			m.retreatWeak(key, prev, hasPrev)
+			expired = append(expired, orderedmap.Item[K, V]{Key: key, Value: e.value})
+			return true
+		}
+		prev, hasPrev = key, true
+		return false
+	})
+	onExpire := m.onExpire
+	m.mu.Unlock()
+	if onExpire != nil {
+		for _, item := range expired {
+			onExpire(item.Key, item.Value)
+		}
+	}
+	return len(expired)
+}
+
+// Len returns the number of items stored in the map, including expired items
+// not removed yet. Call DeleteExpired first to count only unexpired items.
+func (m *Map[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.m.Len()
+}
+
+// Range calls f sequentially for each key and value present in the map and
+// not expired, starting from the front element. If f returns false, Range
+// stops the iteration.
+//
+// Range operates on a snapshot of the map, so f may access the map.
+func (m *Map[K, V]) Range(f func(key K, value V) bool) {
+	for _, item := range m.Items() {
+		if !f(item.Key, item.Value) {
+			return
+		}
+	}
+}
+
+// RangeWeak calls f sequentially for each key and value present in the map
+// and not expired, starting from the front element, like Range. If f returns
+// false, RangeWeak stops the iteration.
+//
+// Unlike Range, RangeWeak does not operate on a snapshot of the map and holds
+// the lock of the map only while looking for the next item, so that long
+// iterations do not block writers. In exchange, it is only weakly
+// consistent, as sync.Map.Range: each item is visited at most once, but items
+// inserted or updated during the iteration may or may not be visited, and
+// an item deleted and inserted again may be visited twice.
+//
+// Since items are only inserted at the back of the map, an iteration resumes
+// after the item it last visited, or after the item preceding it if that one
+// has been deleted, in O(1) time.
+func (m *Map[K, V]) RangeWeak(f func(key K, value V) bool) {
+	r := &weakRange[K]{}
+	m.mu.Lock()
+	m.weak[r] = struct{}{}
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		delete(m.weak, r)
+		m.mu.Unlock()
+	}()
+	for {
+		key, value, ok := m.nextWeak(r)
+		if !ok || !f(key, value) {
+			return
+		}
+	}
+}
+
+// weakRange is the position of a RangeWeak call in progress.
+type weakRange[K comparable] struct {
+	// key is the key of the last item scanned, if started is set
+	key     K
+	started bool
+}
+
+// nextWeak advances r to the next item not expired and returns it.
+func (m *Map[K, V]) nextWeak(r *weakRange[K]) (key K, value V, ok bool) {
+	now := m.clock.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var e orderedmap.Entry[K, entry[V]]
+	if r.started {
+		// the key is present, as deletions move r to the preceding item
+		e, _ = m.m.GetEntry(r.key)
+		e, ok = e.Next()
+	} else {
+		e, ok = m.m.FrontEntry()
+	}
+	for ; ok; e, ok = e.Next() {
+		r.key, r.started = e.Key(), true
+		if v := e.Value(); !v.expired(now) {
+			return e.Key(), v.value, true
+		}
+	}
+	return key, value, false
+}
+
+// deleteLocked deletes a key, moving the RangeWeak calls positioned at it to
+// the preceding item. The lock must be held.
+func (m *Map[K, V]) deleteLocked(key K) (entry[V], bool) {
+	if len(m.weak) > 0 {
+		prev, ok := m.m.Prev(key)
+		m.retreatWeak(key, prev.Key, ok)
+	}
+	return m.m.Delete(key)
+}
+
+// retreatWeak moves the RangeWeak calls positioned at a key being deleted to
+// the item preceding it, if any, or to the front of the map otherwise. The
+// lock must be held.
+func (m *Map[K, V]) retreatWeak(key, prev K, ok bool) {
+	for r := range m.weak {
+		if r.started && r.key == key {
+			r.key, r.started = prev, ok
+		}
+	}
+}
+
+// Keys returns the ordered list of keys of the map not expired.
+func (m *Map[K, V]) Keys() []K {
+	items := m.Items()
+	keys := make([]K, len(items))
+	for i, item := range items {
+		keys[i] = item.Key
+	}
+	return keys
+}
+
+// Items returns the ordered list of items of the map not expired.
+func (m *Map[K, V]) Items() []orderedmap.Item[K, V] {
+	now := m.clock.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	items := make([]orderedmap.Item[K, V], 0, m.m.Len())
+	m.m.Range(func(key K, e entry[V]) bool {
+		if !e.expired(now) {
+			items = append(items, orderedmap.Item[K, V]{Key: key, Value: e.value})
+		}
+		return true
+	})
+	return items
+}
+
+// expireLocked removes a key if it is expired and returns its item and
+// the function to notify, if any. The lock must be held.
+func (m *Map[K, V]) expireLocked(key K, now time.Time) (expired expiredItem[K, V], ok bool) {
+	e, ok := m.m.Get(key)
+	if !ok || !e.expired(now) {
+		return expired, false
+	}
+	m.deleteLocked(key)
+	return expiredItem[K, V]{orderedmap.Item[K, V]{Key: key, Value: e.value}, m.onExpire}, true
+}
+
+// expiredItem is an item removed because expired, together with the function
+// to notify of its expiration.
+type expiredItem[K comparable, V any] struct {
+	item     orderedmap.Item[K, V]
+	onExpire func(key K, value V)
+}
+
+// notify invokes the expiration callback of an expired item, if ok is true
+// and a callback is registered. The lock must not be held.
+func (m *Map[K, V]) notify(expired expiredItem[K, V], ok bool) {
+	if ok && expired.onExpire != nil {
+		expired.onExpire(expired.item.Key, expired.item.Value)
+	}
+}
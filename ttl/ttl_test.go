@@ -0,0 +1,421 @@
+package ttl
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+var _ Clock = (*testClock)(nil)
+
+// testClock is a Clock advanced manually.
+type testClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []testWaiter
+}
+
+type testWaiter struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newTestClock() *testClock {
+	return &testClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *testClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *testClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, testWaiter{c.now.Add(d), ch})
+	return ch
+}
+
+// Advance advances the clock by d, firing the channels of all elapsed timers.
+func (c *testClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	waiters := c.waiters[:0]
+	for _, w := range c.waiters {
+		if c.now.Before(w.at) {
+			waiters = append(waiters, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = waiters
+}
+
+// WaitForTimers blocks until n timers are pending.
+func (c *testClock) WaitForTimers(n int) {
+	for {
+		c.mu.Lock()
+		pending := len(c.waiters)
+		c.mu.Unlock()
+		if pending >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func newTestMap(t *testing.T) (*Map[string, int], *testClock, *[]orderedmap.Item[string, int]) {
+	t.Helper()
+
+	clock := newTestClock()
+	m := New[string, int](WithClock(clock))
+	var mu sync.Mutex
+	expired := []orderedmap.Item[string, int]{}
+	m.OnExpire(func(key string, value int) {
+		mu.Lock()
+		defer mu.Unlock()
+		expired = append(expired, orderedmap.Item[string, int]{Key: key, Value: value})
+	})
+	return m, clock, &expired
+}
+
+func TestExpiry(t *testing.T) {
+	m, clock, expired := newTestMap(t)
+	m.SetWithTTL("a", 1, time.Second)
+	m.Set("b", 2)
+	m.SetWithTTL("c", 3, 2*time.Second)
+	m.SetWithTTL("d", 4, -time.Second)
+	checkItems(t, m, []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}, {Key: "d", Value: 4}})
+
+	if ttl, ok := m.TTL("a"); !ok || ttl != time.Second {
+		t.Fatalf("unexpected TTL: want: %v (true), got %v (%v)", time.Second, ttl, ok)
+	}
+	if ttl, ok := m.TTL("b"); !ok || ttl != 0 {
+		t.Fatalf("unexpected TTL: want: 0 (true), got %v (%v)", ttl, ok)
+	}
+
+	clock.Advance(time.Second)
+	checkItems(t, m, []orderedmap.Item[string, int]{{Key: "b", Value: 2}, {Key: "c", Value: 3}, {Key: "d", Value: 4}})
+	// expired items are not removed until accessed
+	if n := m.Len(); n != 4 {
+		t.Fatalf("unexpected length: want: 4, got %d", n)
+	}
+	if _, ok := m.Get("a"); ok {
+		t.Fatal("expired key found")
+	}
+	if n := m.Len(); n != 3 {
+		t.Fatalf("unexpected length: want: 3, got %d", n)
+	}
+	if diff := cmp.Diff([]orderedmap.Item[string, int]{{Key: "a", Value: 1}}, *expired); diff != "" {
+		t.Fatalf("unexpected expired items (-want +got):\n%s", diff)
+	}
+
+	clock.Advance(time.Second)
+	if n := m.DeleteExpired(); n != 1 {
+		t.Fatalf("unexpected number of expired items: want: 1, got %d", n)
+	}
+	checkItems(t, m, []orderedmap.Item[string, int]{{Key: "b", Value: 2}, {Key: "d", Value: 4}})
+	if diff := cmp.Diff([]orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "c", Value: 3}}, *expired); diff != "" {
+		t.Fatalf("unexpected expired items (-want +got):\n%s", diff)
+	}
+}
+
+func TestSetWithTTL(t *testing.T) {
+	m, clock, expired := newTestMap(t)
+	m.SetWithTTL("a", 1, time.Second)
+	m.SetWithTTL("b", 2, time.Second)
+
+	// updating an unexpired key extends its life and keeps its position
+	if replaced := m.SetWithTTL("a", 10, 2*time.Second); !replaced {
+		t.Fatal("key not replaced")
+	}
+	clock.Advance(time.Second)
+	checkItems(t, m, []orderedmap.Item[string, int]{{Key: "a", Value: 10}})
+
+	// setting an expired key inserts it at the back
+	m.Set("c", 3)
+	clock.Advance(time.Second)
+	if replaced := m.Set("a", 100); replaced {
+		t.Fatal("expired key replaced")
+	}
+	checkItems(t, m, []orderedmap.Item[string, int]{{Key: "c", Value: 3}, {Key: "a", Value: 100}})
+	if diff := cmp.Diff([]orderedmap.Item[string, int]{{Key: "a", Value: 10}}, *expired); diff != "" {
+		t.Fatalf("unexpected expired items (-want +got):\n%s", diff)
+	}
+}
+
+func TestDelete(t *testing.T) {
+	m, clock, expired := newTestMap(t)
+	m.SetWithTTL("a", 1, time.Second)
+	m.Set("b", 2)
+	if value, ok := m.Delete("b"); !ok || value != 2 {
+		t.Fatalf("unexpected result: want: 2 (true), got %v (%v)", value, ok)
+	}
+	clock.Advance(time.Second)
+	if _, ok := m.Delete("a"); ok {
+		t.Fatal("deleted expired key")
+	}
+	checkItems(t, m, []orderedmap.Item[string, int]{})
+	if diff := cmp.Diff([]orderedmap.Item[string, int]{{Key: "a", Value: 1}}, *expired); diff != "" {
+		t.Fatalf("unexpected expired items (-want +got):\n%s", diff)
+	}
+}
+
+func TestReaper(t *testing.T) {
+	clock := newTestClock()
+	m := New[string, int](WithClock(clock), WithReapInterval(time.Minute))
+	t.Cleanup(m.Stop)
+	done := make(chan orderedmap.Item[string, int], 1)
+	m.OnExpire(func(key string, value int) {
+		done <- orderedmap.Item[string, int]{Key: key, Value: value}
+	})
+	m.SetWithTTL("a", 1, time.Second)
+	m.Set("b", 2)
+
+	clock.WaitForTimers(1)
+	clock.Advance(time.Second)
+	// the item is expired but the reaper has not run yet
+	if n := m.Len(); n != 2 {
+		t.Fatalf("unexpected length: want: 2, got %d", n)
+	}
+
+	clock.Advance(time.Minute)
+	select {
+	case item := <-done:
+		if want := (orderedmap.Item[string, int]{Key: "a", Value: 1}); item != want {
+			t.Fatalf("unexpected expired item: want: %v, got %v", want, item)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("expired item not reaped")
+	}
+	if n := m.Len(); n != 1 {
+		t.Fatalf("unexpected length: want: 1, got %d", n)
+	}
+	m.Stop()
+	m.Stop()
+}
+
+func TestRangeWeak(t *testing.T) {
+	tests := []struct {
+		name string
+		f    func(m *Map[string, int], key string)
+		want []orderedmap.Item[string, int]
+	}{
+		{
+			name: "no writes",
+			f:    func(m *Map[string, int], key string) {},
+			want: []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}},
+		},
+		{
+			name: "update and insert",
+			f: func(m *Map[string, int], key string) {
+				if key == "a" {
+					m.Set("b", 20)
+					m.Set("d", 4)
+				}
+			},
+			want: []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 20}, {Key: "c", Value: 3}, {Key: "d", Value: 4}},
+		},
+		{
+			name: "update visited",
+			f: func(m *Map[string, int], key string) {
+				if key == "b" {
+					m.Set("a", 10)
+				}
+			},
+			want: []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}},
+		},
+		{
+			name: "delete next",
+			f: func(m *Map[string, int], key string) {
+				if key == "a" {
+					m.Delete("b")
+				}
+			},
+			want: []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "c", Value: 3}},
+		},
+		{
+			name: "delete visited",
+			f: func(m *Map[string, int], key string) {
+				if key == "b" {
+					m.Delete("a")
+					m.Delete("b")
+				}
+			},
+			want: []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}},
+		},
+		{
+			name: "reinsert visited",
+			f: func(m *Map[string, int], key string) {
+				if v, _ := m.Get("b"); key == "b" && v == 2 {
+					m.Delete("b")
+					m.Set("b", 20)
+				}
+			},
+			want: []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}, {Key: "b", Value: 20}},
+		},
+		{
+			name: "nested",
+			f: func(m *Map[string, int], key string) {
+				if key == "a" {
+					n := 0
+					m.RangeWeak(func(key string, value int) bool {
+						n++
+						return true
+					})
+					if n != 3 {
+						panic("nested weak range did not visit all items")
+					}
+				}
+			},
+			want: []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "c", Value: 3}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, _, _ := newTestMap(t)
+			m.Set("a", 1)
+			m.Set("b", 2)
+			m.Set("c", 3)
+			got := rangeWeak(m, func(key string, value int) {
+				tt.f(m, key)
+			})
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected items (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRangeWeakExpired(t *testing.T) {
+	m, clock, _ := newTestMap(t)
+	m.SetWithTTL("a", 1, time.Second)
+	m.Set("b", 2)
+	m.SetWithTTL("c", 3, 2*time.Second)
+	got := rangeWeak(m, func(key string, value int) {
+		if key == "a" {
+			clock.Advance(2 * time.Second)
+		}
+	})
+	want := []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+}
+
+func TestRangeWeakStop(t *testing.T) {
+	m, _, _ := newTestMap(t)
+	m.Set("a", 1)
+	m.Set("b", 2)
+	n := 0
+	m.RangeWeak(func(key string, value int) bool {
+		n++
+		return false
+	})
+	if n != 1 {
+		t.Fatalf("unexpected number of items visited: want: 1, got %d", n)
+	}
+	checkItems(t, m, []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}})
+}
+
+func TestRangeWeakDeleteExpired(t *testing.T) {
+	m, clock, _ := newTestMap(t)
+	m.Set("a", 1)
+	m.SetWithTTL("b", 2, time.Second)
+	m.SetWithTTL("c", 3, time.Second)
+	m.Set("d", 4)
+	got := rangeWeak(m, func(key string, value int) {
+		if key == "b" {
+			clock.Advance(time.Second)
+			if n := m.DeleteExpired(); n != 2 {
+				t.Fatalf("unexpected number of expired items: want: 2, got %d", n)
+			}
+		}
+	})
+	want := []orderedmap.Item[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}, {Key: "d", Value: 4}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+}
+
+func TestRangeWeakConcurrent(t *testing.T) {
+	m, _, _ := newTestMap(t)
+	for i := 0; i < 100; i++ {
+		m.Set(strconv.Itoa(i), i)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i += 2 {
+			m.Delete(strconv.Itoa(i))
+			m.Set(strconv.Itoa(i+100), i+100)
+		}
+	}()
+	seen := map[string]bool{}
+	m.RangeWeak(func(key string, value int) bool {
+		if seen[key] {
+			t.Errorf("key visited twice: %q", key)
+		}
+		seen[key] = true
+		return true
+	})
+	wg.Wait()
+	for i := 1; i < 100; i += 2 {
+		if key := strconv.Itoa(i); !seen[key] {
+			t.Errorf("key not visited: %q", key)
+		}
+	}
+}
+
+func checkItems(t *testing.T, m *Map[string, int], items []orderedmap.Item[string, int]) {
+	t.Helper()
+
+	if diff := cmp.Diff(items, m.Items()); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+	keys := []string{}
+	for _, item := range items {
+		keys = append(keys, item.Key)
+	}
+	if diff := cmp.Diff(keys, m.Keys()); diff != "" {
+		t.Fatalf("unexpected keys (-want +got):\n%s", diff)
+	}
+	got := []orderedmap.Item[string, int]{}
+	m.Range(func(key string, value int) bool {
+		got = append(got, orderedmap.Item[string, int]{Key: key, Value: value})
+		return true
+	})
+	if diff := cmp.Diff(items, got); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+	if got := rangeWeak(m, nil); cmp.Diff(items, got) != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", cmp.Diff(items, got))
+	}
+}
+
+// rangeWeak returns the items visited by RangeWeak, calling f with each of
+// them if not nil, and checks that its position is released afterwards.
+func rangeWeak(m *Map[string, int], f func(key string, value int)) []orderedmap.Item[string, int] {
+	got := []orderedmap.Item[string, int]{}
+	m.RangeWeak(func(key string, value int) bool {
+		got = append(got, orderedmap.Item[string, int]{Key: key, Value: value})
+		if f != nil {
+			f(key, value)
+		}
+		return true
+	})
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.weak) != 0 {
+		panic("weak ranges left in progress")
+	}
+	return got
+}
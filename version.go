@@ -0,0 +1,11 @@
+package orderedmap
+
+// checkVersion panics if debug is enabled and the map has been structurally
+// modified since its version was v. It must be called by all methods
+// iterating the map after each invocation of a function provided by the
+// caller, other than those allowing the map to be modified.
+func (m *OrderedMap[K, V]) checkVersion(v uint64) {
+	if debug && m.version != v {
+		panic(ErrConcurrentModification)
+	}
+}
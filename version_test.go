@@ -0,0 +1,52 @@
+package orderedmap
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRangeErrConcurrentModification(t *testing.T) {
+	cases := []struct {
+		name   string
+		modify func(m *OrderedMap[int, string])
+		err    error
+	}{
+		{
+			name:   "update",
+			modify: func(m *OrderedMap[int, string]) { m.Update(2, "TWO") },
+		},
+		{
+			name:   "insert",
+			modify: func(m *OrderedMap[int, string]) { m.PushBack(4, "four") },
+			err:    ErrConcurrentModification,
+		},
+		{
+			name:   "delete",
+			modify: func(m *OrderedMap[int, string]) { m.Delete(1) },
+			err:    ErrConcurrentModification,
+		},
+		{
+			name:   "move",
+			modify: func(m *OrderedMap[int, string]) { m.MoveToFront(3) },
+			err:    ErrConcurrentModification,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, []Item[int, string]{{1, "one"}, {2, "two"}, {3, "three"}})
+			n := 0
+			err := m.RangeErr(func(key int, value string) error {
+				if n++; n == 1 {
+					c.modify(m)
+				}
+				return nil
+			})
+			if !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			if c.err != nil && n != 1 {
+				t.Fatalf("iteration not stopped: %d items visited", n)
+			}
+		})
+	}
+}
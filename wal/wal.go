@@ -0,0 +1,327 @@
+// Package wal implements a write-ahead log for ordered maps.
+//
+// A Log records the modifications of an ordered map, as notified by a hook
+// registered with orderedmap.WithWriteHook, to an io.Writer, typically an
+// append-only file. After a crash or a restart, replaying the log reconstructs
+// the map:
+//
+//	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+//	...
+//	l := wal.New[string, int](f)
+//	m := orderedmap.New[string, int](orderedmap.WithWriteHook(l.Append, 0))
+//	if _, err := l.Replay(m, f); err != nil {
+//		...
+//	}
+//	m.Set("a", 1) // logged to f
+//
+// Each invocation of the hook is written as a single record, protected by a
+// checksum, and replayed atomically, so that a crash while writing a record
+// never leaves the map partially modified.
+//
+// Logs grow with each modification. They can be compacted by writing a
+// snapshot of the map with WriteTo and starting a new log after it.
+package wal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sync"
+
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+var (
+	// ErrTruncated indicates that a log ends with a partial record
+	ErrTruncated = errors.New("truncated log")
+
+	// ErrCorrupted indicates that a log contains a malformed record
+	ErrCorrupted = errors.New("corrupted log")
+)
+
+// maxRecordSize is the maximum size of a record.
+const maxRecordSize = 1 << 30
+
+// maxRecordChunk is the largest record size for which a buffer is allocated
+// upfront when reading, so that corrupted lengths cannot cause huge
+// allocations.
+const maxRecordChunk = 1 << 16
+
+// Log is a write-ahead log of the modifications of an ordered map.
+//
+// K and V are respectively the types of keys and values.
+type Log[K comparable, V any] struct {
+	mu         sync.Mutex
+	w          io.Writer
+	keyCodec   orderedmap.Codec[K]
+	valueCodec orderedmap.Codec[V]
+	buf        []byte
+	err        error
+	replaying  bool
+}
+
+// New returns a new log writing to w, encoding keys and values as
+// orderedmap.OrderedMap.MarshalBinary does.
+func New[K comparable, V any](w io.Writer) *Log[K, V] {
+	return NewWithCodecs(w, orderedmap.BinaryCodec[K](), orderedmap.BinaryCodec[V]())
+}
+
+// NewWithCodecs returns a new log writing to w, encoding keys and values with
+// the codecs provided.
+func NewWithCodecs[K comparable, V any](w io.Writer, keyCodec orderedmap.Codec[K], valueCodec orderedmap.Codec[V]) *Log[K, V] {
+	return &Log[K, V]{w: w, keyCodec: keyCodec, valueCodec: valueCodec}
+}
+
+// Append writes a record of edits to the log, with a single write to the
+// underlying writer. It is meant to be registered as the hook of an ordered
+// map with orderedmap.WithWriteHook.
+//
+// Since the hook cannot return errors, the first error encountered is
+// retained and returned by Err, and no further records are written.
+func (l *Log[K, V]) Append(edits []orderedmap.Edit[K, V]) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil || l.replaying || len(edits) == 0 {
+		return
+	}
+	l.err = l.write(edits)
+}
+
+// write encodes a record of edits and writes it.
+//
+// A record is encoded as the length of its payload, as an unsigned varint,
+// followed by the payload and by its CRC-32 checksum. The payload is the number
+// of edits followed by each edit, encoded as the byte of its operation followed
+// by the length-prefixed encodings of its key, value and mark, if used by the
+// operation.
+func (l *Log[K, V]) write(edits []orderedmap.Edit[K, V]) error {
+	payload := appendUvarint(l.buf[:0], uint64(len(edits)))
+	for _, e := range edits {
+		var err error
+		payload = append(payload, byte(e.Op))
+		if payload, err = appendField(payload, e.Key, l.keyCodec); err != nil {
+			return fmt.Errorf("cannot encode key %v: %w", e.Key, err)
+		}
+		if hasValue(e.Op) {
+			if payload, err = appendField(payload, e.Value, l.valueCodec); err != nil {
+				return fmt.Errorf("cannot encode value of key %v: %w", e.Key, err)
+			}
+		}
+		if hasMark(e.Op) {
+			if payload, err = appendField(payload, e.Mark, l.keyCodec); err != nil {
+				return fmt.Errorf("cannot encode key %v: %w", e.Mark, err)
+			}
+		}
+	}
+	l.buf = payload
+	record := appendUvarint(make([]byte, 0, len(payload)+binary.MaxVarintLen64+4), uint64(len(payload)))
+	record = append(record, payload...)
+	var sum [4]byte
+	binary.BigEndian.PutUint32(sum[:], crc32.ChecksumIEEE(payload))
+	record = append(record, sum[:]...)
+	_, err := l.w.Write(record)
+	return err
+}
+
+// Err returns the first error encountered writing to the log, if any.
+func (l *Log[K, V]) Err() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.err
+}
+
+// Replay reads records from r and applies their edits to m, which must be in
+// the state it had when the log was started, typically empty. If the log has
+// been written by a bounded map, m may have the same bounds, as items evicted
+// by m while replaying are not expected to be present.
+//
+// If m notifies its modifications to l, they are not written to the log again.
+// Replay returns the size of the valid records read from r. If the log ends
+// with a partial record, as left by a crash while writing it, Replay returns
+// an error wrapping ErrTruncated, and the log should be truncated to the size
+// returned before appending records to it. If a record is malformed, Replay
+// returns an error wrapping ErrCorrupted.
+func (l *Log[K, V]) Replay(m *orderedmap.OrderedMap[K, V], r io.Reader) (int64, error) {
+	l.mu.Lock()
+	l.replaying = true
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		l.replaying = false
+		l.mu.Unlock()
+	}()
+	// edits pending in a batched hook are discarded once replayed
+	defer m.Flush()
+
+	br := bufio.NewReader(r)
+	var size int64
+	for {
+		edits, n, err := l.read(br)
+		if err == io.EOF {
+			return size, nil
+		}
+		if err != nil {
+			return size, fmt.Errorf("record at offset %d: %w", size, err)
+		}
+		if err := m.Apply(edits); err != nil {
+			return size, fmt.Errorf("record at offset %d: %w", size, err)
+		}
+		size += n
+	}
+}
+
+// read reads a record and returns its edits and size. It returns io.EOF if
+// there are no more records.
+func (l *Log[K, V]) read(r *bufio.Reader) ([]orderedmap.Edit[K, V], int64, error) {
+	if _, err := r.Peek(1); err == io.EOF {
+		return nil, 0, io.EOF
+	}
+	cr := &countingReader{r: r}
+	n, err := binary.ReadUvarint(cr)
+	if err != nil {
+		return nil, 0, readError(err)
+	}
+	if n > maxRecordSize {
+		return nil, 0, fmt.Errorf("%w: record of %d bytes", ErrCorrupted, n)
+	}
+	var record []byte
+	if n+4 > maxRecordChunk {
+		// grow the buffer as data is read rather than trusting n
+		var b bytes.Buffer
+		if _, err := io.CopyN(&b, r, int64(n+4)); err != nil {
+			return nil, 0, readError(err)
+		}
+		record = b.Bytes()
+	} else {
+		record = make([]byte, n+4)
+		if _, err := io.ReadFull(r, record); err != nil {
+			return nil, 0, readError(err)
+		}
+	}
+	payload := record[:n]
+	if binary.BigEndian.Uint32(record[n:]) != crc32.ChecksumIEEE(payload) {
+		return nil, 0, fmt.Errorf("%w: checksum mismatch", ErrCorrupted)
+	}
+	edits, err := l.decode(payload)
+	if err != nil {
+		return nil, 0, err
+	}
+	return edits, int64(cr.n) + int64(len(record)), nil
+}
+
+// decode decodes the payload of a record.
+func (l *Log[K, V]) decode(payload []byte) ([]orderedmap.Edit[K, V], error) {
+	n, size := binary.Uvarint(payload)
+	if size <= 0 || n > uint64(len(payload)) {
+		return nil, fmt.Errorf("%w: malformed number of edits", ErrCorrupted)
+	}
+	payload = payload[size:]
+	edits := make([]orderedmap.Edit[K, V], n)
+	for i := range edits {
+		e := &edits[i]
+		if len(payload) == 0 {
+			return nil, fmt.Errorf("%w: missing edit", ErrCorrupted)
+		}
+		e.Op = orderedmap.EditOp(payload[0])
+		if e.Op > orderedmap.EditEvict {
+			return nil, fmt.Errorf("%w: unknown operation %d", ErrCorrupted, payload[0])
+		}
+		payload = payload[1:]
+		var err error
+		if payload, err = parseField(payload, &e.Key, l.keyCodec); err != nil {
+			return nil, err
+		}
+		if hasValue(e.Op) {
+			if payload, err = parseField(payload, &e.Value, l.valueCodec); err != nil {
+				return nil, err
+			}
+		}
+		if hasMark(e.Op) {
+			if payload, err = parseField(payload, &e.Mark, l.keyCodec); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(payload) != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrCorrupted, len(payload))
+	}
+	return edits, nil
+}
+
+// hasValue reports whether edits with an operation have a value.
+func hasValue(op orderedmap.EditOp) bool {
+	switch op {
+	case orderedmap.EditPushFront, orderedmap.EditPushBack, orderedmap.EditInsertAfter,
+		orderedmap.EditInsertBefore, orderedmap.EditUpdate, orderedmap.EditSet:
+		return true
+	}
+	return false
+}
+
+// hasMark reports whether edits with an operation have a mark key.
+func hasMark(op orderedmap.EditOp) bool {
+	switch op {
+	case orderedmap.EditInsertAfter, orderedmap.EditInsertBefore,
+		orderedmap.EditMoveAfter, orderedmap.EditMoveBefore:
+		return true
+	}
+	return false
+}
+
+// appendField appends the length-prefixed encoding of v to b.
+func appendField[T any](b []byte, v T, c orderedmap.Codec[T]) ([]byte, error) {
+	field, err := c.Encode(nil, v)
+	if err != nil {
+		return b, err
+	}
+	b = appendUvarint(b, uint64(len(field)))
+	return append(b, field...), nil
+}
+
+// parseField decodes a length-prefixed field from data into the value pointed
+// to by dst and returns the remaining data.
+func parseField[T any](data []byte, dst *T, c orderedmap.Codec[T]) ([]byte, error) {
+	n, size := binary.Uvarint(data)
+	if size <= 0 || n > uint64(len(data)-size) {
+		return data, fmt.Errorf("%w: malformed length", ErrCorrupted)
+	}
+	data = data[size:]
+	if err := c.Decode(data[:n], dst); err != nil {
+		return data, fmt.Errorf("%w: %v", ErrCorrupted, err)
+	}
+	return data[n:], nil
+}
+
+// readError converts an error reading a record into an error wrapping
+// ErrTruncated if it is caused by the end of the log.
+func readError(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return ErrTruncated
+	}
+	return err
+}
+
+// appendUvarint appends the unsigned varint encoding of x to b.
+func appendUvarint(b []byte, x uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], x)
+	return append(b, buf[:n]...)
+}
+
+// countingReader counts the bytes read with ReadByte.
+type countingReader struct {
+	r io.ByteReader
+	n int
+}
+
+func (r *countingReader) ReadByte() (byte, error) {
+	c, err := r.r.ReadByte()
+	if err == nil {
+		r.n++
+	}
+	return c, err
+}
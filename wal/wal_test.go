@@ -0,0 +1,218 @@
+package wal
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	orderedmap "github.com/lorenzosaino/go-orderedmap"
+)
+
+// modify applies a sequence of modifications covering all edit operations.
+func modify(t *testing.T, m *orderedmap.OrderedMap[string, int]) {
+	t.Helper()
+	m.Set("a", 1)
+	m.Set("b", 2)
+	if err := m.PushFront("c", 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.InsertAfter("d", 4, "c"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.Set("a", 10)
+	if err := m.MoveBefore("b", "c"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.Delete("d")
+	if err := m.MoveToBack("c"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	for _, batch := range []int{0, 3} {
+		var buf bytes.Buffer
+		l := New[string, int](&buf)
+		m := orderedmap.New[string, int](orderedmap.WithWriteHook(l.Append, batch))
+		modify(t, m)
+		m.Flush()
+		if err := l.Err(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		size := int64(buf.Len())
+		out := orderedmap.New[string, int](orderedmap.WithWriteHook(l.Append, batch))
+		n, err := l.Replay(out, bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if n != size {
+			t.Fatalf("unexpected size: want: %d, got %d", size, n)
+		}
+		if diff := cmp.Diff(m.Items(), out.Items()); diff != "" {
+			t.Fatalf("unexpected items (-want +got):\n%s", diff)
+		}
+		// replayed edits are not logged again
+		if int64(buf.Len()) != size {
+			t.Fatalf("log modified by replay: want size %d, got %d", size, buf.Len())
+		}
+
+		// modifications after replay are logged
+		out.Set("e", 5)
+		out.Flush()
+		replica := orderedmap.New[string, int]()
+		if _, err := l.Replay(replica, &buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(out.Items(), replica.Items()); diff != "" {
+			t.Fatalf("unexpected items (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestReplayBounded(t *testing.T) {
+	for _, batch := range []int{0, 3} {
+		var buf bytes.Buffer
+		l := New[string, int](&buf)
+		opts := []orderedmap.Option{
+			orderedmap.WithMaxEntries[string, int](2, orderedmap.EvictOldest, nil),
+			orderedmap.WithWriteHook(l.Append, batch),
+		}
+		m := orderedmap.New[string, int](opts...)
+		m.Set("a", 1)
+		m.Set("b", 2)
+		m.Set("c", 3)
+		if err := m.PushFront("d", 4); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		m.Set("e", 5)
+		m.Flush()
+		if err := l.Err(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		out := orderedmap.New[string, int](opts...)
+		if _, err := l.Replay(out, bytes.NewReader(buf.Bytes())); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(m.Items(), out.Items()); diff != "" {
+			t.Fatalf("unexpected items (-want +got):\n%s", diff)
+		}
+		unbounded := orderedmap.New[string, int]()
+		if _, err := l.Replay(unbounded, bytes.NewReader(buf.Bytes())); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if diff := cmp.Diff(m.Items(), unbounded.Items()); diff != "" {
+			t.Fatalf("unexpected items (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestReplayTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	l := New[string, int](&buf)
+	m := orderedmap.New[string, int](orderedmap.WithWriteHook(l.Append, 0))
+	m.Set("a", 1)
+	size := int64(buf.Len())
+	m.Set("b", 2)
+
+	data := buf.Bytes()[:buf.Len()-1]
+	out := orderedmap.New[string, int]()
+	n, err := l.Replay(out, bytes.NewReader(data))
+	if !errors.Is(err, ErrTruncated) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrTruncated, err)
+	}
+	if n != size {
+		t.Fatalf("unexpected size: want: %d, got %d", size, n)
+	}
+	if diff := cmp.Diff([]orderedmap.Item[string, int]{{Key: "a", Value: 1}}, out.Items()); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+}
+
+func TestReplayLargeRecord(t *testing.T) {
+	var buf bytes.Buffer
+	l := New[string, string](&buf)
+	m := orderedmap.New[string, string](orderedmap.WithWriteHook(l.Append, 0))
+	m.Set("a", strings.Repeat("x", 3*maxRecordChunk))
+	m.Set("b", "y")
+
+	out := orderedmap.New[string, string]()
+	if _, err := l.Replay(out, bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(m.Items(), out.Items()); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+
+	// truncated large records are detected too
+	_, err := l.Replay(orderedmap.New[string, string](), bytes.NewReader(buf.Bytes()[:2*maxRecordChunk]))
+	if !errors.Is(err, ErrTruncated) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrTruncated, err)
+	}
+}
+
+func TestReplayHugeLength(t *testing.T) {
+	// a record claiming to be almost as large as the maximum size
+	data := appendUvarint(nil, maxRecordSize-1)
+	data = append(data, 1, 2, 3)
+	l := New[string, int](io.Discard)
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	_, err := l.Replay(orderedmap.New[string, int](), bytes.NewReader(data))
+	runtime.ReadMemStats(&after)
+	if !errors.Is(err, ErrTruncated) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrTruncated, err)
+	}
+	if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 1<<20 {
+		t.Fatalf("unexpected allocation of %d bytes", allocated)
+	}
+}
+
+func TestReplayCorrupted(t *testing.T) {
+	var buf bytes.Buffer
+	l := New[string, int](&buf)
+	m := orderedmap.New[string, int](orderedmap.WithWriteHook(l.Append, 0))
+	m.Set("a", 1)
+	m.Set("b", 2)
+
+	data := buf.Bytes()
+	data[len(data)-6] ^= 0xff
+	out := orderedmap.New[string, int]()
+	if _, err := l.Replay(out, bytes.NewReader(data)); !errors.Is(err, ErrCorrupted) {
+		t.Fatalf("unexpected error: want: %v, got %v", ErrCorrupted, err)
+	}
+	if diff := cmp.Diff([]orderedmap.Item[string, int]{{Key: "a", Value: 1}}, out.Items()); diff != "" {
+		t.Fatalf("unexpected items (-want +got):\n%s", diff)
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	errWrite := errors.New("write failed")
+	l := New[string, int](errWriter{errWrite})
+	m := orderedmap.New[string, int](orderedmap.WithWriteHook(l.Append, 0))
+	m.Set("a", 1)
+	m.Set("b", 2)
+	if err := l.Err(); !errors.Is(err, errWrite) {
+		t.Fatalf("unexpected error: want: %v, got %v", errWrite, err)
+	}
+
+	unsupported := New[string, []int](&bytes.Buffer{})
+	unsupported.Append([]orderedmap.Edit[string, []int]{{Op: orderedmap.EditSet, Key: "a", Value: []int{1}}})
+	if err := unsupported.Err(); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+type errWriter struct {
+	err error
+}
+
+func (w errWriter) Write(p []byte) (int, error) {
+	return 0, w.err
+}
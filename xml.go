@@ -0,0 +1,105 @@
+package orderedmap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// MarshalXML implements the xml.Marshaler interface.
+//
+// Each item of the map is encoded as a child element of start, in order.
+// Keys are used as element names and values are encoded as element bodies.
+// Keys must either implement encoding.TextMarshaler or be strings or
+// booleans, and their text must be a valid XML name without colons, or an
+// error is returned. Keys of other types, such as integers, cannot form valid
+// names, so maps with such keys must be encoded in other ways.
+func (m *OrderedMap[K, V]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	// when no name is specified, encoding/xml names the element after the
+	// type, which for an instantiated generic type includes its type
+	// arguments and would not be a valid element name.
+	if i := strings.IndexByte(start.Name.Local, '['); i >= 0 {
+		start.Name.Local = start.Name.Local[:i]
+	}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if m.m != nil {
+		for el := m.l.Front(); el != nil; el = el.Next() {
+			name, err := formatText(el.Value.Key)
+			if err != nil {
+				return fmt.Errorf("cannot encode key %v: %w", el.Value.Key, err)
+			}
+			if !isXMLName(name) {
+				return fmt.Errorf("cannot encode key %v: invalid element name %q", el.Value.Key, name)
+			}
+			if err := e.EncodeElement(el.Value.Value, xml.StartElement{Name: xml.Name{Local: name}}); err != nil {
+				return err
+			}
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// isXMLName reports whether s is a valid XML name without colons, as defined
+// by the Name production of the XML 1.0 specification.
+func isXMLName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		if !isXMLNameStartChar(r) && (i == 0 || !isXMLNameChar(r)) {
+			return false
+		}
+	}
+	return true
+}
+
+// isXMLNameStartChar reports whether r can start an XML name, excluding
+// colons.
+func isXMLNameStartChar(r rune) bool {
+	return r >= 'A' && r <= 'Z' || r == '_' || r >= 'a' && r <= 'z' ||
+		r >= 0xC0 && r <= 0xD6 || r >= 0xD8 && r <= 0xF6 || r >= 0xF8 && r <= 0x2FF ||
+		r >= 0x370 && r <= 0x37D || r >= 0x37F && r <= 0x1FFF || r >= 0x200C && r <= 0x200D ||
+		r >= 0x2070 && r <= 0x218F || r >= 0x2C00 && r <= 0x2FEF || r >= 0x3001 && r <= 0xD7FF ||
+		r >= 0xF900 && r <= 0xFDCF || r >= 0xFDF0 && r <= 0xFFFD || r >= 0x10000 && r <= 0xEFFFF
+}
+
+// isXMLNameChar reports whether r can follow the first character of an XML
+// name.
+func isXMLNameChar(r rune) bool {
+	return r == '-' || r == '.' || r >= '0' && r <= '9' || r == 0xB7 ||
+		r >= 0x300 && r <= 0x36F || r >= 0x203F && r <= 0x2040
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface.
+//
+// It replaces the content of the map with the child elements of start, in
+// the order in which they appear in the document. If two child elements
+// have the same name, it returns an error wrapping ErrKeyAlreadyPresent.
+func (m *OrderedMap[K, V]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	m.lazyInit()
+	m.Clear()
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			var key K
+			if err := parseText(t.Name.Local, &key); err != nil {
+				return fmt.Errorf("cannot decode element name %q: %w", t.Name.Local, err)
+			}
+			var value V
+			if err := d.DecodeElement(&value, &t); err != nil {
+				return err
+			}
+			if err := m.PushBack(key, value); err != nil {
+				return fmt.Errorf("cannot decode element %q: %w", t.Name.Local, err)
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package orderedmap
+
+import (
+	"encoding/xml"
+	"errors"
+	"testing"
+)
+
+func TestMarshalXML(t *testing.T) {
+	cases := []struct {
+		name  string
+		items []Item[string, string]
+		want  string
+	}{
+		{
+			name:  "empty",
+			items: []Item[string, string]{},
+			want:  `<OrderedMap></OrderedMap>`,
+		},
+		{
+			name:  "multiple items",
+			items: []Item[string, string]{{"b", "two"}, {"a", "one"}, {"c", "three"}},
+			want:  `<OrderedMap><b>two</b><a>one</a><c>three</c></OrderedMap>`,
+		},
+		{
+			name:  "escaped values",
+			items: []Item[string, string]{{"a", "<one>"}},
+			want:  `<OrderedMap><a>&lt;one&gt;</a></OrderedMap>`,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			got, err := xml.Marshal(m)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != c.want {
+				t.Fatalf("unexpected XML: want: %s, got %s", c.want, got)
+			}
+		})
+	}
+}
+
+func TestMarshalXMLEmptyKey(t *testing.T) {
+	m := newFromItems(t, []Item[string, int]{{"", 1}})
+	if _, err := xml.Marshal(m); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestMarshalXMLInvalidName(t *testing.T) {
+	for _, key := range []string{"1", "-a", ".a", "a b", "a<b", "a:b", "a&b", "\uFFFE"} {
+		m := newFromItems(t, []Item[string, int]{{"a", 1}, {key, 2}})
+		if _, err := xml.Marshal(m); err == nil {
+			t.Fatalf("expected error encoding key %q", key)
+		}
+	}
+	if _, err := xml.Marshal(newFromItems(t, []Item[int, int]{{1, 1}})); err == nil {
+		t.Fatal("expected error encoding integer key")
+	}
+	m := newFromItems(t, []Item[string, int]{{"_a-1.b", 1}, {"é·\u0300", 2}})
+	got, err := xml.Marshal(m)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "<OrderedMap><_a-1.b>1</_a-1.b><é·\u0300>2</é·\u0300></OrderedMap>"; string(got) != want {
+		t.Fatalf("unexpected XML: want: %s, got %s", want, got)
+	}
+}
+
+func TestUnmarshalXML(t *testing.T) {
+	cases := []struct {
+		name  string
+		data  string
+		items []Item[string, int]
+		want  []Item[string, int]
+		err   error
+	}{
+		{
+			name: "empty",
+			data: `<m></m>`,
+			want: []Item[string, int]{},
+		},
+		{
+			name: "multiple items",
+			data: `<m> <b>2</b> <a>1</a> <c>3</c> </m>`,
+			want: []Item[string, int]{{"b", 2}, {"a", 1}, {"c", 3}},
+		},
+		{
+			name:  "replace existing content",
+			data:  `<m><a>1</a></m>`,
+			items: []Item[string, int]{{"z", 26}},
+			want:  []Item[string, int]{{"a", 1}},
+		},
+		{
+			name: "duplicate element",
+			data: `<m><a>1</a><a>2</a></m>`,
+			want: []Item[string, int]{{"a", 1}},
+			err:  ErrKeyAlreadyPresent,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			m := newFromItems(t, c.items)
+			if err := xml.Unmarshal([]byte(c.data), m); !errors.Is(err, c.err) {
+				t.Fatalf("unexpected error: want: %v, got %v", c.err, err)
+			}
+			checkAll(t, m, c.want)
+		})
+	}
+}
+
+func TestUnmarshalXMLInvalidKey(t *testing.T) {
+	m := New[int, string]()
+	if err := xml.Unmarshal([]byte(`<m><a>one</a></m>`), m); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	type envelope struct {
+		XMLName xml.Name                   `xml:"Envelope"`
+		Body    OrderedMap[string, string] `xml:"Body"`
+	}
+
+	in := envelope{Body: *newFromItems(t, []Item[string, string]{{"Zeta", "z"}, {"Alpha", "a"}})}
+	data, err := xml.Marshal(&in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `<Envelope><Body><Zeta>z</Zeta><Alpha>a</Alpha></Body></Envelope>`; string(data) != want {
+		t.Fatalf("unexpected XML: want: %s, got %s", want, data)
+	}
+
+	var out envelope
+	if err := xml.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	checkAll(t, &out.Body, []Item[string, string]{{"Zeta", "z"}, {"Alpha", "a"}})
+}